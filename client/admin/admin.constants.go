@@ -0,0 +1,19 @@
+package admin
+
+import "time"
+
+// TokenDuration is a constant that holds how long a staff member stays logged in with a single token
+const TokenDuration = time.Hour * 24
+
+// TokenSigningPurpose is a constant that separates the key staff tokens are signed with from the app secret key,
+// so a staff token can't be used where an internal token is expected
+const TokenSigningPurpose = "staff_token"
+
+// RevokedTokenKey is a constant that holds the store key format of a staff token that has been logged out
+const RevokedTokenKey = "revoked_staff_token/%s"
+
+// StaffContextKey is a constant that holds the request context key of the authenticated staff member
+const StaffContextKey = "staff"
+
+// StaffClaimContextKey is a constant that holds the request context key of the claims of the authenticated staff member
+const StaffClaimContextKey = "staff_claim"
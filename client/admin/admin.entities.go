@@ -0,0 +1,53 @@
+package admin
+
+import "github.com/dgrijalva/jwt-go"
+
+// StaffClaim is a type that defines the claims of a token issued to a staff member on login,
+// the subject is the id of the staff member
+type StaffClaim struct {
+	Role string `json:"role"`
+	jwt.StandardClaims
+}
+
+// LoginRequest is a type that defines the body of a staff login request
+type LoginRequest struct {
+	Identifier string `json:"identifier"` // Email or phone number
+	Password   string `json:"password"`
+}
+
+// PasswordChangeRequest is a type that defines the body of a request for changing the password of a staff member
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// StaffRequest is a type that defines the body of a request for adding a new staff member
+type StaffRequest struct {
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	Password    string `json:"password"`
+}
+
+// JobStatusRequest is a type that defines the body of a request for changing the status of a job
+type JobStatusRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"` // The reason for declining a job, sent to the employer
+}
+
+// JobAttributeRequest is a type that defines the body of a request for adding or renaming a job attribute
+type JobAttributeRequest struct {
+	Name string `json:"name"`
+}
+
+// FeedbackReplyRequest is a type that defines the body of a request for replying to a feedback
+type FeedbackReplyRequest struct {
+	Message string `json:"message"`
+}
+
+// FeedbackStatusRequest is a type that defines the body of a request for changing the thread status of a feedback
+type FeedbackStatusRequest struct {
+	Status string `json:"status"`
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/gorilla/mux"
+)
+
+// HandleAllJobAttributes is a handler func that returns all the attributes of a job attribute table,
+// the table is one of job_types, job_sectors or education_levels
+func (handler *AdminHandler) HandleAllJobAttributes(w http.ResponseWriter, r *http.Request) {
+
+	tableName := mux.Vars(r)["table"]
+	if err := handler.cmService.ValidateJobAttributeTable(tableName); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	jobAttributes := handler.cmService.AllJobAttributes(tableName)
+	if jobAttributes == nil {
+		jobAttributes = []*entity.JobAttribute{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{tableName: jobAttributes})
+}
+
+// HandleAddJobAttribute is a handler func that adds a new attribute to a job attribute table
+func (handler *AdminHandler) HandleAddJobAttribute(w http.ResponseWriter, r *http.Request) {
+
+	tableName := mux.Vars(r)["table"]
+	if err := handler.cmService.ValidateJobAttributeTable(tableName); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	request := new(admin.JobAttributeRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	jobAttribute := &entity.JobAttribute{Name: request.Name}
+	err = handler.cmService.ValidateJobAttribute(tableName, jobAttribute)
+	if err != nil {
+		writeErrMap(w, entity.ErrMap{"name": err})
+		return
+	}
+
+	err = handler.cmService.AddJobAttribute(jobAttribute, tableName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, jobAttribute)
+}
+
+// HandleUpdateJobAttribute is a handler func that renames an attribute of a job attribute table
+func (handler *AdminHandler) HandleUpdateJobAttribute(w http.ResponseWriter, r *http.Request) {
+
+	tableName := mux.Vars(r)["table"]
+	if err := handler.cmService.ValidateJobAttributeTable(tableName); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	jobAttribute, err := handler.cmService.FindJobAttribute(mux.Vars(r)["id"], tableName)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	request := new(admin.JobAttributeRequest)
+	err = json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	jobAttribute.Name = request.Name
+	err = handler.cmService.ValidateJobAttribute(tableName, jobAttribute)
+	if err != nil {
+		writeErrMap(w, entity.ErrMap{"name": err})
+		return
+	}
+
+	err = handler.cmService.UpdateJobAttribute(jobAttribute, tableName)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobAttribute)
+}
+
+// HandleDeleteJobAttribute is a handler func that deletes an attribute from a job attribute table
+func (handler *AdminHandler) HandleDeleteJobAttribute(w http.ResponseWriter, r *http.Request) {
+
+	tableName := mux.Vars(r)["table"]
+	if err := handler.cmService.ValidateJobAttributeTable(tableName); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	jobAttribute, err := handler.cmService.DeleteJobAttribute(mux.Vars(r)["id"], tableName)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobAttribute)
+}
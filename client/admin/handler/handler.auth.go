@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+)
+
+// HandleLogin is a handler func that verifies the credentials of a staff member and issues a token
+func (handler *AdminHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+
+	signingKey := tokenSigningKey()
+	if signingKey == nil {
+		writeError(w, http.StatusServiceUnavailable, "login is disabled")
+		return
+	}
+
+	request := new(admin.LoginRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	staff, err := handler.stService.VerifyPassword(request.Identifier, request.Password)
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Failed staff login for %s from %s",
+			request.Identifier, r.RemoteAddr), entity.ServerLogFile)
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	now := time.Now()
+	claim := &admin.StaffClaim{Role: staff.Role, StandardClaims: jwt.StandardClaims{
+		Id:        uuid.New().String(),
+		Subject:   staff.ID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(admin.TokenDuration).Unix(),
+	}}
+
+	token, err := tools.GenerateToken(signingKey, claim)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "unable to issue token")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"token": token,
+		"expires_at": time.Unix(claim.ExpiresAt, 0), "staff": staff})
+}
+
+// HandleLogout is a handler func that revokes the token the request has been authorized with
+func (handler *AdminHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+
+	claim, ok := r.Context().Value(entity.Key(admin.StaffClaimContextKey)).(*admin.StaffClaim)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	// The store keeps entries for as long as a token is valid, so the revocation outlives the token
+	handler.store.Add(fmt.Sprintf(admin.RevokedTokenKey, claim.Id), "revoked")
+	writeJSON(w, http.StatusOK, map[string]string{"message": "logged out"})
+}
+
+// HandleGetProfile is a handler func that returns the profile of the logged in staff member
+func (handler *AdminHandler) HandleGetProfile(w http.ResponseWriter, r *http.Request) {
+
+	staff, ok := r.Context().Value(entity.Key(admin.StaffContextKey)).(*entity.Staff)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, staff)
+}
+
+// HandleChangePassword is a handler func that changes the password of the logged in staff member
+func (handler *AdminHandler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	staff, ok := r.Context().Value(entity.Key(admin.StaffContextKey)).(*entity.Staff)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	request := new(admin.PasswordChangeRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	_, err = handler.stService.VerifyPassword(staff.ID, request.CurrentPassword)
+	if err != nil {
+		writeErrMap(w, entity.ErrMap{"current_password": errors.New("invalid password")})
+		return
+	}
+
+	err = handler.stService.ValidatePassword(request.NewPassword)
+	if err != nil {
+		writeErrMap(w, entity.ErrMap{"new_password": err})
+		return
+	}
+
+	err = handler.stService.ChangePassword(staff.ID, request.NewPassword)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"message": "password changed"})
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/gorilla/mux"
+)
+
+// HandleAllFeedbacks is a handler func that returns the feedbacks with the status given in the query, paginated.
+// The status can either be a seen status [Seen, Unseen] or a thread status [Open, Answered, Closed].
+// If a search key is given in the 'q' query only the matching feedbacks are returned.
+func (handler *AdminHandler) HandleAllFeedbacks(w http.ResponseWriter, r *http.Request) {
+
+	var feedbacks []*entity.Feedback
+	var pageCount int64
+
+	status := r.URL.Query().Get("status")
+	pageNum := pageNumber(r)
+
+	if key := strings.TrimSpace(r.URL.Query().Get("q")); key != "" {
+		feedbacks, pageCount = handler.fdService.SearchFeedbacks(key, status, pageNum)
+	} else {
+		feedbacks, pageCount = handler.fdService.AllFeedbacks(status, pageNum)
+	}
+
+	if feedbacks == nil {
+		feedbacks = []*entity.Feedback{}
+	}
+
+	writePage(w, "feedbacks", feedbacks, pageNum, pageCount)
+}
+
+// HandleGetFeedback is a handler func that returns a single feedback
+func (handler *AdminHandler) HandleGetFeedback(w http.ResponseWriter, r *http.Request) {
+
+	feedback, err := handler.fdService.FindFeedback(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feedback)
+}
+
+// HandleMarkFeedbackAsSeen is a handler func that marks a feedback as seen
+func (handler *AdminHandler) HandleMarkFeedbackAsSeen(w http.ResponseWriter, r *http.Request) {
+
+	feedbackID := mux.Vars(r)["id"]
+
+	err := handler.fdService.MarkAsSeen(feedbackID)
+	if err != nil {
+		if err.Error() == "feedback not found" {
+			writeError(w, http.StatusNotFound, err.Error())
+		} else {
+			writeError(w, http.StatusConflict, err.Error())
+		}
+		return
+	}
+
+	feedback, _ := handler.fdService.FindFeedback(feedbackID)
+	writeJSON(w, http.StatusOK, feedback)
+}
+
+// HandleGetFeedbackReplies is a handler func that returns a feedback along with the replies of its thread
+func (handler *AdminHandler) HandleGetFeedbackReplies(w http.ResponseWriter, r *http.Request) {
+
+	feedback, err := handler.fdService.FindFeedback(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	replies := handler.fdService.FindFeedbackReplies(feedback.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"feedback": feedback, "replies": replies})
+}
+
+// HandleReplyToFeedback is a handler func that adds a staff reply to the thread of a feedback
+// and delivers it to the user through the bot
+func (handler *AdminHandler) HandleReplyToFeedback(w http.ResponseWriter, r *http.Request) {
+
+	staff, ok := r.Context().Value(entity.Key(admin.StaffContextKey)).(*entity.Staff)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	request := new(admin.FeedbackReplyRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	reply := &entity.FeedbackReply{FeedbackID: mux.Vars(r)["id"], SenderID: staff.ID,
+		SenderType: entity.FeedbackSenderStaff, Message: strings.TrimSpace(request.Message)}
+
+	errMap := handler.fdService.ValidateFeedbackReply(reply)
+	if errMap != nil {
+		writeErrMap(w, errMap)
+		return
+	}
+
+	feedback, err := handler.fdService.ReplyToFeedback(reply)
+	if err != nil {
+		if err.Error() == "feedback not found" {
+			writeError(w, http.StatusNotFound, err.Error())
+		} else {
+			writeError(w, http.StatusConflict, err.Error())
+		}
+		return
+	}
+
+	// The reply is kept in the thread even if the user can't be reached through the bot
+	delivered := true
+	if err = handler.publisher.DeliverFeedbackReply(feedback, reply); err != nil {
+		delivered = false
+		handler.logger.LogFileError(fmt.Sprintf("Unable to deliver reply %s of feedback %s, %s",
+			reply.ID, feedback.ID, err.Error()), entity.ServerLogFile)
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"feedback": feedback, "reply": reply,
+		"delivered": delivered})
+}
+
+// HandleChangeFeedbackStatus is a handler func that changes the thread status of a feedback
+func (handler *AdminHandler) HandleChangeFeedbackStatus(w http.ResponseWriter, r *http.Request) {
+
+	request := new(admin.FeedbackStatusRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	feedback, err := handler.fdService.ChangeFeedbackStatus(mux.Vars(r)["id"], request.Status)
+	if err != nil {
+		switch err.Error() {
+		case "feedback not found":
+			writeError(w, http.StatusNotFound, err.Error())
+		case "invalid feedback status":
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusConflict, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feedback)
+}
+
+// HandleDeleteFeedback is a handler func that deletes a feedback
+func (handler *AdminHandler) HandleDeleteFeedback(w http.ResponseWriter, r *http.Request) {
+
+	feedback, err := handler.fdService.DeleteFeedback(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, feedback)
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/client/bot/client"
+	"github.com/Benyam-S/asseri/common"
+	"github.com/Benyam-S/asseri/feedback"
+	"github.com/Benyam-S/asseri/job"
+	"github.com/Benyam-S/asseri/log"
+	"github.com/Benyam-S/asseri/staff"
+	"github.com/Benyam-S/asseri/subscription"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/Benyam-S/asseri/user"
+)
+
+// AdminHandler is a struct that defines a handler for the admin api
+type AdminHandler struct {
+	stService staff.IService
+	urService user.IService
+	jbService job.IService
+	sbService subscription.IService
+	fdService feedback.IService
+	cmService common.IService
+	clService client.IService
+	publisher admin.IPublisher
+	store     tools.IStore
+	logger    *log.Logger
+}
+
+// NewAdminHandler is a function that returns a new admin handler
+func NewAdminHandler(staffService staff.IService, userService user.IService, jobService job.IService,
+	subscriptionService subscription.IService, feedbackService feedback.IService, commonService common.IService,
+	clientService client.IService, publisher admin.IPublisher, store tools.IStore,
+	log *log.Logger) *AdminHandler {
+	return &AdminHandler{stService: staffService, urService: userService, jbService: jobService,
+		sbService: subscriptionService, fdService: feedbackService, cmService: commonService,
+		clService: clientService, publisher: publisher, store: store, logger: log}
+}
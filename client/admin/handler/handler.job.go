@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/gorilla/mux"
+)
+
+// HandleAllJobs is a handler func that returns the jobs with the status given in the query, paginated.
+// If a search key is given in the 'q' query only the matching jobs are returned.
+func (handler *AdminHandler) HandleAllJobs(w http.ResponseWriter, r *http.Request) {
+
+	var jobs []*entity.Job
+	var pageCount int64
+
+	status := r.URL.Query().Get("status")
+	pageNum := pageNumber(r)
+
+	if key := strings.TrimSpace(r.URL.Query().Get("q")); key != "" {
+		jobs, pageCount = handler.jbService.SearchJobs(key, status, pageNum)
+	} else {
+		jobs, pageCount = handler.jbService.AllJobsWithPagination(status, pageNum)
+	}
+
+	if jobs == nil {
+		jobs = []*entity.Job{}
+	}
+
+	writePage(w, "jobs", jobs, pageNum, pageCount)
+}
+
+// HandleGetJob is a handler func that returns a single job
+func (handler *AdminHandler) HandleGetJob(w http.ResponseWriter, r *http.Request) {
+
+	job, err := handler.jbService.FindJob(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// HandleChangeJobStatus is a handler func that approves, declines or closes a job and announces the change
+func (handler *AdminHandler) HandleChangeJobStatus(w http.ResponseWriter, r *http.Request) {
+
+	staff, ok := r.Context().Value(entity.Key(admin.StaffContextKey)).(*entity.Staff)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	request := new(admin.JobStatusRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	// Closing a job isn't a review so the previous review is kept
+	var job *entity.Job
+	if request.Status == entity.JobStatusClosed {
+		job, err = handler.jbService.ChangeJobStatus(mux.Vars(r)["id"], request.Status)
+	} else {
+		job, err = handler.jbService.ReviewJob(mux.Vars(r)["id"], request.Status, staff.ID,
+			strings.TrimSpace(request.Note))
+	}
+
+	if err != nil {
+		if err.Error() == "job not found" {
+			writeError(w, http.StatusNotFound, err.Error())
+		} else {
+			writeError(w, http.StatusConflict, err.Error())
+		}
+		return
+	}
+
+	handler.publisher.PublishJobStatus(job)
+	writeJSON(w, http.StatusOK, job)
+}
+
+// HandleDeleteJob is a handler func that deletes a job
+func (handler *AdminHandler) HandleDeleteJob(w http.ResponseWriter, r *http.Request) {
+
+	job, err := handler.jbService.DeleteJob(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// writeJSON is a function that writes the given value as an indented json response
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	output, _ := json.MarshalIndent(value, "", "\t")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(output)
+}
+
+// writeError is a function that writes an error message as a json response
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"error": message})
+}
+
+// writeErrMap is a function that writes the errors of each invalid field as a json response
+func writeErrMap(w http.ResponseWriter, errMap entity.ErrMap) {
+	writeJSON(w, http.StatusBadRequest, map[string]map[string]string{"errors": errMap.StringMap()})
+}
+
+// tokenSigningKey is a function that returns the key staff tokens are signed with, nil if there is no app secret key
+func tokenSigningKey() []byte {
+
+	appSecretKey := os.Getenv(entity.AppSecretKeyName)
+	if appSecretKey == "" {
+		return nil
+	}
+
+	return []byte(tools.GenerateSignature([]byte(appSecretKey), admin.TokenSigningPurpose))
+}
+
+// writePage is a function that writes a page of items along with the pagination metadata as a json response
+func writePage(w http.ResponseWriter, name string, items interface{}, pageNum, pageCount int64) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{name: items,
+		"pagination": map[string]int64{"page": pageNum, "page_count": pageCount}})
+}
+
+// pageNumber is a function that returns the zero based page number given in the request query
+func pageNumber(r *http.Request) int64 {
+	pageNum, _ := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if pageNum < 0 {
+		return 0
+	}
+	return pageNum
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/gorilla/mux"
+)
+
+// HandleAllStaffs is a handler func that returns the staff members with the role given in the query, paginated
+func (handler *AdminHandler) HandleAllStaffs(w http.ResponseWriter, r *http.Request) {
+
+	role := r.URL.Query().Get("role")
+	if role == "" {
+		role = entity.RoleAny
+	}
+
+	pageNum := pageNumber(r)
+	staffs, pageCount := handler.stService.AllStaffs(role, pageNum)
+	if staffs == nil {
+		staffs = []*entity.Staff{}
+	}
+
+	writePage(w, "staffs", staffs, pageNum, pageCount)
+}
+
+// HandleAddStaff is a handler func that adds a new staff member with an initial password
+func (handler *AdminHandler) HandleAddStaff(w http.ResponseWriter, r *http.Request) {
+
+	request := new(admin.StaffRequest)
+	err := json.NewDecoder(r.Body).Decode(request)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request body")
+		return
+	}
+
+	newStaff := &entity.Staff{FirstName: request.FirstName, LastName: request.LastName,
+		PhoneNumber: request.PhoneNumber, Email: request.Email, Role: request.Role}
+
+	errMap := handler.stService.ValidateStaffProfile(newStaff)
+	if errMap == nil {
+		errMap = make(map[string]error)
+	}
+
+	if err := handler.stService.ValidatePassword(request.Password); err != nil {
+		errMap["password"] = err
+	}
+
+	if len(errMap) > 0 {
+		writeErrMap(w, errMap)
+		return
+	}
+
+	err = handler.stService.AddStaff(newStaff, request.Password)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newStaff)
+}
+
+// HandleDeleteStaff is a handler func that deletes a staff member, a staff member can't delete their own account
+func (handler *AdminHandler) HandleDeleteStaff(w http.ResponseWriter, r *http.Request) {
+
+	staffID := mux.Vars(r)["id"]
+
+	currentStaff, ok := r.Context().Value(entity.Key(admin.StaffContextKey)).(*entity.Staff)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "login required")
+		return
+	}
+
+	if currentStaff.ID == staffID {
+		writeError(w, http.StatusConflict, "unable to delete own account")
+		return
+	}
+
+	staff, err := handler.stService.DeleteStaff(staffID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, staff)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// HandleGetStats is a handler func that returns the totals of users, jobs, subscribers and bot clients
+func (handler *AdminHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+
+	users := make(map[string]int64)
+	for _, category := range []string{entity.UserCategoryAny, entity.UserCategoryasseri,
+		entity.UserCategoryAgent, entity.UserCategoryJobSeeker} {
+		users[category] = handler.urService.TotalUsers(category)
+	}
+
+	jobs := make(map[string]int64)
+	for _, status := range []string{entity.JobStatusAny, entity.JobStatusPending, entity.JobStatusOpened,
+		entity.JobStatusClosed, entity.JobStatusDecelined} {
+		jobs[status] = handler.jbService.TotalJobs(status)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"users":           users,
+		"jobs":            jobs,
+		"subscribers":     handler.sbService.TotalSubscribers(),
+		"blocked_clients": handler.clService.CountBlockedClients(),
+	})
+}
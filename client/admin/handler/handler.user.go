@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/gorilla/mux"
+)
+
+// HandleAllUsers is a handler func that returns the users with the category given in the query, paginated.
+// If a search key is given in the 'q' query only the matching users are returned.
+func (handler *AdminHandler) HandleAllUsers(w http.ResponseWriter, r *http.Request) {
+
+	var users []*entity.User
+	var pageCount int64
+
+	category := r.URL.Query().Get("category")
+	pageNum := pageNumber(r)
+
+	if key := strings.TrimSpace(r.URL.Query().Get("q")); key != "" {
+		users, pageCount = handler.urService.SearchUsers(key, category, pageNum)
+	} else {
+		users, pageCount = handler.urService.AllUsersWithPagination(category, pageNum)
+	}
+
+	if users == nil {
+		users = []*entity.User{}
+	}
+
+	writePage(w, "users", users, pageNum, pageCount)
+}
+
+// HandleGetUser is a handler func that returns a single user along with the jobs posted by the user
+func (handler *AdminHandler) HandleGetUser(w http.ResponseWriter, r *http.Request) {
+
+	user, err := handler.urService.FindUser(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	jobs := handler.jbService.FindMultipleJobs(user.ID)
+	if jobs == nil {
+		jobs = []*entity.Job{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user, "jobs": jobs})
+}
+
+// HandleDeleteUser is a handler func that deletes a user, the opened jobs of the user are closed
+func (handler *AdminHandler) HandleDeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	user, err := handler.urService.DeleteUser(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
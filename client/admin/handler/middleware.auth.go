@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/admin"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// Authorize is a method that returns a middleware which only lets requests of logged in staff members
+// with one of the given roles through, entity.RoleAny lets any staff member through.
+// The authenticated staff member is added to the request context.
+func (handler *AdminHandler) Authorize(roles ...string) entity.Middleware {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+
+			signingKey := tokenSigningKey()
+			authorization := r.Header.Get("Authorization")
+
+			if signingKey == nil || !strings.HasPrefix(authorization, "Bearer ") {
+				writeError(w, http.StatusUnauthorized, "login required")
+				return
+			}
+
+			claim := new(admin.StaffClaim)
+			err := tools.ParseToken(strings.TrimPrefix(authorization, "Bearer "), signingKey, claim)
+			if err != nil || handler.store.Get(fmt.Sprintf(admin.RevokedTokenKey, claim.Id)) != "" {
+				writeError(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+
+			// The staff member is loaded again so a deleted account or a changed role takes effect immediately
+			staff, err := handler.stService.FindStaff(claim.Subject)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "invalid or expired token")
+				return
+			}
+
+			if !hasRole(staff.Role, roles) {
+				writeError(w, http.StatusForbidden, "operation not permitted")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), entity.Key(admin.StaffContextKey), staff)
+			ctx = context.WithValue(ctx, entity.Key(admin.StaffClaimContextKey), claim)
+			next(w, r.WithContext(ctx))
+		}
+	}
+}
+
+// hasRole is a function that checks whether the role is one of the allowed roles
+func hasRole(role string, allowedRoles []string) bool {
+	for _, allowedRole := range allowedRoles {
+		if allowedRole == entity.RoleAny || allowedRole == role {
+			return true
+		}
+	}
+	return false
+}
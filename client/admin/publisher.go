@@ -0,0 +1,10 @@
+package admin
+
+import "github.com/Benyam-S/asseri/entity"
+
+// IPublisher is an interface that defines how changes made from the admin api are announced to the users,
+// like notifying the employer of a job status change or delivering a reply to a feedback
+type IPublisher interface {
+	PublishJobStatus(job *entity.Job)
+	DeliverFeedbackReply(feedback *entity.Feedback, reply *entity.FeedbackReply) error
+}
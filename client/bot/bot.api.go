@@ -0,0 +1,265 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ITelegramClient is an interface that defines all the Telegram bot api methods used by the bot
+type ITelegramClient interface {
+	SendMessage(ctx context.Context, request *SendMessageRequest) (*Message, error)
+	SendDocument(ctx context.Context, request *SendDocumentRequest) (*Message, error)
+	UploadDocument(ctx context.Context, request *UploadDocumentRequest) (*Message, error)
+	EditMessageText(ctx context.Context, request *EditMessageTextRequest) (*Message, error)
+	EditMessageReplyMarkup(ctx context.Context, request *EditMessageReplyMarkupRequest) (*Message, error)
+	AnswerCallbackQuery(ctx context.Context, request *AnswerCallbackQueryRequest) error
+	GetChat(ctx context.Context, chatID string) (*Chat, error)
+	GetUpdates(ctx context.Context, request *GetUpdatesRequest) ([]*Update, error)
+	DeleteWebhook(ctx context.Context) error
+}
+
+// TelegramClient is a type that communicates with the Telegram bot api over http
+type TelegramClient struct {
+	apiURL     string
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// NewTelegramClient is a function that returns a new Telegram client for the given api access point and bot token
+func NewTelegramClient(apiAccessPoint, botAPIToken string, timeout time.Duration) ITelegramClient {
+	return &TelegramClient{apiURL: apiAccessPoint + botAPIToken, timeout: timeout,
+		httpClient: &http.Client{}}
+}
+
+// SendMessage is a method that sends a text message to the chat identified in the request
+func (client *TelegramClient) SendMessage(ctx context.Context, request *SendMessageRequest) (*Message, error) {
+
+	message := new(Message)
+	err := client.call(ctx, "sendMessage", url.Values{
+		"chat_id":      {request.ChatID},
+		"text":         {request.Text},
+		"reply_markup": {request.ReplyMarkup},
+		"parse_mode":   {request.ParseMode},
+	}, message)
+
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// SendDocument is a method that sends an already uploaded document to the chat identified in the request
+func (client *TelegramClient) SendDocument(ctx context.Context, request *SendDocumentRequest) (*Message, error) {
+
+	message := new(Message)
+	err := client.call(ctx, "sendDocument", url.Values{
+		"chat_id":      {request.ChatID},
+		"document":     {request.Document},
+		"caption":      {request.Caption},
+		"reply_markup": {request.ReplyMarkup},
+		"parse_mode":   {request.ParseMode},
+	}, message)
+
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// UploadDocument is a method that uploads a new document as a file to the chat identified in the request
+func (client *TelegramClient) UploadDocument(ctx context.Context, request *UploadDocumentRequest) (*Message, error) {
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	fields := map[string]string{
+		"chat_id":      request.ChatID,
+		"caption":      request.Caption,
+		"reply_markup": request.ReplyMarkup,
+		"parse_mode":   request.ParseMode,
+	}
+
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	part, err := writer.CreateFormFile("document", request.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = part.Write(request.Content); err != nil {
+		return nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, client.apiURL+"/sendDocument", body)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", writer.FormDataContentType())
+
+	message := new(Message)
+	err = client.do(httpRequest, message)
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// EditMessageText is a method that replaces the text and the inline keyboard of a message the bot has sent
+func (client *TelegramClient) EditMessageText(ctx context.Context, request *EditMessageTextRequest) (*Message, error) {
+
+	message := new(Message)
+	err := client.call(ctx, "editMessageText", url.Values{
+		"chat_id":      {request.ChatID},
+		"message_id":   {strconv.FormatInt(request.MessageID, 10)},
+		"text":         {request.Text},
+		"reply_markup": {emptyInlineKeyboard(request.ReplyMarkup)},
+		"parse_mode":   {request.ParseMode},
+	}, message)
+
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// EditMessageReplyMarkup is a method that replaces only the inline keyboard of a message the bot has sent
+func (client *TelegramClient) EditMessageReplyMarkup(ctx context.Context,
+	request *EditMessageReplyMarkupRequest) (*Message, error) {
+
+	message := new(Message)
+	err := client.call(ctx, "editMessageReplyMarkup", url.Values{
+		"chat_id":      {request.ChatID},
+		"message_id":   {strconv.FormatInt(request.MessageID, 10)},
+		"reply_markup": {emptyInlineKeyboard(request.ReplyMarkup)},
+	}, message)
+
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// AnswerCallbackQuery is a method that sends a reply to the callback query identified in the request
+func (client *TelegramClient) AnswerCallbackQuery(ctx context.Context, request *AnswerCallbackQueryRequest) error {
+	return client.call(ctx, "answerCallbackQuery", url.Values{
+		"callback_query_id": {request.CallbackQueryID},
+		"text":              {request.Text},
+	}, nil)
+}
+
+// GetChat is a method that returns the up to date information about the chat identified by the chat id
+func (client *TelegramClient) GetChat(ctx context.Context, chatID string) (*Chat, error) {
+
+	chat := new(Chat)
+	err := client.call(ctx, "getChat", url.Values{"chat_id": {chatID}}, chat)
+	if err != nil {
+		return nil, err
+	}
+	return chat, nil
+}
+
+// GetUpdates is a method that receives incoming updates using long polling
+func (client *TelegramClient) GetUpdates(ctx context.Context, request *GetUpdatesRequest) ([]*Update, error) {
+
+	updates := make([]*Update, 0)
+	values := url.Values{
+		"offset":  {strconv.FormatInt(request.Offset, 10)},
+		"timeout": {strconv.FormatInt(request.Timeout, 10)},
+	}
+
+	if request.Limit > 0 {
+		values.Set("limit", strconv.FormatInt(request.Limit, 10))
+	}
+
+	// The request is held open by Telegram for the long polling timeout so it must be added to the client timeout
+	timeout := client.timeout + time.Duration(request.Timeout)*time.Second
+	err := client.callWithTimeout(ctx, timeout, "getUpdates", values, &updates)
+	if err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// DeleteWebhook is a method that removes the webhook integration so updates can be received using getUpdates
+func (client *TelegramClient) DeleteWebhook(ctx context.Context) error {
+	return client.call(ctx, "deleteWebhook", url.Values{}, nil)
+}
+
+// call is a method that posts the given values to a bot api method and decodes the result into the provided value.
+// An unsuccessful api response is returned as *APIError.
+func (client *TelegramClient) call(ctx context.Context, method string, values url.Values, result interface{}) error {
+	return client.callWithTimeout(ctx, client.timeout, method, values, result)
+}
+
+// callWithTimeout is a method that performs a bot api call that is canceled after the given timeout
+func (client *TelegramClient) callWithTimeout(ctx context.Context, timeout time.Duration, method string,
+	values url.Values, result interface{}) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, client.apiURL+"/"+method,
+		strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return client.do(request, result)
+}
+
+// do is a method that sends a prepared bot api request and decodes the result into the provided value.
+// An unsuccessful api response is returned as *APIError.
+func (client *TelegramClient) do(request *http.Request, result interface{}) error {
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	apiResponse := new(APIResponse)
+	err = json.NewDecoder(response.Body).Decode(apiResponse)
+	if err != nil {
+		return errors.New("unable to parse telegram api response")
+	}
+
+	if !apiResponse.Ok {
+		return &APIError{ErrorCode: apiResponse.ErrorCode, Description: apiResponse.Description,
+			RetryAfter: apiResponse.Parameters.RetryAfter}
+	}
+
+	if result != nil && len(apiResponse.Result) > 0 {
+		return json.Unmarshal(apiResponse.Result, result)
+	}
+
+	return nil
+}
+
+// emptyInlineKeyboard is a function that returns an empty inline keyboard in place of an empty reply markup,
+// so the existing keyboard of an edited message is removed explicitly
+func emptyInlineKeyboard(replyMarkup string) string {
+	if replyMarkup == "" {
+		return `{"inline_keyboard":[]}`
+	}
+	return replyMarkup
+}
@@ -1,5 +1,7 @@
 package bot
 
+import "time"
+
 // RegistrationStatusInit is a constant that indicates the temporary user's registration status is in init stage
 const RegistrationStatusInit = 0
 
@@ -12,15 +14,9 @@ const RegistrationStatusPhoneNumber = 2
 // RegistrationStatusCategory is a constant that indicates the temporary user has selected user category
 const RegistrationStatusCategory = 3
 
-// MainMenuW is a constant that holds the main menu value with post job button
-var MainMenuW = CreateReplyKeyboard(true, false,
-	[]string{"📋 Post Job", "💼 Manage Jobs"},
-	[]string{"🔔 Job Subscriptions", "⚙️ Settings"})
-
-// MainMenuWO is a constant that holds the main menu value with out post job button
-var MainMenuWO = CreateReplyKeyboard(true, false,
-	[]string{"💼 Manage Jobs"},
-	[]string{"🔔 Job Subscriptions", "⚙️ Settings"})
+// RegistrationStatusPhoneVerification is a constant that indicates the temporary user has been sent a code
+// to verify the entered phonenumber
+const RegistrationStatusPhoneVerification = 4
 
 // SubscriptionModified is a constant that indicates a subscription field has been added to previously created one
 const SubscriptionModified = 1
@@ -30,3 +26,291 @@ const SubscriptionNotFound = 2
 
 // SubscriptionError is a constant that indicates an error related to modifying subscription
 const SubscriptionError = 3
+
+// UpdateModeWebhook is a constant that states the bot receives updates through a webhook
+const UpdateModeWebhook = "webhook"
+
+// UpdateModePolling is a constant that states the bot receives updates using getUpdates long polling
+const UpdateModePolling = "polling"
+
+// UpdateOffsetKey is a constant that holds the store key of the next update id the poller should request
+const UpdateOffsetKey = "telegram_update_offset"
+
+// PollingTimeout is a constant that holds the getUpdates long polling timeout in seconds
+const PollingTimeout = 25
+
+// SecretTokenHeader is a constant that holds the header Telegram uses to send the webhook secret token
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// HandledUpdateKey is a constant that holds the store key format used to mark an update id as handled
+const HandledUpdateKey = "handled_update/%d"
+
+// PushSignatureHeader is a constant that holds the header carrying the HMAC signature of an internal push request
+const PushSignatureHeader = "X-Asseri-Signature"
+
+// PushTimestampHeader is a constant that holds the header carrying the unix time an internal push request was signed at
+const PushTimestampHeader = "X-Asseri-Timestamp"
+
+// PushSignatureMaxAge is a constant that holds how long in seconds a signed internal push request stays valid
+const PushSignatureMaxAge = 300
+
+// UsedPushSignatureKey is a constant that holds the store key format used to mark the signed message of a push signature as used
+const UsedPushSignatureKey = "used_push_signature/%s"
+
+// PushTokenPurpose is a constant that holds the purpose claim a jwt must have to authenticate an internal push request
+const PushTokenPurpose = "push"
+
+// UsedPushTokenKey is a constant that holds the store key format used to mark the id of a push jwt as used
+const UsedPushTokenKey = "used_push_token/%s"
+
+// GlobalMessageRate is a constant that holds the number of messages per second the bot can send to all chats combined
+const GlobalMessageRate = 30
+
+// ChatMessageRate is a constant that holds the number of messages per second the bot can send to a single chat
+const ChatMessageRate = 1
+
+// ChatMemberKicked is a constant that holds the chat member status Telegram sends when a user blocks the bot
+const ChatMemberKicked = "kicked"
+
+// ChatMemberMember is a constant that holds the chat member status Telegram sends when a user unblocks the bot
+const ChatMemberMember = "member"
+
+// DueJobsCheckInterval is a constant that holds how often the opened jobs are checked for a passed due date
+const DueJobsCheckInterval = time.Minute
+
+// DefaultDueDateReminderHours is a constant that holds how many hours before the due date the employer is reminded
+// if 'due_date_reminder_hours' isn't configured
+const DefaultDueDateReminderHours = 24
+
+// DueDateExtension is a constant that holds how long the "Extend 7 days" reminder button extends a due date by
+const DueDateExtension = time.Hour * 24 * 7
+
+// DueDateLayout is a constant that holds the layout used to show a due date to users
+const DueDateLayout = "Jan 2, 2006 3:04 PM"
+
+// JobDraftTitle is a constant that holds the job posting step that asks for the job title
+const JobDraftTitle = "Post Job Title"
+
+// JobDraftDescription is a constant that holds the job posting step that asks for the job description
+const JobDraftDescription = "Post Job Description"
+
+// JobDraftSector is a constant that holds the job posting step that asks for the job sectors
+const JobDraftSector = "Post Job Sector"
+
+// JobDraftType is a constant that holds the job posting step that asks for the job types
+const JobDraftType = "Post Job Type"
+
+// JobDraftEducationLevel is a constant that holds the job posting step that asks for the education level
+const JobDraftEducationLevel = "Post Job Education Level"
+
+// JobDraftExperience is a constant that holds the job posting step that asks for the work experience
+const JobDraftExperience = "Post Job Experience"
+
+// JobDraftGender is a constant that holds the job posting step that asks for the gender
+const JobDraftGender = "Post Job Gender"
+
+// JobDraftContactType is a constant that holds the job posting step that asks for the contact type
+const JobDraftContactType = "Post Job Contact Type"
+
+// JobDraftDueDate is a constant that holds the job posting step that asks for the optional due date
+const JobDraftDueDate = "Post Job Due Date"
+
+// JobDraftPreview is a constant that holds the job posting step that shows the job before it is submitted
+const JobDraftPreview = "Post Job Preview"
+
+// JobDraftSteps is a value list that holds the job posting steps in order
+var JobDraftSteps = []string{JobDraftTitle, JobDraftDescription, JobDraftSector, JobDraftType,
+	JobDraftEducationLevel, JobDraftExperience, JobDraftGender, JobDraftContactType, JobDraftDueDate,
+	JobDraftPreview}
+
+// DueDateInputLayout is a constant that holds the layout users enter a due date with
+const DueDateInputLayout = "02/01/2006 15:04"
+
+// JobPostTokenKey is a constant that holds the store key format of a single use job posting access token
+const JobPostTokenKey = "job_post_token/%s"
+
+// ModerationReasonKey is a constant that holds the store key format of a moderation action waiting for a reason,
+// identified by the message id of the prompt the moderator replies to
+const ModerationReasonKey = "moderation_reason/%d"
+
+// ModerationApprove is a constant that holds the moderation action that approves a pending job
+const ModerationApprove = "approve"
+
+// ModerationDecline is a constant that holds the moderation action that declines a pending job
+const ModerationDecline = "decline"
+
+// ModerationRequestChanges is a constant that holds the moderation action that asks the employer to change a pending job
+const ModerationRequestChanges = "changes"
+
+// ApplicationShortlist is a constant that holds the employer action that shortlists a job application
+const ApplicationShortlist = "shortlist"
+
+// ApplicationReject is a constant that holds the employer action that rejects a job application
+const ApplicationReject = "reject"
+
+// ApplicationHire is a constant that holds the employer action that hires the job seeker of a job application
+const ApplicationHire = "hire"
+
+// ApplicationMessage is a constant that holds the employer action that sends a message to the job seeker of a job application
+const ApplicationMessage = "message"
+
+// ApplicationsPerPage is a constant that holds the number of applications listed on a single page of "My Applications"
+const ApplicationsPerPage = 5
+
+// ApplicantsPerPage is a constant that holds the number of applicants listed on a single page of a job's applicants
+const ApplicantsPerPage = 10
+
+// StateMainMenu is a constant that holds the conversation state a client starts from and returns to when a flow ends
+const StateMainMenu = "Main Menu"
+
+// StateManageJobs is a constant that holds the conversation state of the manage jobs menu
+const StateManageJobs = "Manage Jobs"
+
+// StatePendingJobs is a constant that holds the conversation state of listing the pending jobs of an employer
+const StatePendingJobs = "Pending"
+
+// StateOpenedJobs is a constant that holds the conversation state of listing the opened jobs of an employer
+const StateOpenedJobs = "Opened"
+
+// StateClosedJobs is a constant that holds the conversation state of listing the closed jobs of an employer
+const StateClosedJobs = "Closed"
+
+// StateDeclinedJobs is a constant that holds the conversation state of listing the declined jobs of an employer
+const StateDeclinedJobs = "Declined"
+
+// StateJobSubscriptions is a constant that holds the conversation state of the job subscriptions menu
+const StateJobSubscriptions = "Job Subscriptions"
+
+// StateAddSubscription is a constant that holds the conversation state that waits for the sector of a new subscription
+const StateAddSubscription = "Add Subscription"
+
+// StateAddSubscriptionSector is a constant that holds the conversation state that waits for the job type of a new subscription
+const StateAddSubscriptionSector = "Add Subscription Sector"
+
+// StateAddSubscriptionType is a constant that holds the conversation state that waits for the education level of a new subscription
+const StateAddSubscriptionType = "Add Subscription Type"
+
+// StateAddSubscriptionEducationLevel is a constant that holds the conversation state that waits for the experience of a new subscription
+const StateAddSubscriptionEducationLevel = "Add Subscription Education Level"
+
+// StateSettings is a constant that holds the conversation state of the settings menu
+const StateSettings = "Settings"
+
+// StateProfile is a constant that holds the conversation state of viewing the profile
+const StateProfile = "Profile"
+
+// StateFeedback is a constant that holds the conversation state that waits for a feedback
+const StateFeedback = "Feedback"
+
+// StateUpdateProfile is a constant that holds the conversation state that waits for a new user name
+const StateUpdateProfile = "Update Profile"
+
+// StateUpdateProfilePhoneNumber is a constant that holds the conversation state that waits for a new phone number
+const StateUpdateProfilePhoneNumber = "Update Profile Phonenumber"
+
+// StateUpdateProfileCategory is a constant that holds the conversation state that waits for a new user category
+const StateUpdateProfileCategory = "Update Profile Category"
+
+// StateNotifications is a constant that holds the conversation state of the notification preferences
+const StateNotifications = "Notifications"
+
+// StateUpdateEmail is a constant that holds the conversation state that waits for the email address of a user
+const StateUpdateEmail = "Update Email"
+
+// StateApply is a constant that holds the conversation state that waits for the cv of a job application
+const StateApply = "Apply"
+
+// StateFeedbackReply is a constant that holds the conversation state that waits for the answer to a reply of a feedback
+const StateFeedbackReply = "Feedback Reply"
+
+// StateMessageApplicant is a constant that holds the conversation state that waits for a message to an applicant
+const StateMessageApplicant = "Message Applicant"
+
+// StateVerifyPhoneNumber is a constant that holds the conversation state of a profile update waiting for the
+// verification code sent to the new phonenumber
+const StateVerifyPhoneNumber = "Verify Phonenumber"
+
+// StateMyApplications is a constant that holds the conversation state of listing the applications of a job seeker
+const StateMyApplications = "My Applications"
+
+// SessionJobID is a constant that holds the session key of the job the current flow is about
+const SessionJobID = "job_id"
+
+// SessionJobSeekerID is a constant that holds the session key of the job seeker the current flow is about
+const SessionJobSeekerID = "job_seeker_id"
+
+// SessionFeedbackID is a constant that holds the session key of the feedback the current flow is about
+const SessionFeedbackID = "feedback_id"
+
+// SessionExpiration is a constant that holds how long the payloads of an abandoned flow are kept before they expire
+const SessionExpiration = time.Hour * 6
+
+// PayloadConversation is a constant that holds the session payload name of the conversation state of a chat
+const PayloadConversation = "conversation"
+
+// PayloadRegistration is a constant that holds the session payload name of the registration entries of a chat
+const PayloadRegistration = "registration"
+
+// PayloadJobDraft is a constant that holds the session payload name of the job that is being posted
+const PayloadJobDraft = "job_draft"
+
+// PayloadSubscriptionDraft is a constant that holds the session payload name of the job subscription that is being added
+const PayloadSubscriptionDraft = "subscription_draft"
+
+// PayloadPhoneVerification is a constant that holds the session payload name of the phonenumber that is being verified
+const PayloadPhoneVerification = "phone_verification"
+
+// PayloadProfileDraft is a constant that holds the session payload name of the profile changes that haven't been saved
+const PayloadProfileDraft = "profile_draft"
+
+// VerificationCodeExpiration is a constant that holds how long a phonenumber verification code stays valid
+const VerificationCodeExpiration = time.Minute * 5
+
+// VerificationCodeResendInterval is a constant that holds how long a chat has to wait before another code can be sent
+const VerificationCodeResendInterval = time.Minute
+
+// VerificationMaxAttempts is a constant that holds how many times a verification code can be entered
+const VerificationMaxAttempts = 3
+
+// VerificationCodeAttemptsKey is a constant that holds the store key format of the number of times a verification code
+// has been entered, identified by the chat and the time the code has been sent
+const VerificationCodeAttemptsKey = "verification/code_attempts/%s/%d"
+
+// VerificationLimitWindow is a constant that holds how long the codes sent and the codes entered are counted,
+// the counts start over once nothing has been counted for that long
+const VerificationLimitWindow = time.Hour * 24
+
+// VerificationMaxSends is a constant that holds how many codes can be sent to a chat or a phonenumber within the limit window
+const VerificationMaxSends = 5
+
+// VerificationMaxTotalAttempts is a constant that holds how many codes a chat or a phonenumber can enter
+// within the limit window, however many codes have been sent
+const VerificationMaxTotalAttempts = 10
+
+// VerificationSendsKey is a constant that holds the store key format of the number of codes sent to a chat or a phonenumber
+const VerificationSendsKey = "verification/sends/%s"
+
+// VerificationAttemptsKey is a constant that holds the store key format of the number of codes entered
+// by a chat or for a phonenumber
+const VerificationAttemptsKey = "verification/attempts/%s"
+
+// VerificationPassed is a constant that indicates the entered verification code is correct
+const VerificationPassed = 1
+
+// VerificationFailed is a constant that indicates the entered verification code is wrong but can be entered again
+const VerificationFailed = 2
+
+// VerificationExpired is a constant that indicates the verification code has expired or has run out of attempts
+const VerificationExpired = 3
+
+// SMSSenderTwilio is a constant that states verification codes and notifications are sent using the twilio sms account
+const SMSSenderTwilio = "twilio"
+
+// SMSSenderLocal is a constant that states verification codes and notifications are only written to the standard output
+const SMSSenderLocal = "local"
+
+// EmailSenderSMTP is a constant that states notification emails are sent using the smtp account
+const EmailSenderSMTP = "smtp"
+
+// EmailSenderLocal is a constant that states notification emails are only written to the standard output
+const EmailSenderLocal = "local"
@@ -1,27 +1,40 @@
 package bot
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Benyam-S/asseri/entity"
+	"github.com/dgrijalva/jwt-go"
 )
 
-// TempUser is a struct that holds the temporary user data before registration
+// PushClaim is a type that defines the claims of a jwt that authenticates a single internal push request,
+// the id of the token is used to make sure it can only be used once
+type PushClaim struct {
+	Purpose string `json:"purpose"`
+	JobID   string `json:"job_id"`
+	jwt.StandardClaims
+}
+
+// TempUser is a struct that holds the temporary user data before registration, it is kept in the session of the chat
 type TempUser struct {
-	TelegramID  string `gorm:"primary_key; unique; not null"`
+	TelegramID  string
 	UserName    string
 	PhoneNumber string
 	Category    string
-	Status      int64 // Used to identify the state of the registration process
+	Language    string // Taken from the language of the telegram app the registration has been started from
+	Status      int64  // Used to identify the state of the registration process
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
 // Client is a struct that defines the relation between user and Telegram bot user
 type Client struct {
-	UserID      string `gorm:"primary_key; unique; not null"`
-	TelegramID  string `gorm:"unique; not null"`
-	PrevCommand string
+	UserID     string `gorm:"primary_key; unique; not null"`
+	TelegramID string `gorm:"unique; not null"`
+	Blocked    bool   `gorm:"not null; default:false"` // Set when the telegram user has blocked the bot
 }
 
 // TableName overrides the table name used by Client to `bot_clients`
@@ -29,37 +42,105 @@ func (Client) TableName() string {
 	return "bot_clients"
 }
 
+// JobDraft is a type that holds a job that is being posted through the bot before it is submitted
+type JobDraft struct {
+	Job     *entity.Job
+	Editing bool // Set when a single step is being changed from the preview
+}
+
+// PhoneVerification is a type that holds a verification code sent to a phonenumber that hasn't been verified yet
+type PhoneVerification struct {
+	PhoneNumber string
+	Code        string
+	SentAt      time.Time
+}
+
+// ProfileDraft is a type that holds the profile entries a user has changed before they are saved,
+// an empty entry keeps the current value of the profile
+type ProfileDraft struct {
+	UserName    string
+	PhoneNumber string
+	Category    string
+}
+
+// JobSubmission is a type that defines a job posted through the job submission api with the access token issued by the bot
+type JobSubmission struct {
+	EmployerID     string     `json:"employer_id"`
+	AccessToken    string     `json:"access_token"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Type           string     `json:"type"`
+	Sector         string     `json:"sector"`
+	EducationLevel string     `json:"education_level"`
+	Experience     string     `json:"experience"`
+	Gender         string     `json:"gender"`
+	ContactType    string     `json:"contact_type"`
+	DueDate        *time.Time `json:"due_date"`
+}
+
+// ModerationReason is a type that holds a moderation action that is waiting for the moderator to reply with a reason
+type ModerationReason struct {
+	Action    string
+	JobID     string
+	MessageID int64 // The message id of the job's post on the moderators' chat
+}
+
 // Update is a Telegram object that the handler receives every time an user interacts with the bot.
 type Update struct {
 	UpdateID      int64         `json:"update_id"`
 	Message       Message       `json:"message"`
 	CallbackQuery CallbackQuery `json:"callback_query"`
+	MyChatMember  ChatMember    `json:"my_chat_member"`
 }
 
 // Message is a Telegram object that can be found inside an update.
 type Message struct {
-	Text     string    `json:"text"`
-	Chat     Chat      `json:"chat"`
-	User     TUser     `json:"from"`
-	Document TDocument `json:"document"`
-	Contact  TContact  `json:"contact"`
+	MessageID int64     `json:"message_id"`
+	Text      string    `json:"text"`
+	Chat      Chat      `json:"chat"`
+	User      TUser     `json:"from"`
+	Document  TDocument `json:"document"`
+	Contact   TContact  `json:"contact"`
+
+	// The message this message replies to, only set on the top level message
+	ReplyToMessage *Message `json:"reply_to_message"`
 }
 
 // CallbackQuery is a Telegram object that can be found inside an update.
 type CallbackQuery struct {
-	ID   string `json:"id"`
-	Data string `json:"data"`
-	User TUser  `json:"from"`
+	ID      string  `json:"id"`
+	Data    string  `json:"data"`
+	User    TUser   `json:"from"`
+	Message Message `json:"message"` // The message with the inline keyboard that originated the query
 }
 
 // Chat indicates the conversation to which the message belongs.
 type Chat struct {
-	ID int64 `json:"id"`
+	ID        int64  `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	UserName  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// ChatMember is a Telegram object that describes a change of the bot's status in a chat
+type ChatMember struct {
+	Chat          Chat          `json:"chat"`
+	User          TUser         `json:"from"`
+	NewChatMember ChatMemberNew `json:"new_chat_member"`
+}
+
+// ChatMemberNew is a Telegram object that holds the bot's new status in a chat
+type ChatMemberNew struct {
+	Status string `json:"status"`
 }
 
 // TUser is a Telegram user object
 type TUser struct {
 	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	UserName     string `json:"username"`
 	LanguageCode string `json:"language_code"`
 }
 
@@ -76,6 +157,7 @@ type TContact struct {
 	PhoneNumber string `json:"phone_number"`
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
+	UserID      int64  `json:"user_id"` // The Telegram id of the contact, zero if the contact isn't a Telegram user
 }
 
 // ReplyKeyboardMarkup is a struct that represents a reply to form Telegram keyboard
@@ -91,6 +173,12 @@ type ReplyKeyboardButton struct {
 	RequestContact bool   `json:"request_contact"`
 }
 
+// ForceReply is a struct that represents a reply markup which makes the Telegram client reply to the message
+type ForceReply struct {
+	ForceReply bool `json:"force_reply"`
+	Selective  bool `json:"selective"`
+}
+
 // InlineKeyboardMarkup is a struct that represents an inline keyboard for a reply chat
 type InlineKeyboardMarkup struct {
 	InlineKeyboard [][]*InlineKeyboardButton `json:"inline_keyboard"`
@@ -110,10 +198,111 @@ func (tempUser *TempUser) ToUser() *entity.User {
 	user.UserName = tempUser.UserName
 	user.PhoneNumber = tempUser.PhoneNumber
 	user.Category = tempUser.Category
+	user.Language = tempUser.Language
 
 	return user
 }
 
+// SendMessageRequest is a type that defines the parameters of a Telegram sendMessage request
+type SendMessageRequest struct {
+	ChatID      string
+	Text        string
+	ReplyMarkup string
+	ParseMode   string
+}
+
+// SendDocumentRequest is a type that defines the parameters of a Telegram sendDocument request
+type SendDocumentRequest struct {
+	ChatID      string
+	Document    string // The file_id of a document that exists on the Telegram servers
+	Caption     string
+	ReplyMarkup string
+	ParseMode   string
+}
+
+// UploadDocumentRequest is a type that defines the parameters of a Telegram sendDocument request
+// that uploads a new file instead of using an existing file_id
+type UploadDocumentRequest struct {
+	ChatID      string
+	FileName    string
+	Content     []byte
+	Caption     string
+	ReplyMarkup string
+	ParseMode   string
+}
+
+// EditMessageTextRequest is a type that defines the parameters of a Telegram editMessageText request
+type EditMessageTextRequest struct {
+	ChatID      string
+	MessageID   int64
+	Text        string
+	ReplyMarkup string // An empty reply markup removes the message's inline keyboard
+	ParseMode   string
+}
+
+// EditMessageReplyMarkupRequest is a type that defines the parameters of a Telegram editMessageReplyMarkup request
+type EditMessageReplyMarkupRequest struct {
+	ChatID      string
+	MessageID   int64
+	ReplyMarkup string // An empty reply markup removes the message's inline keyboard
+}
+
+// AnswerCallbackQueryRequest is a type that defines the parameters of a Telegram answerCallbackQuery request
+type AnswerCallbackQueryRequest struct {
+	CallbackQueryID string
+	Text            string
+}
+
+// GetUpdatesRequest is a type that defines the parameters of a Telegram getUpdates request
+type GetUpdatesRequest struct {
+	Offset  int64
+	Limit   int64
+	Timeout int64 // Long polling timeout in seconds
+}
+
+// APIResponse is a type that defines the response envelope returned by every Telegram bot api method
+type APIResponse struct {
+	Ok          bool               `json:"ok"`
+	ErrorCode   int64              `json:"error_code"`
+	Description string             `json:"description"`
+	Parameters  ResponseParameters `json:"parameters"`
+	Result      json.RawMessage    `json:"result"`
+}
+
+// ResponseParameters is a type that holds the extra information Telegram sends with an unsuccessful request
+type ResponseParameters struct {
+	MigrateToChatID int64 `json:"migrate_to_chat_id"`
+	RetryAfter      int64 `json:"retry_after"`
+}
+
+// APIError is a type that defines an unsuccessful Telegram bot api response as an error
+type APIError struct {
+	ErrorCode   int64
+	Description string
+	RetryAfter  int64
+}
+
+// Error is a method that returns the string representation of the api error
+func (apiErr *APIError) Error() string {
+	return fmt.Sprintf("telegram api error %d: %s", apiErr.ErrorCode, apiErr.Description)
+}
+
+// IsRateLimited is a method that checks whether the request has been rejected for exceeding the flood limits
+func (apiErr *APIError) IsRateLimited() bool {
+	return apiErr.ErrorCode == 429
+}
+
+// IsPermanent is a method that checks whether sending the same request again will fail the same way,
+// like when the bot was blocked by the user [403] or the chat is not found [400]
+func (apiErr *APIError) IsPermanent() bool {
+	return apiErr.ErrorCode == 400 || apiErr.ErrorCode == 403
+}
+
+// IsNotModified is a method that checks whether an edit has been rejected because the message is already the same
+func (apiErr *APIError) IsNotModified() bool {
+	return apiErr.ErrorCode == 400 && strings.Contains(apiErr.Description, "message is not modified")
+}
+
 // StructuredPackage is a type that holds all the structured and modified entities ready for consumption
 type StructuredPackage struct {
 	Employer string
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// TokenBucket is a type that allows a number of events per second with a burst of up to its capacity
+type TokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewTokenBucket is a function that returns a new full token bucket
+func NewTokenBucket(rate, capacity float64, now time.Time) *TokenBucket {
+	return &TokenBucket{rate: rate, capacity: capacity, tokens: capacity, last: now}
+}
+
+// Reserve is a method that takes a token from the bucket and returns how long the caller has to wait before using it
+func (bucket *TokenBucket) Reserve(now time.Time) time.Duration {
+
+	bucket.refill(now)
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// IsFull is a method that checks whether the bucket has been idle long enough to be refilled completely
+func (bucket *TokenBucket) IsFull(now time.Time) bool {
+	bucket.refill(now)
+	return bucket.tokens >= bucket.capacity
+}
+
+// refill is a method that adds the tokens earned since the last refill
+func (bucket *TokenBucket) refill(now time.Time) {
+
+	if now.After(bucket.last) {
+		bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+		bucket.last = now
+	}
+
+	if bucket.tokens > bucket.capacity {
+		bucket.tokens = bucket.capacity
+	}
+}
+
+// DeliveryLimiter is a type that spaces out outgoing messages to stay within the Telegram broadcast limits,
+// a global limit for all the chats combined and a limit for each chat
+type DeliveryLimiter struct {
+	mutex       sync.Mutex
+	global      *TokenBucket
+	chats       map[int64]*TokenBucket
+	chatRate    float64
+	pausedUntil time.Time
+	clock       tools.IClock
+	sleep       func(duration time.Duration)
+}
+
+// NewDeliveryLimiter is a function that returns a new delivery limiter with the given messages per second limits
+func NewDeliveryLimiter(globalRate, chatRate float64, clock tools.IClock) *DeliveryLimiter {
+	return &DeliveryLimiter{global: NewTokenBucket(globalRate, globalRate, clock.Now()),
+		chats: make(map[int64]*TokenBucket), chatRate: chatRate, clock: clock, sleep: time.Sleep}
+}
+
+// Wait is a method that blocks until a message can be sent to the given chat
+func (limiter *DeliveryLimiter) Wait(chatID int64) {
+	limiter.sleep(limiter.reserve(chatID, limiter.clock.Now()))
+}
+
+// Pause is a method that stops every delivery for the given duration, used when Telegram responds with retry_after
+func (limiter *DeliveryLimiter) Pause(duration time.Duration) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if until := limiter.clock.Now().Add(duration); until.After(limiter.pausedUntil) {
+		limiter.pausedUntil = until
+	}
+}
+
+// reserve is a method that reserves a global and a chat token and returns how long to wait before sending
+func (limiter *DeliveryLimiter) reserve(chatID int64, now time.Time) time.Duration {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	// Chats that haven't received a message recently don't need to be tracked
+	for id, bucket := range limiter.chats {
+		if bucket.IsFull(now) {
+			delete(limiter.chats, id)
+		}
+	}
+
+	chat, ok := limiter.chats[chatID]
+	if !ok {
+		chat = NewTokenBucket(limiter.chatRate, 1, now)
+		limiter.chats[chatID] = chat
+	}
+
+	wait := limiter.global.Reserve(now)
+	if chatWait := chat.Reserve(now); chatWait > wait {
+		wait = chatWait
+	}
+
+	if paused := limiter.pausedUntil.Sub(now); paused > wait {
+		wait = paused
+	}
+
+	return wait
+}
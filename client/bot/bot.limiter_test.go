@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/tools"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	bucket := NewTokenBucket(2, 3, now)
+
+	// A full bucket allows a burst of its capacity and then spaces the events out by the rate
+	expected := []time.Duration{0, 0, 0, time.Millisecond * 500, time.Second}
+	for i, wait := range expected {
+		if reserved := bucket.Reserve(now); reserved != wait {
+			t.Errorf("expected event %d to wait %s, got %s", i+1, wait, reserved)
+		}
+	}
+
+	// Tokens are earned at the rate, so a second pays back two of the borrowed tokens
+	now = now.Add(time.Second)
+	if reserved := bucket.Reserve(now); reserved != time.Millisecond*500 {
+		t.Errorf("expected the bucket to be refilled by 2 tokens, got a wait of %s", reserved)
+	}
+
+	if bucket.IsFull(now.Add(time.Second)) {
+		t.Errorf("expected the bucket not to be full before it is refilled completely")
+	}
+
+	// The tokens earned while idle never exceed the capacity
+	now = now.Add(time.Hour)
+	if !bucket.IsFull(now) {
+		t.Errorf("expected an idle bucket to be full")
+	}
+
+	for i, wait := range expected {
+		if reserved := bucket.Reserve(now); reserved != wait {
+			t.Errorf("expected event %d after idling to wait %s, got %s", i+1, wait, reserved)
+		}
+	}
+
+	// A time before the last refill doesn't take tokens back
+	if reserved := bucket.Reserve(now.Add(-time.Hour)); reserved != time.Millisecond*1500 {
+		t.Errorf("expected the bucket to ignore an earlier time, got a wait of %s", reserved)
+	}
+}
+
+// newTestLimiter is a function that returns a delivery limiter whose waits advance the given clock
+// instead of sleeping, along with the waits it has made
+func newTestLimiter(clock *tools.ManualClock, globalRate, chatRate float64) (*DeliveryLimiter, *[]time.Duration) {
+	waits := make([]time.Duration, 0)
+	limiter := NewDeliveryLimiter(globalRate, chatRate, clock)
+	limiter.sleep = func(duration time.Duration) {
+		waits = append(waits, duration)
+		clock.Advance(duration)
+	}
+
+	return limiter, &waits
+}
+
+func TestDeliveryLimiterWait(t *testing.T) {
+	clock := tools.NewManualClock(time.Now())
+	limiter, waits := newTestLimiter(clock, 3, 1)
+
+	// Messages to the same chat are a second apart while other chats only wait for the global limit
+	limiter.Wait(1)
+	limiter.Wait(1)
+	limiter.Wait(2)
+	limiter.Wait(3)
+
+	expected := []time.Duration{0, time.Second, 0, 0}
+	for i, wait := range expected {
+		if (*waits)[i] != wait {
+			t.Errorf("expected message %d to wait %s, got %s", i+1, wait, (*waits)[i])
+		}
+	}
+
+	// The global bucket is shared by every chat, once it is used up every chat waits for the global rate
+	clock.Advance(time.Minute)
+	*waits = (*waits)[:0]
+	for chatID := int64(10); chatID < 14; chatID++ {
+		limiter.Wait(chatID)
+	}
+
+	expected = []time.Duration{0, 0, 0, time.Second / 3}
+	for i, wait := range expected {
+		if diff := (*waits)[i] - wait; diff > time.Microsecond || diff < -time.Microsecond {
+			t.Errorf("expected message %d to different chats to wait %s, got %s", i+1, wait, (*waits)[i])
+		}
+	}
+
+	// Chats that haven't received a message within their interval aren't tracked anymore
+	clock.Advance(time.Second * 2)
+	limiter.Wait(1)
+	if len(limiter.chats) != 1 {
+		t.Errorf("expected only the last chat to be tracked, got %d", len(limiter.chats))
+	}
+}
+
+func TestDeliveryLimiterPause(t *testing.T) {
+	clock := tools.NewManualClock(time.Now())
+	limiter, waits := newTestLimiter(clock, 30, 1)
+
+	// A retry_after response holds back every chat until it passes
+	limiter.Pause(time.Second * 5)
+	limiter.Wait(1)
+	limiter.Wait(2)
+
+	if (*waits)[0] != time.Second*5 || (*waits)[1] != 0 {
+		t.Errorf("expected only the first message to wait for the pause, got %v", *waits)
+	}
+
+	// A shorter pause doesn't cut an earlier one short
+	limiter.Pause(time.Second * 10)
+	limiter.Pause(time.Second)
+	limiter.Wait(3)
+
+	if (*waits)[2] != time.Second*10 {
+		t.Errorf("expected the longer pause to be kept, got %s", (*waits)[2])
+	}
+
+	// The pause is combined with the chat limit rather than added to it
+	limiter.Wait(4)
+	limiter.Pause(time.Millisecond * 500)
+	limiter.Wait(4)
+
+	if (*waits)[3] != 0 || (*waits)[4] != time.Second {
+		t.Errorf("expected the chat limit to outlast the pause, got %v", (*waits)[3:])
+	}
+}
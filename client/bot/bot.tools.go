@@ -3,154 +3,15 @@ package bot
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"net/url"
-	"os"
+	"html"
 	"regexp"
-	"strconv"
 	"strings"
 
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
 	"github.com/Benyam-S/asseri/tools"
 )
 
-// SendReplyToTelegramChat sends a reply to the Telegram chat identified by its chat Id
-func SendReplyToTelegramChat(chatID int64, reply ...string) (string, error) {
-
-	text := ""
-	replyMarkup := ""
-
-	if len(reply) > 0 {
-		text = reply[0]
-	}
-
-	if len(reply) > 1 {
-		replyMarkup = reply[1]
-	}
-
-	var telegramAPI string = os.Getenv("api_access_point") + os.Getenv("bot_api_token") + "/sendMessage"
-	response, err := http.PostForm(
-		telegramAPI,
-		url.Values{
-			"chat_id":      {strconv.FormatInt(chatID, 10)},
-			"text":         {text},
-			"reply_markup": {replyMarkup},
-			"parse_mode":   {"html"},
-		})
-
-	if err != nil {
-		return "", err
-	}
-	defer response.Body.Close()
-
-	var bodyBytes, errRead = ioutil.ReadAll(response.Body)
-	if errRead != nil {
-		return "", err
-	}
-
-	return string(bodyBytes), nil
-}
-
-// SendDocumentToTelegramChat sends a document to the Telegram chat identified by its chat Id
-func SendDocumentToTelegramChat(chatID int64, fileID string, reply ...string) (string, error) {
-
-	caption := ""
-	replyMarkup := ""
-
-	if len(reply) > 0 {
-		caption = reply[0]
-	}
-
-	if len(reply) > 1 {
-		replyMarkup = reply[1]
-	}
-
-	var telegramAPI string = os.Getenv("api_access_point") + os.Getenv("bot_api_token") + "/sendDocument"
-	response, err := http.PostForm(
-		telegramAPI,
-		url.Values{
-			"chat_id":      {strconv.FormatInt(chatID, 10)},
-			"document":     {fileID},
-			"caption":      {caption},
-			"parse_mode":   {"html"},
-			"reply_markup": {replyMarkup},
-		})
-
-	if err != nil {
-		return "", err
-	}
-	defer response.Body.Close()
-
-	var bodyBytes, errRead = ioutil.ReadAll(response.Body)
-	if errRead != nil {
-		return "", err
-	}
-
-	return string(bodyBytes), nil
-}
-
-// PostToTelegramChannel posts a certain content to a telegram channel
-func PostToTelegramChannel(post ...string) (string, error) {
-
-	text := ""
-	replyMarkup := ""
-
-	if len(post) > 0 {
-		text = post[0]
-	}
-
-	if len(post) > 1 {
-		replyMarkup = post[1]
-	}
-
-	var telegramAPI string = os.Getenv("api_access_point") + os.Getenv("bot_api_token") + "/sendMessage"
-	response, err := http.PostForm(
-		telegramAPI,
-		url.Values{
-			"chat_id":      {os.Getenv("channel_name")},
-			"text":         {text},
-			"reply_markup": {replyMarkup},
-			"parse_mode":   {"html"},
-		})
-
-	if err != nil {
-		return "", err
-	}
-	defer response.Body.Close()
-
-	var bodyBytes, errRead = ioutil.ReadAll(response.Body)
-	if errRead != nil {
-		return "", err
-	}
-
-	return string(bodyBytes), nil
-}
-
-// AnswerToTelegramCallBack sends a reply to the Telegram call back request identified by the query id
-func AnswerToTelegramCallBack(queryID string, text string) (string, error) {
-
-	var telegramAPI string = os.Getenv("api_access_point") + os.Getenv("bot_api_token") + "/answerCallbackQuery"
-	response, err := http.PostForm(
-		telegramAPI,
-		url.Values{
-			"callback_query_id": {queryID},
-			"text":              {text},
-		})
-
-	if err != nil {
-		return "", err
-	}
-	defer response.Body.Close()
-
-	var bodyBytes, errRead = ioutil.ReadAll(response.Body)
-	if errRead != nil {
-		return "", err
-	}
-
-	return string(bodyBytes), nil
-}
-
 // CreateReplyKeyboard is a function that creates a reply keyboard from set of parameters
 func CreateReplyKeyboard(resizeKeyboard, oneTimeKeyboard bool, keyboardButtons ...[]string) string {
 
@@ -233,25 +94,46 @@ func CreateInlineKeyboard(keyboardButtons ...[]InlineKeyboardButton) string {
 	return string(keyboardS)
 }
 
-// GetGender is a function tha get the appropriate gender value for a given gender acronym
-func GetGender(gender string) string {
+// CreateForceReply is a function that creates a reply markup which asks the Telegram client to reply to the message.
+// If selective is set only the users mentioned in the message are asked to reply.
+func CreateForceReply(selective bool) string {
+	forceReply := ForceReply{ForceReply: true, Selective: selective}
+	forceReplyS, _ := json.Marshal(&forceReply)
+	return string(forceReplyS)
+}
+
+// GetGender is a function tha get the appropriate gender value for a given gender acronym in the given language
+func GetGender(gender, language string) string {
 
 	switch gender {
 	case "M":
-		gender = "Male"
+		return locale.Text(language, "gender.male")
 	case "F":
-		gender = "Female"
-	case "B":
-		fallthrough
-	default:
-		gender = "Both"
+		return locale.Text(language, "gender.female")
+	}
+
+	return locale.Text(language, "gender.both")
+}
+
+// GetJobStatus is a function that get the appropriate job status value for a given job status acronym in the given language
+func GetJobStatus(status, language string) string {
+
+	switch status {
+	case entity.JobStatusPending:
+		status = locale.Text(language, "job.status.pending")
+	case entity.JobStatusOpened:
+		status = locale.Text(language, "job.status.opened")
+	case entity.JobStatusClosed:
+		status = locale.Text(language, "job.status.closed")
+	case entity.JobStatusDecelined:
+		status = locale.Text(language, "job.status.declined")
 	}
 
-	return gender
+	return status
 }
 
-// BuildNotification is a function that builds a notification and keyboard from given job
-func BuildNotification(job *entity.Job, pack *StructuredPackage) string {
+// BuildNotification is a function that builds a notification and keyboard from given job in the given language
+func BuildNotification(job *entity.Job, pack *StructuredPackage, language string) string {
 
 	var notification string
 	var jobSector = ""
@@ -278,54 +160,38 @@ func BuildNotification(job *entity.Job, pack *StructuredPackage) string {
 
 	emptyJobType, _ := regexp.MatchString(`^\s*$`, jobType)
 	if !emptyJobType {
-		jobType = fmt.Sprintf("<b>Job Type</b>:  %s\n", jobType)
+		jobType = locale.Text(language, "job.field.type", jobType)
 	} else {
 		jobType = ""
 	}
 
 	emptyEducationLevel, _ := regexp.MatchString(`^\s*$`, educationLevel)
 	if !emptyEducationLevel {
-		educationLevel = fmt.Sprintf("<b>Education level</b>:  %s\n", educationLevel)
+		educationLevel = locale.Text(language, "job.field.education_level", educationLevel)
 	} else {
 		educationLevel = ""
 	}
 
 	switch job.PostType {
-	case entity.PostCategoryUser:
-		notification = fmt.Sprintf(
-			"<b>Job Title</b>:  %s\n\n"+
-				"<b>አሰሪ</b>:  %s\n\n"+
-				jobType+
-				"<b>Gender</b>:  %s\n"+
-				educationLevel+
-				"<b>Experience</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n%s"+"%s\n\n"+
-				"@asseri_bot         @asseri_bot\n\n",
-			job.Title, pack.Employer, GetGender(job.Gender), job.Experience,
-			job.Description, pack.Contact, jobSector)
-	case entity.PostCategoryInternal:
-		notification = fmt.Sprintf(
-			"<b>Job Title</b>:  %s\n\n"+
-				"<b>አሰሪ</b>:  %s\n\n"+
-				jobType+
-				"<b>Gender</b>:  %s\n"+
-				educationLevel+
-				"<b>Experience</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n%s"+"%s\n\n"+
-				"@asseri_bot         @asseri_bot\n\n",
-			job.Title, pack.Employer, GetGender(job.Gender), job.Experience,
-			job.Description, pack.Contact, jobSector)
+	case entity.PostCategoryUser, entity.PostCategoryInternal:
+		notification = locale.Text(language, "job.field.title", html.EscapeString(job.Title)) + "\n" +
+			locale.Text(language, "job.field.employer", html.EscapeString(pack.Employer)) + "\n" +
+			jobType +
+			locale.Text(language, "job.field.gender", GetGender(job.Gender, language)) +
+			educationLevel +
+			locale.Text(language, "job.field.experience", job.Experience) + "\n" +
+			locale.Text(language, "job.field.description", html.EscapeString(job.Description)) + "\n" +
+			pack.Contact + jobSector + "\n\n" +
+			"@asseri_bot         @asseri_bot\n\n"
 	case entity.PostCategoryExternal:
-		notification = fmt.Sprintf(
-			"<b>Job Title</b>:  %s\n\n"+
-				"<b>አሰሪ</b>:  %s\n\n"+
-				jobType+
-				educationLevel+
-				"<b>Experience</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n"+"%s\n\n"+
-				"@asseri_bot         @asseri_bot\n\n",
-			job.Title, pack.Employer, job.Experience, job.Description,
-			jobSector)
+		notification = locale.Text(language, "job.field.title", html.EscapeString(job.Title)) + "\n" +
+			locale.Text(language, "job.field.employer", html.EscapeString(pack.Employer)) + "\n" +
+			jobType +
+			educationLevel +
+			locale.Text(language, "job.field.experience", job.Experience) + "\n" +
+			locale.Text(language, "job.field.description", html.EscapeString(job.Description)) + "\n" +
+			jobSector + "\n\n" +
+			"@asseri_bot         @asseri_bot\n\n"
 	}
 
 	return notification
@@ -10,4 +10,6 @@ type IClientRepository interface {
 	Find(identifier string) (*bot.Client, error)
 	Update(client *bot.Client) error
 	Delete(identifier string) (*bot.Client, error)
+	SetBlocked(identifier string, blocked bool) error
+	CountBlocked() int64
 }
@@ -69,3 +69,20 @@ func (repo *ClientRepository) Delete(identifier string) (*bot.Client, error) {
 	repo.conn.Delete(client)
 	return client, nil
 }
+
+// SetBlocked is a method that sets the blocked state of a client using telegram_id or user_id
+func (repo *ClientRepository) SetBlocked(identifier string, blocked bool) error {
+	err := repo.conn.Model(&bot.Client{}).Where("telegram_id = ? || user_id = ?", identifier, identifier).
+		Update("blocked", blocked).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountBlocked is a method that returns the number of clients that have blocked the bot
+func (repo *ClientRepository) CountBlocked() int64 {
+	var count int64
+	repo.conn.Model(&bot.Client{}).Where("blocked = ?", true).Count(&count)
+	return count
+}
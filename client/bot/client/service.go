@@ -10,4 +10,7 @@ type IService interface {
 	FindClient(identifier string) (*bot.Client, error)
 	UpdateClient(client *bot.Client) error
 	DeleteClient(identifier string) (*bot.Client, error)
+	MarkClientBlocked(identifier string) error
+	MarkClientActive(identifier string) error
+	CountBlockedClients() int64
 }
@@ -62,3 +62,28 @@ func (service *Service) DeleteClient(identifier string) (*bot.Client, error) {
 
 	return client, nil
 }
+
+// MarkClientBlocked is a method that marks a client as one that has blocked the bot
+func (service *Service) MarkClientBlocked(identifier string) error {
+	err := service.clientRepo.SetBlocked(identifier, true)
+	if err != nil {
+		return errors.New("unable to update client")
+	}
+
+	return nil
+}
+
+// MarkClientActive is a method that marks a client as one that can receive messages from the bot again
+func (service *Service) MarkClientActive(identifier string) error {
+	err := service.clientRepo.SetBlocked(identifier, false)
+	if err != nil {
+		return errors.New("unable to update client")
+	}
+
+	return nil
+}
+
+// CountBlockedClients is a method that returns the number of clients that have blocked the bot
+func (service *Service) CountBlockedClients() int64 {
+	return service.clientRepo.CountBlocked()
+}
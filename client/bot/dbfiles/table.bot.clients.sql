@@ -1,4 +1,5 @@
 CREATE TABLE bot_clients (
     user_id  VARCHAR PRIMARY KEY UNIQUE NOT NULL,
-    telegram_id VARCHAR UNIQUE NOT NULL
-);
\ No newline at end of file
+    telegram_id VARCHAR UNIQUE NOT NULL,
+    blocked BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,90 @@
+package faketelegram
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	clService "github.com/Benyam-S/asseri/client/bot/client/service"
+	"github.com/Benyam-S/asseri/client/bot/handler"
+	tuRepository "github.com/Benyam-S/asseri/client/bot/tempuser/repository"
+	tuService "github.com/Benyam-S/asseri/client/bot/tempuser/service"
+	cmService "github.com/Benyam-S/asseri/common/service"
+	"github.com/Benyam-S/asseri/entity"
+	fdService "github.com/Benyam-S/asseri/feedback/service"
+	"github.com/Benyam-S/asseri/internal/testdb"
+	jbService "github.com/Benyam-S/asseri/job/service"
+	jaService "github.com/Benyam-S/asseri/jobapplication/service"
+	"github.com/Benyam-S/asseri/log"
+	"github.com/Benyam-S/asseri/notifier"
+	sbService "github.com/Benyam-S/asseri/subscription/service"
+	"github.com/Benyam-S/asseri/tools"
+	urService "github.com/Benyam-S/asseri/user/service"
+)
+
+// JobAttributes is a value list that holds the job attributes every fake bot's database starts with
+var JobAttributes = map[string][]string{
+	"job_sectors":      {"Accounting", "Engineering"},
+	"job_types":        {"Full Time", "Part Time"},
+	"education_levels": {"Degree", "Diploma"},
+}
+
+// Bot is a type that defines a bot handler which is wired like the production one except that
+// every repository keeps its data in memory, along with a harness driving it
+type Bot struct {
+	*Harness
+	Handler *handler.TelegramBotHandler
+	DB      *testdb.MemoryDB
+	Store   tools.IStore
+	Clock   *tools.ManualClock
+}
+
+// NewBot is a function that returns a new fake bot that talks to the given telegram server,
+// the clock of the bot starts at the current time and also expires the pairs of its store
+func NewBot(telegram *Server) *Bot {
+
+	db := testdb.NewMemoryDB()
+	userRepo := testdb.NewMemoryUserRepository(db)
+	jobRepo := testdb.NewMemoryJobRepository(db)
+	jobApplicationRepo := testdb.NewMemoryJobApplicationRepository(db)
+	subscriptionRepo := testdb.NewMemorySubscriptionRepository(db)
+	feedbackRepo := testdb.NewMemoryFeedbackRepository(db)
+	feedbackReplyRepo := testdb.NewMemoryFeedbackReplyRepository(db)
+	commonRepo := testdb.NewMemoryCommonRepository(db)
+	clientRepo := testdb.NewMemoryClientRepository(db)
+
+	for tableName, names := range JobAttributes {
+		for _, name := range names {
+			commonRepo.CreateJobAttribute(&entity.JobAttribute{Name: name}, tableName)
+		}
+	}
+
+	clock := tools.NewManualClock(time.Now())
+	commonService := cmService.NewCommonService(commonRepo)
+	store := tools.NewMapStoreWithClock(clock)
+	sessionStore := tools.NewSessionStore(store, bot.SessionExpiration)
+	smsSender := tools.NewLocalSMSSender(ioutil.Discard)
+
+	botHandler := handler.NewTelegramBotHandler(
+		tuService.NewTempUserService(tuRepository.NewTempUserRepository(sessionStore), userRepo, commonRepo),
+		clService.NewClientService(clientRepo),
+		urService.NewUserService(userRepo, jobRepo, jobApplicationRepo, commonRepo),
+		jbService.NewJobService(jobRepo, userRepo, commonService, clock),
+		jaService.NewJobApplicationService(jobApplicationRepo, commonRepo),
+		sbService.NewSubscriptionService(subscriptionRepo, commonService),
+		fdService.NewFeedbackService(feedbackRepo, feedbackReplyRepo, userRepo),
+		commonService, telegram.Client(), clock, store, sessionStore, smsSender,
+		notifier.NewSMSNotifier(smsSender), notifier.NewLocalNotifier("Email", ioutil.Discard),
+		make(chan string), cmService.NewPushQueue(time.Minute, 3), &log.Logger{})
+
+	return &Bot{Harness: NewHarness(telegram, botHandler), Handler: botHandler, DB: db, Store: store, Clock: clock}
+}
+
+// Job is a method that returns a copy of the job with the given id as it is stored in the bot's database
+func (fakeBot *Bot) Job(jobID string) *entity.Job {
+	jobs := fakeBot.DB.Select("jobs", func(row interface{}) bool { return row.(*entity.Job).ID == jobID })
+	if len(jobs) == 0 {
+		return nil
+	}
+	return jobs[0].(*entity.Job)
+}
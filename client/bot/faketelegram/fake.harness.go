@@ -0,0 +1,103 @@
+package faketelegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/handler"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// Harness is a type that drives a bot handler end to end by posting updates to its webhook chain
+// and collecting the replies the fake server receives
+type Harness struct {
+	Telegram     *Server
+	SecretToken  string // Sent as the webhook secret token header if not empty
+	webhook      http.HandlerFunc
+	mutex        sync.Mutex
+	nextUpdateID int64
+}
+
+// NewHarness is a function that returns a new harness for the given bot handler.
+// The bot handler must have been created with the telegram server's Client().
+func NewHarness(telegram *Server, botHandler *handler.TelegramBotHandler) *Harness {
+	return &Harness{Telegram: telegram,
+		webhook: tools.MiddlewareFactory(botHandler.HandleWebHook, botHandler.ParseRequest)}
+}
+
+// PostUpdate is a method that posts the given update to the webhook chain and returns the recorded response.
+// If the update doesn't have an update id the next one in sequence is assigned.
+func (harness *Harness) PostUpdate(update *bot.Update) *httptest.ResponseRecorder {
+
+	harness.mutex.Lock()
+	if update.UpdateID == 0 {
+		harness.nextUpdateID++
+		update.UpdateID = harness.nextUpdateID
+	} else if update.UpdateID > harness.nextUpdateID {
+		harness.nextUpdateID = update.UpdateID
+	}
+	harness.mutex.Unlock()
+
+	body, _ := json.Marshal(update)
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	if harness.SecretToken != "" {
+		request.Header.Set(bot.SecretTokenHeader, harness.SecretToken)
+	}
+
+	recorder := httptest.NewRecorder()
+	harness.webhook(recorder, request)
+	return recorder
+}
+
+// Send is a method that posts the given update and returns only the bot api calls made while handling it
+func (harness *Harness) Send(update *bot.Update) []*Call {
+	before := len(harness.Telegram.Calls())
+	harness.PostUpdate(update)
+	return harness.Telegram.Calls()[before:]
+}
+
+// SendText is a method that sends a text message from the given telegram user
+func (harness *Harness) SendText(telegramID int64, text string) []*Call {
+	return harness.Send(&bot.Update{Message: bot.Message{Text: text,
+		Chat: bot.Chat{ID: telegramID}, User: bot.TUser{ID: telegramID}}})
+}
+
+// SendContact is a method that shares a contact from the given telegram user
+func (harness *Harness) SendContact(telegramID int64, contact bot.TContact) []*Call {
+	return harness.Send(&bot.Update{Message: bot.Message{Contact: contact,
+		Chat: bot.Chat{ID: telegramID}, User: bot.TUser{ID: telegramID}}})
+}
+
+// SendDocument is a method that sends a document from the given telegram user
+func (harness *Harness) SendDocument(telegramID int64, document bot.TDocument) []*Call {
+	return harness.Send(&bot.Update{Message: bot.Message{Document: document,
+		Chat: bot.Chat{ID: telegramID}, User: bot.TUser{ID: telegramID}}})
+}
+
+// SendCallback is a method that presses an inline button with the given callback data as the given telegram user
+func (harness *Harness) SendCallback(telegramID int64, data string) []*Call {
+	harness.mutex.Lock()
+	queryID := harness.nextUpdateID + 1
+	harness.mutex.Unlock()
+
+	return harness.Send(&bot.Update{CallbackQuery: bot.CallbackQuery{ID: "query-" + strconv.FormatInt(queryID, 10),
+		Data: data, User: bot.TUser{ID: telegramID}}})
+}
+
+// Replies is a function that returns the texts of the sendMessage and sendDocument calls found in the given calls
+func Replies(calls []*Call) []string {
+	replies := make([]string, 0)
+	for _, call := range calls {
+		if call.Method == "sendMessage" || call.Method == "sendDocument" {
+			replies = append(replies, call.Text())
+		}
+	}
+
+	return replies
+}
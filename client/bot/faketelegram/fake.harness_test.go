@@ -0,0 +1,268 @@
+package faketelegram_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/faketelegram"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+const (
+	moderatorsChatID = -100123
+	moderatorID      = 9001
+	employerID       = 1001
+	jobSeekerID      = 2002
+)
+
+// testBot is a type that wraps a fake bot with the steps shared by the flow tests
+type testBot struct {
+	*faketelegram.Bot
+}
+
+// newTestBot is a function that returns a fake bot with a moderators' chat and a staff member
+func newTestBot(t *testing.T) *testBot {
+
+	os.Setenv("moderators_chat_id", strconv.Itoa(moderatorsChatID))
+	os.Setenv("staff_telegram_ids", strconv.Itoa(moderatorID))
+	os.Setenv("bot_url", "https://t.me/asseri_test_bot")
+	os.Setenv("channel_name", "@asseri_test_channel")
+	os.Unsetenv("webhook_secret_token")
+	os.Unsetenv("job_post_url")
+
+	telegram := faketelegram.NewServer()
+	t.Cleanup(telegram.Close)
+
+	return &testBot{Bot: faketelegram.NewBot(telegram)}
+}
+
+// text is a function that returns a message of the default language
+func text(id string, args ...interface{}) string {
+	return locale.Text(locale.DefaultLanguage, id, args...)
+}
+
+// expectReply is a function that fails the test if none of the replies found in the calls contains the expected text
+func expectReply(t *testing.T, calls []*faketelegram.Call, expected string) {
+	t.Helper()
+
+	replies := faketelegram.Replies(calls)
+	for _, reply := range replies {
+		if strings.Contains(reply, expected) {
+			return
+		}
+	}
+
+	t.Fatalf("expected a reply containing %q, got %q", expected, replies)
+}
+
+// register is a method that registers a telegram user with a shared contact in the given category
+func (testBot *testBot) register(t *testing.T, telegramID int64, name, phoneNumber, categoryButton string) {
+	t.Helper()
+
+	expectReply(t, testBot.SendText(telegramID, "/start"), text("registration.enter_name"))
+	expectReply(t, testBot.SendText(telegramID, name), text("phone.add"))
+	expectReply(t, testBot.SendContact(telegramID, bot.TContact{PhoneNumber: phoneNumber, UserID: telegramID}),
+		text("registration.choose_category"))
+	expectReply(t, testBot.SendText(telegramID, text(categoryButton)), text("registration.completed"))
+}
+
+// postJob is a method that posts a job through every job posting step and approves it from the moderators' chat,
+// it returns the id of the opened job
+func (testBot *testBot) postJob(t *testing.T, telegramID int64, title string) string {
+	t.Helper()
+
+	expectReply(t, testBot.SendText(telegramID, text("button.post_job")), text("post.enter_title"))
+	expectReply(t, testBot.SendText(telegramID, title), text("post.enter_description"))
+	expectReply(t, testBot.SendText(telegramID, "Keeping the books of a small company"), text("post.choose_sectors"))
+
+	testBot.SendCallback(telegramID, "job/draft/sector/0")
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/sector/next"), text("post.choose_types"))
+
+	testBot.SendCallback(telegramID, "job/draft/type/0")
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/type/next"), text("post.choose_education_level"))
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/education_level/0"), text("post.choose_experience"))
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/experience/2"), text("post.choose_gender"))
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/gender/B"), text("post.choose_contact_type"))
+	expectReply(t, testBot.SendCallback(telegramID, "job/draft/contact_type/1"), text("post.enter_due_date"))
+	expectReply(t, testBot.SendText(telegramID, text("button.skip")), text("post.preview"))
+
+	calls := testBot.SendCallback(telegramID, "job/draft/submit")
+	expectReply(t, calls, text("post.submitted"))
+
+	var jobID string
+	for _, row := range testBot.DB.Select("jobs", nil) {
+		if job := row.(*entity.Job); job.Title == title {
+			jobID = job.ID
+		}
+	}
+
+	forwarded := false
+	for _, call := range calls {
+		if call.ChatID() == strconv.Itoa(moderatorsChatID) && strings.Contains(call.ReplyMarkup(), jobID) {
+			forwarded = true
+		}
+	}
+
+	if jobID == "" || !forwarded {
+		t.Fatalf("expected job %q to be added and forwarded for moderation", title)
+	}
+
+	testBot.Send(&bot.Update{CallbackQuery: bot.CallbackQuery{ID: "moderate-" + jobID,
+		Data: "job/moderate/approve/" + jobID, User: bot.TUser{ID: moderatorID},
+		Message: bot.Message{MessageID: 1, Chat: bot.Chat{ID: moderatorsChatID}}}})
+
+	job := testBot.Job(jobID)
+	if job.Status != entity.JobStatusOpened {
+		t.Fatalf("expected the approved job to be %s, got %s", entity.JobStatusOpened, job.Status)
+	}
+
+	return jobID
+}
+
+func TestRegistrationWithContact(t *testing.T) {
+	testBot := newTestBot(t)
+
+	calls := testBot.SendText(employerID, "/start")
+	expectReply(t, calls, text("registration.welcome"))
+	expectReply(t, calls, text("registration.enter_name"))
+
+	expectReply(t, testBot.SendText(employerID, "Abebe Kebede"), text("phone.add"))
+
+	// A contact of someone else can't be used for registration
+	expectReply(t, testBot.SendContact(employerID, bot.TContact{PhoneNumber: "+251911000001", UserID: 42}),
+		text("phone.error.own_contact"))
+
+	expectReply(t, testBot.SendContact(employerID, bot.TContact{PhoneNumber: "+251911000001", UserID: employerID}),
+		text("registration.choose_category"))
+	expectReply(t, testBot.SendText(employerID, text("button.category_asseri")), text("registration.completed"))
+
+	users := testBot.DB.Select("users", nil)
+	if len(users) != 1 {
+		t.Fatalf("expected 1 registered user, got %d", len(users))
+	}
+
+	user := users[0].(*entity.User)
+	if user.UserName != "Abebe Kebede" || user.PhoneNumber != "+251911000001" ||
+		user.Category != entity.UserCategoryasseri {
+		t.Errorf("unexpected registered user %+v", user)
+	}
+
+	if testBot.DB.Count("bot_clients") != 1 {
+		t.Errorf("expected the user to be linked with a bot client")
+	}
+}
+
+func TestPostJob(t *testing.T) {
+	testBot := newTestBot(t)
+	testBot.register(t, employerID, "Abebe Kebede", "+251911000001", "button.category_asseri")
+
+	jobID := testBot.postJob(t, employerID, "Junior Accountant")
+
+	job := testBot.Job(jobID)
+	if job.Sector != "Accounting" || job.Type != "Full Time" || job.EducationLevel != "Degree" ||
+		job.Experience != entity.ValidWorkExperiences[2] || job.Gender != "B" ||
+		job.ContactType != entity.ValidContactTypes[1] || job.DueDate != nil {
+		t.Errorf("unexpected posted job %+v", job)
+	}
+
+	if job.ReviewedBy != strconv.Itoa(moderatorID) {
+		t.Errorf("expected the job to be reviewed by %d, got %q", moderatorID, job.ReviewedBy)
+	}
+}
+
+func TestApplyForJob(t *testing.T) {
+	testBot := newTestBot(t)
+	testBot.register(t, employerID, "Abebe Kebede", "+251911000001", "button.category_asseri")
+	testBot.register(t, jobSeekerID, "Almaz Tesfaye", "+251911000002", "button.category_job_seeker")
+
+	jobID := testBot.postJob(t, employerID, "Junior Accountant")
+
+	expectReply(t, testBot.SendText(jobSeekerID, "/start apply_"+jobID), text("apply.send_cv"))
+
+	// Only pdf files are accepted as a cv
+	expectReply(t, testBot.SendDocument(jobSeekerID, bot.TDocument{ID: "cv-doc", Type: "application/msword"}),
+		text("apply.error.pdf_only"))
+
+	calls := testBot.SendDocument(jobSeekerID, bot.TDocument{ID: "cv-pdf", Type: "application/pdf"})
+	expectReply(t, calls, text("apply.sent"))
+
+	sent := false
+	for _, call := range calls {
+		if call.Method == "sendDocument" && call.ChatID() == strconv.Itoa(employerID) &&
+			call.Values.Get("document") == "cv-pdf" {
+			sent = true
+		}
+	}
+
+	if !sent {
+		t.Errorf("expected the cv to be sent to the employer")
+	}
+
+	if testBot.DB.Count("job_applications") != 1 {
+		t.Errorf("expected 1 job application, got %d", testBot.DB.Count("job_applications"))
+	}
+
+	// A job can only be applied for once
+	expectReply(t, testBot.SendText(jobSeekerID, "/start apply_"+jobID), text("apply.error.already_applied"))
+}
+
+func TestAddSubscription(t *testing.T) {
+	testBot := newTestBot(t)
+	testBot.register(t, jobSeekerID, "Almaz Tesfaye", "+251911000002", "button.category_job_seeker")
+
+	expectReply(t, testBot.SendText(jobSeekerID, text("button.job_subscriptions")), text("subscription.choose_option"))
+	expectReply(t, testBot.SendText(jobSeekerID, text("button.add_subscription")), text("subscription.choose_sector"))
+	expectReply(t, testBot.SendCallback(jobSeekerID, "subscription/add/sector/Accounting"),
+		text("subscription.choose_type"))
+	expectReply(t, testBot.SendCallback(jobSeekerID, "subscription/add/type/Full Time"),
+		text("subscription.choose_education_level"))
+	expectReply(t, testBot.SendCallback(jobSeekerID, "subscription/add/education_level/Degree"),
+		text("subscription.choose_experience"))
+	expectReply(t, testBot.SendCallback(jobSeekerID, "subscription/add/experience/"+entity.ValidWorkExperiences[1]),
+		text("subscription.added"))
+
+	subscriptions := testBot.DB.Select("subscriptions", nil)
+	if len(subscriptions) != 1 {
+		t.Fatalf("expected 1 subscription, got %d", len(subscriptions))
+	}
+
+	subscription := subscriptions[0].(*entity.Subscription)
+	if subscription.Sector != "Accounting" || subscription.Type != "Full Time" ||
+		subscription.EducationLevel != "Degree" || subscription.Experience != entity.ValidWorkExperiences[1] {
+		t.Errorf("unexpected subscription %+v", subscription)
+	}
+
+	// A finished subscription can't be added again with the buttons of its steps
+	testBot.SendCallback(jobSeekerID, "subscription/add/experience/"+entity.ValidWorkExperiences[2])
+	if testBot.DB.Count("subscriptions") != 1 {
+		t.Errorf("expected the subscription flow to be completed")
+	}
+}
+
+func TestGiveFeedback(t *testing.T) {
+	testBot := newTestBot(t)
+	testBot.register(t, jobSeekerID, "Almaz Tesfaye", "+251911000002", "button.category_job_seeker")
+
+	testBot.SendText(jobSeekerID, text("button.settings"))
+	expectReply(t, testBot.SendText(jobSeekerID, text("button.feedback")), text("feedback.prompt"))
+	expectReply(t, testBot.SendText(jobSeekerID, "The bot is really helpful"), text("feedback.received"))
+
+	feedbacks := testBot.DB.Select("feedbacks", nil)
+	if len(feedbacks) != 1 {
+		t.Fatalf("expected 1 feedback, got %d", len(feedbacks))
+	}
+
+	if feedback := feedbacks[0].(*entity.Feedback); feedback.Comment != "The bot is really helpful" {
+		t.Errorf("unexpected feedback comment %q", feedback.Comment)
+	}
+
+	// The feedback flow ends at the main menu so a new text isn't taken as a feedback
+	testBot.SendText(jobSeekerID, "Another text")
+	if testBot.DB.Count("feedbacks") != 1 {
+		t.Errorf("expected the feedback flow to be completed")
+	}
+}
@@ -0,0 +1,241 @@
+package faketelegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+)
+
+// FakeBotAPIToken is the bot token the fake server expects in every request path
+const FakeBotAPIToken = "000000000:FAKE-TELEGRAM-BOT-API-TOKEN"
+
+// Call is a type that defines a single bot api request received by the fake server
+type Call struct {
+	Method string
+	Values url.Values
+}
+
+// ChatID is a method that returns the chat id the call was addressed to
+func (call *Call) ChatID() string {
+	return call.Values.Get("chat_id")
+}
+
+// Text is a method that returns the text or caption sent with the call
+func (call *Call) Text() string {
+	if call.Values.Get("text") != "" {
+		return call.Values.Get("text")
+	}
+	return call.Values.Get("caption")
+}
+
+// ReplyMarkup is a method that returns the raw reply markup sent with the call
+func (call *Call) ReplyMarkup() string {
+	return call.Values.Get("reply_markup")
+}
+
+// Server is a type that defines an in-process fake of the Telegram bot api that records every call it receives
+type Server struct {
+	server        *httptest.Server
+	mutex         sync.Mutex
+	calls         []*Call
+	chats         map[string]*bot.Chat
+	failures      map[string][]*bot.APIResponse
+	updates       []*bot.Update
+	nextMessageID int64
+	nextUpdateID  int64
+}
+
+// NewServer is a function that starts and returns a new fake Telegram bot api server
+func NewServer() *Server {
+	server := &Server{calls: make([]*Call, 0), chats: make(map[string]*bot.Chat),
+		failures: make(map[string][]*bot.APIResponse), updates: make([]*bot.Update, 0)}
+	server.server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+// APIAccessPoint is a method that returns the api access point that should be used in place of https://api.telegram.org/bot
+func (server *Server) APIAccessPoint() string {
+	return server.server.URL + "/bot"
+}
+
+// Client is a method that returns a Telegram client that is pointed at the fake server
+func (server *Server) Client() bot.ITelegramClient {
+	return bot.NewTelegramClient(server.APIAccessPoint(), FakeBotAPIToken, time.Second*5)
+}
+
+// Close is a method that shuts down the fake server
+func (server *Server) Close() {
+	server.server.Close()
+}
+
+// AddChat is a method that registers a chat so it can be returned by getChat
+func (server *Server) AddChat(chat *bot.Chat) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.chats[strconv.FormatInt(chat.ID, 10)] = chat
+}
+
+// FailNext is a method that makes the next call to the given method fail with the provided error
+func (server *Server) FailNext(method string, errorCode int64, description string, retryAfter int64) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failures[method] = append(server.failures[method], &bot.APIResponse{ErrorCode: errorCode,
+		Description: description, Parameters: bot.ResponseParameters{RetryAfter: retryAfter}})
+}
+
+// QueueUpdate is a method that queues an update to be returned by getUpdates.
+// If the update doesn't have an update id the next one in sequence is assigned.
+func (server *Server) QueueUpdate(update *bot.Update) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if update.UpdateID == 0 {
+		server.nextUpdateID++
+		update.UpdateID = server.nextUpdateID
+	} else if update.UpdateID > server.nextUpdateID {
+		server.nextUpdateID = update.UpdateID
+	}
+
+	server.updates = append(server.updates, update)
+}
+
+// Calls is a method that returns all the calls received by the fake server in order
+func (server *Server) Calls() []*Call {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	calls := make([]*Call, len(server.calls))
+	copy(calls, server.calls)
+	return calls
+}
+
+// CallsTo is a method that returns all the calls received for a certain bot api method
+func (server *Server) CallsTo(method string) []*Call {
+	calls := make([]*Call, 0)
+	for _, call := range server.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset is a method that clears all the recorded calls
+func (server *Server) Reset() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.calls = make([]*Call, 0)
+}
+
+// handle is a method that records a bot api request and writes a response similar to the one Telegram would send
+func (server *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if path.Base(path.Dir(r.URL.Path)) != "bot"+FakeBotAPIToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(&bot.APIResponse{ErrorCode: 401, Description: "Unauthorized"})
+		return
+	}
+
+	r.ParseForm()
+	call := &Call{Method: path.Base(r.URL.Path), Values: r.Form}
+
+	// Uploaded documents are sent as multipart forms, the name of the uploaded file is recorded as the document
+	if err := r.ParseMultipartForm(10 << 20); err == nil {
+		call.Values = r.MultipartForm.Value
+		for _, files := range r.MultipartForm.File["document"] {
+			call.Values.Add("document", files.Filename)
+		}
+	}
+
+	if call.Method == "getUpdates" {
+		server.waitForUpdates(call)
+	}
+
+	server.mutex.Lock()
+	server.calls = append(server.calls, call)
+
+	if failures := server.failures[call.Method]; len(failures) > 0 {
+		server.failures[call.Method] = failures[1:]
+		server.mutex.Unlock()
+
+		failure := failures[0]
+		w.WriteHeader(int(failure.ErrorCode))
+		json.NewEncoder(w).Encode(failure)
+		return
+	}
+
+	var result interface{} = true
+
+	switch call.Method {
+	case "sendMessage", "sendDocument", "editMessageText", "editMessageReplyMarkup":
+		chatID, _ := strconv.ParseInt(call.ChatID(), 10, 64)
+		messageID, _ := strconv.ParseInt(call.Values.Get("message_id"), 10, 64)
+		if messageID == 0 {
+			server.nextMessageID++
+			messageID = server.nextMessageID
+		}
+		result = &bot.Message{MessageID: messageID, Text: call.Text(), Chat: bot.Chat{ID: chatID}}
+
+	case "getUpdates":
+		offset, _ := strconv.ParseInt(call.Values.Get("offset"), 10, 64)
+		pending := make([]*bot.Update, 0)
+		for _, update := range server.updates {
+			if update.UpdateID >= offset {
+				pending = append(pending, update)
+			}
+		}
+
+		// Like Telegram, requesting an offset confirms every update before it
+		server.updates = pending
+		result = pending
+
+	case "getChat":
+		chat, ok := server.chats[call.ChatID()]
+		if !ok {
+			server.mutex.Unlock()
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(&bot.APIResponse{ErrorCode: 400,
+				Description: "Bad Request: chat not found"})
+			return
+		}
+		result = chat
+	}
+	server.mutex.Unlock()
+
+	resultS, _ := json.Marshal(result)
+	json.NewEncoder(w).Encode(&bot.APIResponse{Ok: true, Result: resultS})
+}
+
+// waitForUpdates is a method that holds a getUpdates call until an update is queued or the long polling timeout passes
+func (server *Server) waitForUpdates(call *Call) {
+
+	offset, _ := strconv.ParseInt(call.Values.Get("offset"), 10, 64)
+	timeout, _ := strconv.ParseInt(call.Values.Get("timeout"), 10, 64)
+	deadline := time.Now().Add(time.Second * time.Duration(timeout))
+
+	for time.Now().Before(deadline) {
+		server.mutex.Lock()
+		for _, update := range server.updates {
+			if update.UpdateID >= offset {
+				server.mutex.Unlock()
+				return
+			}
+		}
+		server.mutex.Unlock()
+
+		time.Sleep(time.Millisecond * 20)
+	}
+}
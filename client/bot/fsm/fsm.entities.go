@@ -0,0 +1,104 @@
+package fsm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// Proceed is a constant that is returned by a handler to move the conversation to the next state of its route
+const Proceed = ""
+
+// Stay is a constant that is returned by a handler to keep the conversation in the current state
+const Stay = "fsm/stay"
+
+// Session is a type that defines the state of a conversation with a single chat
+// along with the data the current flow has collected so far
+type Session struct {
+	State string            `json:"state"`
+	Data  map[string]string `json:"data"`
+}
+
+// Get is a method that returns the session value stored under the given key
+func (session *Session) Get(key string) string {
+	return session.Data[key]
+}
+
+// Set is a method that stores a value in the session under the given key
+func (session *Session) Set(key, value string) {
+	if session.Data == nil {
+		session.Data = make(map[string]string)
+	}
+	session.Data[key] = value
+}
+
+// Request is a type that defines a single input sent by a chat along with everything needed to handle it
+type Request struct {
+	Input   string // The emoji free command or the callback data
+	Update  *bot.Update
+	User    *entity.User
+	Client  *bot.Client
+	Session *Session
+}
+
+// Handler is a type that defines a function that handles an input and returns the state the conversation should move to,
+// Proceed moves to the next state of the route and Stay keeps the current state
+type Handler func(request *Request) string
+
+// Matcher is a type that defines a function that reports whether a route accepts an input
+type Matcher func(input string) bool
+
+// Route is a type that defines an input accepted by a state, the handler of the input and the next state
+type Route struct {
+	Match   Matcher
+	Handle  Handler
+	Next    string   // An empty next state keeps the current state
+	Returns []string // The states the handler can return other than Proceed and Stay
+}
+
+// State is a type that defines a single state of a conversation along with the commands and callback actions it accepts
+type State struct {
+	Name     string
+	Commands []*Route
+	Actions  []*Route
+	OnEscape func(request *Request) // Called when the conversation escapes from the state, used for clean ups
+}
+
+// Exact is a function that returns a matcher that accepts any of the given inputs
+func Exact(values ...string) Matcher {
+	return func(input string) bool {
+		for _, value := range values {
+			if input == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Prefix is a function that returns a matcher that accepts any input that starts with one of the given prefixes
+func Prefix(prefixes ...string) Matcher {
+	return func(input string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(input, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Pattern is a function that returns a matcher that accepts any input that matches the given regular expression
+func Pattern(expr string) Matcher {
+	reg := regexp.MustCompile(expr)
+	return reg.MatchString
+}
+
+// Any is a function that returns a matcher that accepts every input, including an empty one like a sent document
+func Any() Matcher {
+	return func(input string) bool {
+		return true
+	}
+}
@@ -0,0 +1,178 @@
+package fsm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Config is a type that defines the transition table of a conversation
+type Config struct {
+	Initial  string   // The state a conversation starts from and returns to when escaped
+	Escape   *Route   // Accepted in every state before the state's own commands
+	Commands []*Route // Accepted in every state if the state itself doesn't accept the command
+	Actions  []*Route // Accepted in every state before the state's own callback actions
+	States   []*State
+}
+
+// Machine is a type that routes the inputs of a conversation using a transition table
+type Machine struct {
+	config *Config
+	states map[string]*State
+}
+
+// NewMachine is a function that returns a new machine for the given transition table
+func NewMachine(config *Config) *Machine {
+
+	states := make(map[string]*State)
+	for _, state := range config.States {
+		states[state.Name] = state
+	}
+
+	return &Machine{config: config, states: states}
+}
+
+// Validate is a method that checks whether every state the transition table refers to has been declared,
+// including the states the handlers can return
+func (machine *Machine) Validate() error {
+
+	if _, ok := machine.states[machine.config.Initial]; !ok {
+		return fmt.Errorf("undeclared initial state %q", machine.config.Initial)
+	}
+
+	if len(machine.states) != len(machine.config.States) {
+		return errors.New("a state has been declared more than once")
+	}
+
+	routes := append([]*Route{}, machine.config.Commands...)
+	routes = append(routes, machine.config.Actions...)
+	if machine.config.Escape != nil {
+		routes = append(routes, machine.config.Escape)
+	}
+
+	for _, state := range machine.config.States {
+		routes = append(routes, state.Commands...)
+		routes = append(routes, state.Actions...)
+	}
+
+	for _, route := range routes {
+		if route.Match == nil || route.Handle == nil {
+			return errors.New("a route without a matcher or a handler has been declared")
+		}
+
+		if _, ok := machine.states[route.Next]; route.Next != "" && !ok {
+			return fmt.Errorf("undeclared next state %q", route.Next)
+		}
+
+		for _, state := range route.Returns {
+			if _, ok := machine.states[state]; !ok {
+				return fmt.Errorf("undeclared returned state %q", state)
+			}
+		}
+	}
+
+	return nil
+}
+
+// MatchCommand is a method that returns the route that accepts a command in the given state, nil if there isn't any.
+// The escape route comes first, then the routes of the state and then the commands accepted in every state.
+func (machine *Machine) MatchCommand(state, command string) *Route {
+
+	if escape := machine.config.Escape; escape != nil && escape.Match(command) {
+		return escape
+	}
+
+	if current, ok := machine.states[state]; ok {
+		if route := firstMatch(current.Commands, command); route != nil {
+			return route
+		}
+	}
+
+	return firstMatch(machine.config.Commands, command)
+}
+
+// MatchAction is a method that returns the route that accepts a callback action in the given state, nil if there isn't any.
+// The actions accepted in every state come before the actions of the state.
+func (machine *Machine) MatchAction(state, action string) *Route {
+
+	if route := firstMatch(machine.config.Actions, action); route != nil {
+		return route
+	}
+
+	if current, ok := machine.states[state]; ok {
+		return firstMatch(current.Actions, action)
+	}
+
+	return nil
+}
+
+// HandleCommand is a method that handles a command sent in the session's state and moves the session to the next state.
+// It returns whether the command has been accepted or not.
+func (machine *Machine) HandleCommand(request *Request) bool {
+
+	route := machine.MatchCommand(request.Session.State, request.Input)
+	if route == nil {
+		return false
+	}
+
+	if route == machine.config.Escape {
+		if current, ok := machine.states[request.Session.State]; ok && current.OnEscape != nil {
+			current.OnEscape(request)
+		}
+	}
+
+	machine.transit(request, route)
+	return true
+}
+
+// HandleAction is a method that handles a callback action sent in the session's state and moves the session
+// to the next state. It returns whether the action has been accepted or not.
+func (machine *Machine) HandleAction(request *Request) bool {
+
+	route := machine.MatchAction(request.Session.State, request.Input)
+	if route == nil {
+		return false
+	}
+
+	machine.transit(request, route)
+	return true
+}
+
+// transit is a method that runs the handler of a route and moves the session to the resulting state.
+// A state that the route doesn't declare keeps the current state, so a session never ends up in an unknown state.
+// The data of a session only lives as long as its flow, so it is cleared once the initial state is reached.
+func (machine *Machine) transit(request *Request, route *Route) {
+
+	next := route.Handle(request)
+	if next == Proceed {
+		next = route.Next
+	}
+
+	if next == Stay || next == "" || (next != route.Next && !contains(route.Returns, next)) {
+		return
+	}
+
+	request.Session.State = next
+	if next == machine.config.Initial {
+		request.Session.Data = nil
+	}
+}
+
+// firstMatch is a function that returns the first route that accepts the given input
+func firstMatch(routes []*Route, input string) *Route {
+	for _, route := range routes {
+		if route.Match(input) {
+			return route
+		}
+	}
+	return nil
+}
+
+// contains is a function that checks whether the given states hold the state
+func contains(states []string, state string) bool {
+	for _, value := range states {
+		if value == state {
+			return true
+		}
+	}
+	return false
+}
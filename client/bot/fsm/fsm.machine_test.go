@@ -0,0 +1,224 @@
+package fsm
+
+import (
+	"testing"
+)
+
+// returning is a function that returns a handler which records its name and returns the given state
+func returning(name, state string, handled *[]string) Handler {
+	return func(request *Request) string {
+		*handled = append(*handled, name)
+		return state
+	}
+}
+
+// newTestMachine is a function that returns a machine with a menu, a two step flow and a handler
+// that returns its next state, along with the names of the handlers that get called
+func newTestMachine(escaped *[]string) (*Machine, *[]string) {
+
+	handled := new([]string)
+	onEscape := func(request *Request) { *escaped = append(*escaped, request.Session.State) }
+
+	return NewMachine(&Config{
+		Initial: "menu",
+		Escape:  &Route{Match: Exact("escape"), Next: "menu", Handle: returning("escape", Proceed, handled)},
+		Commands: []*Route{
+			{Match: Exact("start"), Next: "first", Handle: returning("start", Proceed, handled)},
+			{Match: Exact("jump"), Next: "first", Returns: []string{"second"}, Handle: returning("jump", "second", handled)},
+			{Match: Exact("undeclared"), Next: "first", Handle: returning("undeclared", "second", handled)},
+		},
+		Actions: []*Route{
+			{Match: Prefix("global/"), Handle: returning("global action", Stay, handled)},
+		},
+		States: []*State{
+			{Name: "menu"},
+			{
+				Name: "first",
+				Commands: []*Route{
+					{Match: Exact("start"), Handle: returning("first start", Stay, handled)},
+					{Match: Pattern(`^\d+$`), Next: "second", Handle: returning("number", Proceed, handled)},
+				},
+				Actions: []*Route{
+					{Match: Prefix("global/"), Handle: returning("first action", Stay, handled)},
+					{Match: Prefix("first/"), Next: "menu", Handle: returning("first action", Proceed, handled)},
+				},
+				OnEscape: onEscape,
+			},
+			{
+				Name: "second",
+				Commands: []*Route{
+					{Match: Any(), Handle: returning("any", Stay, handled)},
+				},
+			},
+		},
+	}), handled
+}
+
+func TestMatchCommand(t *testing.T) {
+	machine, handled := newTestMachine(new([]string))
+
+	tests := []struct {
+		state   string
+		command string
+		handler string
+	}{
+		{"menu", "start", "start"},
+		{"menu", "12", ""},
+		{"first", "start", "first start"},
+		{"first", "12", "number"},
+		{"first", "twelve", ""},
+		{"first", "escape", "escape"},
+		{"second", "escape", "escape"},
+		{"second", "start", "any"},
+		{"second", "", "any"},
+		{"unknown", "start", "start"},
+	}
+
+	for _, test := range tests {
+		*handled = nil
+
+		route := machine.MatchCommand(test.state, test.command)
+		if route == nil {
+			if test.handler != "" {
+				t.Errorf("expected %q to be accepted in %q", test.command, test.state)
+			}
+			continue
+		}
+
+		route.Handle(&Request{Session: &Session{}})
+		if len(*handled) != 1 || (*handled)[0] != test.handler {
+			t.Errorf("expected %q in %q to be handled by %q, got %v", test.command, test.state, test.handler, *handled)
+		}
+	}
+}
+
+func TestMatchAction(t *testing.T) {
+	machine, handled := newTestMachine(new([]string))
+
+	tests := []struct {
+		state   string
+		action  string
+		handler string
+	}{
+		{"menu", "global/1", "global action"},
+		{"first", "global/1", "global action"},
+		{"first", "first/1", "first action"},
+		{"menu", "first/1", ""},
+		{"second", "escape", ""},
+	}
+
+	for _, test := range tests {
+		*handled = nil
+
+		route := machine.MatchAction(test.state, test.action)
+		if route == nil {
+			if test.handler != "" {
+				t.Errorf("expected %q to be accepted in %q", test.action, test.state)
+			}
+			continue
+		}
+
+		route.Handle(&Request{Session: &Session{}})
+		if len(*handled) != 1 || (*handled)[0] != test.handler {
+			t.Errorf("expected %q in %q to be handled by %q, got %v", test.action, test.state, test.handler, *handled)
+		}
+	}
+}
+
+func TestHandleCommand(t *testing.T) {
+	escaped := new([]string)
+	machine, _ := newTestMachine(escaped)
+
+	tests := []struct {
+		state    string
+		command  string
+		accepted bool
+		next     string
+	}{
+		{"menu", "start", true, "first"},
+		{"first", "12", true, "second"},
+		{"first", "twelve", false, "first"},
+		{"second", "anything", true, "second"},
+		{"menu", "jump", true, "second"},
+
+		// A handler can't move the session to a state its route doesn't declare
+		{"menu", "undeclared", true, "menu"},
+	}
+
+	for _, test := range tests {
+		session := &Session{State: test.state}
+		accepted := machine.HandleCommand(&Request{Input: test.command, Session: session})
+
+		if accepted != test.accepted || session.State != test.next {
+			t.Errorf("expected %q in %q to be accepted %t and move to %q, got %t and %q",
+				test.command, test.state, test.accepted, test.next, accepted, session.State)
+		}
+	}
+
+	if len(*escaped) != 0 {
+		t.Errorf("expected no state to be escaped, got %v", *escaped)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	escaped := new([]string)
+	machine, _ := newTestMachine(escaped)
+
+	session := &Session{State: "first", Data: map[string]string{"key": "value"}}
+	if !machine.HandleCommand(&Request{Input: "escape", Session: session}) {
+		t.Fatalf("expected the escape route to be accepted")
+	}
+
+	if session.State != "menu" || session.Data != nil {
+		t.Errorf("expected the session to return to the initial state without data, got %+v", session)
+	}
+
+	if len(*escaped) != 1 || (*escaped)[0] != "first" {
+		t.Errorf("expected the escaped state to clean up, got %v", *escaped)
+	}
+
+	// A state without a clean up can be escaped too
+	session = &Session{State: "second"}
+	machine.HandleCommand(&Request{Input: "escape", Session: session})
+	if session.State != "menu" || len(*escaped) != 1 {
+		t.Errorf("expected only the escape route to run, got %q and %v", session.State, *escaped)
+	}
+
+	// An escape action isn't accepted since the escape route only matches commands
+	session = &Session{State: "first"}
+	if machine.HandleAction(&Request{Input: "escape", Session: session}) || len(*escaped) != 1 {
+		t.Errorf("expected the escape route not to match an action")
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	handle := func(request *Request) string { return Proceed }
+	states := []*State{{Name: "menu"}, {Name: "first"}}
+
+	tests := []struct {
+		name   string
+		config *Config
+		valid  bool
+	}{
+		{"valid", &Config{Initial: "menu", States: states,
+			Commands: []*Route{{Match: Any(), Handle: handle, Next: "first", Returns: []string{"menu"}}}}, true},
+		{"undeclared initial state", &Config{Initial: "start", States: states}, false},
+		{"state declared twice", &Config{Initial: "menu", States: append(states, &State{Name: "menu"})}, false},
+		{"undeclared next state", &Config{Initial: "menu", States: states,
+			Commands: []*Route{{Match: Any(), Handle: handle, Next: "second"}}}, false},
+		{"undeclared returned state", &Config{Initial: "menu", States: states,
+			Actions: []*Route{{Match: Any(), Handle: handle, Returns: []string{"second"}}}}, false},
+		{"undeclared state of an escape", &Config{Initial: "menu", States: states,
+			Escape: &Route{Match: Any(), Handle: handle, Returns: []string{"second"}}}, false},
+		{"route without a handler", &Config{Initial: "menu", States: []*State{{Name: "menu",
+			Commands: []*Route{{Match: Any()}}}}}, false},
+	}
+
+	for _, test := range tests {
+		err := NewMachine(test.config).Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("%s: expected valid to be %t, got %v", test.name, test.valid, err)
+		}
+	}
+}
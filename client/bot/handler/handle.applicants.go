@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// applicant is a type that pairs a job application with the profile of its job seeker
+type applicant struct {
+	application *entity.JobApplication
+	profile     *entity.User
+}
+
+// HandleApplicantsAction is a method that handles the applicant list buttons of an employer's job.
+// The action has the form applicants/<list|page|cv|csv>/<job id>[/<page> or /<job seeker id>]
+func (handler *TelegramBotHandler) HandleApplicantsAction(action string, update *bot.Update, user *entity.User) {
+
+	query := update.CallbackQuery
+	segments := strings.Split(action, "/")
+	if len(segments) < 3 {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+		return
+	}
+
+	job, err := handler.jbService.FindJob(segments[2])
+	if err != nil || job.Employer != user.ID {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "job.error.not_found"))
+		return
+	}
+
+	applicants := handler.findApplicants(job.ID)
+	if len(applicants) == 0 {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "applicants.empty"))
+		return
+	}
+
+	switch segments[1] {
+	case "list":
+		reply, inlineKeyboard := buildApplicantsPage(job, applicants, 0, user.Language)
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		handler.SendReplyToTelegramChat(query.User.ID, reply, inlineKeyboard)
+
+	case "page":
+		var page int64
+		if len(segments) == 4 {
+			page, _ = strconv.ParseInt(segments[3], 10, 64)
+		}
+
+		reply, inlineKeyboard := buildApplicantsPage(job, applicants, page, user.Language)
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		handler.EditTelegramMessage(query.Message.Chat.ID, query.Message.MessageID, reply, inlineKeyboard)
+
+	case "cv":
+		if len(segments) != 4 {
+			handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+			return
+		}
+
+		handler.AnswerToTelegramCallBack(query.ID, handler.ResendApplicantCV(job, segments[3], query.User.ID, user.Language))
+
+	case "csv":
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		_, err = handler.UploadDocumentToTelegramChat(query.User.ID, fmt.Sprintf("applicants_%s.csv", job.ID),
+			buildApplicantsCSV(applicants), locale.Text(user.Language, "applicants.export.caption",
+				html.EscapeString(job.Title)))
+		if err != nil {
+			handler.SendReplyToTelegramChat(query.User.ID, locale.Text(user.Language, "applicants.error.export"))
+		}
+
+	default:
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+	}
+}
+
+// ResendApplicantCV is a method that sends the stored cv of an applicant to the employer again,
+// it returns the text the callback should be answered with in the given language
+func (handler *TelegramBotHandler) ResendApplicantCV(job *entity.Job, jobSeekerID string, chatID int64,
+	language string) string {
+
+	jobApplication, err := handler.jaService.FindJobApplication(job.ID, jobSeekerID)
+	if err != nil {
+		return locale.Text(language, "application.error.withdrawn")
+	}
+
+	if jobApplication.CVFileID == "" {
+		return locale.Text(language, "applicants.error.no_cv")
+	}
+
+	name := jobSeekerID
+	if profile, err := handler.urService.FindUser(jobSeekerID); err == nil {
+		name = profile.UserName
+	}
+
+	applyCaption := locale.Text(language, "applicants.cv.caption", html.EscapeString(job.Title),
+		html.EscapeString(name))
+
+	_, err = handler.SendDocumentToTelegramChat(chatID, jobApplication.CVFileID, applyCaption,
+		applicationMenu(jobApplication, language))
+	if err != nil {
+		return locale.Text(language, "applicants.error.send_cv")
+	}
+
+	return ""
+}
+
+// findApplicants is a method that returns the applicants of a job along with their profiles, oldest first
+func (handler *TelegramBotHandler) findApplicants(jobID string) []*applicant {
+
+	applicants := make([]*applicant, 0)
+	for _, jobApplication := range handler.jaService.FindJobApplications(jobID) {
+		if jobApplication.JobID != jobID {
+			continue
+		}
+
+		profile, err := handler.urService.FindUser(jobApplication.JobSeekerID)
+		if err != nil {
+			continue
+		}
+
+		applicants = append(applicants, &applicant{application: jobApplication, profile: profile})
+	}
+
+	sort.Slice(applicants, func(i, j int) bool {
+		return applicants[i].application.CreatedAt.Before(applicants[j].application.CreatedAt)
+	})
+
+	return applicants
+}
+
+// applicantsButton is a function that returns the button that lists the applicants of a job in the given language
+func applicantsButton(jobID string, numOfApplicants int, language string) []bot.InlineKeyboardButton {
+	return []bot.InlineKeyboardButton{
+		{Text: locale.Text(language, "applicants.list", numOfApplicants), CallbackData: "applicants/list/" + jobID},
+	}
+}
+
+// buildApplicantsPage is a function that builds a single page of the applicants of a job along with
+// the cv, export and pagination buttons in the given language
+func buildApplicantsPage(job *entity.Job, applicants []*applicant, page int64, language string) (string, string) {
+
+	pageCount := (int64(len(applicants)) + bot.ApplicantsPerPage - 1) / bot.ApplicantsPerPage
+	if page >= pageCount {
+		page = pageCount - 1
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	start := page * bot.ApplicantsPerPage
+	end := start + bot.ApplicantsPerPage
+	if end > int64(len(applicants)) {
+		end = int64(len(applicants))
+	}
+
+	reply := locale.Text(language, "applicants.title", html.EscapeString(job.Title))
+	buttons := make([][]bot.InlineKeyboardButton, 0)
+	cvButtons := make([]bot.InlineKeyboardButton, 0)
+
+	for index, entry := range applicants[start:end] {
+		number := start + int64(index) + 1
+
+		reply += locale.Text(language, "applicants.entry", number, html.EscapeString(entry.profile.UserName),
+			entry.profile.PhoneNumber, applicationStatusName(entry.application, language))
+
+		if entry.application.CVFileID != "" {
+			cvButtons = append(cvButtons, bot.InlineKeyboardButton{
+				Text:         locale.Text(language, "applicants.cv", number),
+				CallbackData: fmt.Sprintf("applicants/cv/%s/%s", job.ID, entry.application.JobSeekerID)})
+		}
+
+		// Keeping the cv buttons in rows of five
+		if len(cvButtons) == 5 {
+			buttons = append(buttons, cvButtons)
+			cvButtons = make([]bot.InlineKeyboardButton, 0)
+		}
+	}
+
+	if len(cvButtons) > 0 {
+		buttons = append(buttons, cvButtons)
+	}
+
+	reply += locale.Text(language, "common.page", page+1, pageCount)
+
+	navigation := make([]bot.InlineKeyboardButton, 0)
+	if page > 0 {
+		navigation = append(navigation, bot.InlineKeyboardButton{Text: locale.Text(language, "common.prev"),
+			CallbackData: fmt.Sprintf("applicants/page/%s/%d", job.ID, page-1)})
+	}
+
+	if page < pageCount-1 {
+		navigation = append(navigation, bot.InlineKeyboardButton{Text: locale.Text(language, "common.next"),
+			CallbackData: fmt.Sprintf("applicants/page/%s/%d", job.ID, page+1)})
+	}
+
+	if len(navigation) > 0 {
+		buttons = append(buttons, navigation)
+	}
+
+	buttons = append(buttons, []bot.InlineKeyboardButton{
+		{Text: locale.Text(language, "applicants.export"), CallbackData: "applicants/csv/" + job.ID},
+	})
+
+	return reply, bot.CreateInlineKeyboard(buttons...)
+}
+
+// buildApplicantsCSV is a function that returns the applicants of a job as a csv file content,
+// the file holds the raw application statuses so it isn't translated
+func buildApplicantsCSV(applicants []*applicant) []byte {
+
+	content := new(bytes.Buffer)
+	writer := csv.NewWriter(content)
+
+	writer.Write([]string{"Name", "Phone Number", "Status", "Applied At", "Updated At"})
+	for _, entry := range applicants {
+		// Phone numbers are validated to only have digits after the country code so they are written as they are
+		writer.Write([]string{csvCell(entry.profile.UserName), entry.profile.PhoneNumber,
+			applicationStatus(entry.application), entry.application.CreatedAt.Format(time.RFC3339),
+			entry.application.UpdatedAt.Format(time.RFC3339)})
+	}
+
+	writer.Flush()
+	return content.Bytes()
+}
+
+// csvCell is a function that prefixes a user provided csv cell with a quote if it starts like a formula,
+// so spreadsheet applications show the value instead of evaluating it
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// applicationStatus is a function that returns the status of a job application,
+// applications made before statuses were introduced are considered as submitted
+func applicationStatus(jobApplication *entity.JobApplication) string {
+	if jobApplication.Status == "" {
+		return entity.JobApplicationSubmitted
+	}
+	return jobApplication.Status
+}
+
+// applicationStatusName is a function that returns the status of a job application in the given language
+func applicationStatusName(jobApplication *entity.JobApplication, language string) string {
+	return locale.Text(language, "application.status."+strings.ToLower(applicationStatus(jobApplication)))
+}
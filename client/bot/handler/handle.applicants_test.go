@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+func TestBuildApplicantsCSV(t *testing.T) {
+
+	names := []string{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)", "\tTab", "Almaz Tesfaye", ""}
+	expected := []string{"'=HYPERLINK(\"http://evil\")", "'+1+1", "'-2+3", "'@SUM(A1)", "'\tTab", "Almaz Tesfaye", ""}
+
+	applicants := make([]*applicant, 0)
+	for _, name := range names {
+		applicants = append(applicants, &applicant{application: &entity.JobApplication{},
+			profile: &entity.User{UserName: name, PhoneNumber: "+251911000002"}})
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(buildApplicantsCSV(applicants))).ReadAll()
+	if err != nil {
+		t.Fatalf("unable to read the csv, %s", err)
+	}
+
+	if len(records) != len(names)+1 {
+		t.Fatalf("expected %d records, got %d", len(names)+1, len(records))
+	}
+
+	for i, record := range records[1:] {
+		if record[0] != expected[i] {
+			t.Errorf("expected name %q to be written as %q, got %q", names[i], expected[i], record[0])
+		}
+
+		if record[1] != "+251911000002" {
+			t.Errorf("expected the phone number to be written as it is, got %q", record[1])
+		}
+	}
+}
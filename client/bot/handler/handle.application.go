@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/notifier"
+)
+
+// HandleApplicationAction is a method that handles the buttons attached to an application sent to an employer and
+// returns the application the employer is going to write a message about, if any.
+// The action has the form application/<shortlist|reject|hire|message>/<job id>/<job seeker id>
+func (handler *TelegramBotHandler) HandleApplicationAction(action string, update *bot.Update,
+	user *entity.User) *entity.JobApplication {
+
+	query := update.CallbackQuery
+	segments := strings.Split(action, "/")
+	if len(segments) != 4 {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+		return nil
+	}
+
+	applicationAction, jobID, jobSeekerID := segments[1], segments[2], segments[3]
+
+	job, err := handler.jbService.FindJob(jobID)
+	if err != nil || job.Employer != user.ID {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "job.error.not_found"))
+		return nil
+	}
+
+	jobApplication, err := handler.jaService.FindJobApplication(jobID, jobSeekerID)
+	if err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "application.error.withdrawn"))
+		return nil
+	}
+
+	if applicationAction == bot.ApplicationMessage {
+		handler.markApplicationAsViewed(jobApplication, job)
+
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(user.Language, "button.main_menu")})
+		handler.SendReplyToTelegramChat(query.User.ID, locale.Text(user.Language, "application.write_message"), backMenu)
+		return jobApplication
+	}
+
+	var status string
+	switch applicationAction {
+	case bot.ApplicationShortlist:
+		status = entity.JobApplicationShortlisted
+	case bot.ApplicationReject:
+		status = entity.JobApplicationRejected
+	case bot.ApplicationHire:
+		status = entity.JobApplicationHired
+	default:
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+		return nil
+	}
+
+	jobApplication, err = handler.jaService.ChangeJobApplicationStatus(jobID, jobSeekerID, status)
+	if err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "application.error.update"))
+		return nil
+	}
+
+	handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "application.changed."+strings.ToLower(status)))
+	handler.EditTelegramReplyMarkup(query.Message.Chat.ID, query.Message.MessageID,
+		applicationMenu(jobApplication, user.Language))
+	handler.NotifyJobSeeker(jobApplication, job)
+	return nil
+}
+
+// HandleMessageApplicant is a method that relays a message written by an employer to the job seeker of an application
+func (handler *TelegramBotHandler) HandleMessageApplicant(jobID, jobSeekerID string, update *bot.Update,
+	user *entity.User) bool {
+
+	message := strings.TrimSpace(update.Message.Text)
+	if message == "" {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.write_message"))
+		return false
+	}
+
+	job, err := handler.jbService.FindJob(jobID)
+	if err != nil || job.Employer != user.ID {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.error.message"))
+		return true
+	}
+
+	jobSeeker, err := handler.urService.FindUser(jobSeekerID)
+	if err != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.error.unreachable"))
+		return true
+	}
+
+	chatID, err := handler.jobSeekerChatID(jobSeekerID)
+	if err != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.error.unreachable"))
+		return true
+	}
+
+	_, err = handler.SendReplyToTelegramChat(chatID, locale.Text(jobSeeker.Language, "application.employer_message",
+		html.EscapeString(job.Title), html.EscapeString(message)))
+	if err != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.error.unreachable"))
+		return true
+	}
+
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.message_sent"))
+	return true
+}
+
+// NotifyJobSeeker is a method that informs a job seeker about the current status of an application
+func (handler *TelegramBotHandler) NotifyJobSeeker(jobApplication *entity.JobApplication, job *entity.Job) error {
+
+	switch jobApplication.Status {
+	case entity.JobApplicationViewed, entity.JobApplicationShortlisted, entity.JobApplicationRejected,
+		entity.JobApplicationHired:
+	default:
+		return nil
+	}
+
+	jobSeeker, err := handler.urService.FindUser(jobApplication.JobSeekerID)
+	if err != nil {
+		return err
+	}
+
+	language := jobSeeker.Language
+	status := applicationStatusName(jobApplication, language)
+	update := locale.Text(language, "application.update."+strings.ToLower(jobApplication.Status))
+
+	notification := &notifier.Notification{
+		Subject: locale.Text(language, "application.update.subject", job.Title),
+		Message: locale.Text(language, "application.update.message", html.EscapeString(job.Title), status, update),
+		Summary: locale.Text(language, "application.update.summary", job.Title, status, update),
+	}
+
+	// Telegram is tried first and the job seeker's fallback channel is used if it fails
+	err = handler.notifier.Notify(jobSeeker, notification)
+	if err != nil && err != notifier.ErrUnreachable {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to notify job seeker %s about job %s, %s",
+			jobSeeker.ID, job.ID, err.Error()), entity.BotLogFile)
+	}
+
+	return err
+}
+
+// HandleMyApplications is a method that shows the first page of the applications a user has made
+func (handler *TelegramBotHandler) HandleMyApplications(update *bot.Update, user *entity.User) {
+
+	reply, inlineKeyboard := handler.buildMyApplicationsPage(user, 0)
+	if reply == "" {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "application.empty"))
+		return
+	}
+
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, reply, inlineKeyboard)
+}
+
+// HandleMyApplicationsAction is a method that handles the pagination and withdraw buttons of "My Applications".
+// The action has the form applications/page/<page> or applications/withdraw/<page>/<job id>
+func (handler *TelegramBotHandler) HandleMyApplicationsAction(action string, update *bot.Update, user *entity.User) {
+
+	query := update.CallbackQuery
+	segments := strings.Split(action, "/")
+	if len(segments) < 3 {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "common.invalid_action"))
+		return
+	}
+
+	page, err := strconv.ParseInt(segments[2], 10, 64)
+	if err != nil || page < 0 {
+		page = 0
+	}
+
+	answer := ""
+	if segments[1] == "withdraw" && len(segments) == 4 {
+		answer, err = handler.WithdrawJobApplication(segments[3], user)
+		if err != nil {
+			handler.AnswerToTelegramCallBack(query.ID, answer)
+			return
+		}
+	}
+
+	reply, inlineKeyboard := handler.buildMyApplicationsPage(user, page)
+	if reply == "" {
+		reply = locale.Text(user.Language, "application.empty")
+	}
+
+	handler.AnswerToTelegramCallBack(query.ID, answer)
+	handler.EditTelegramMessage(query.Message.Chat.ID, query.Message.MessageID, reply, inlineKeyboard)
+}
+
+// WithdrawJobApplication is a method that removes an application of a user while the job is still open
+func (handler *TelegramBotHandler) WithdrawJobApplication(jobID string, user *entity.User) (string, error) {
+
+	jobApplication, err := handler.jaService.FindJobApplication(jobID, user.ID)
+	if err != nil {
+		return locale.Text(user.Language, "application.error.not_found"), err
+	}
+
+	job, err := handler.jbService.FindJob(jobID)
+	if err != nil || !isWithdrawable(jobApplication, job) {
+		return locale.Text(user.Language, "application.error.not_withdrawable"), errors.New("application can't be withdrawn")
+	}
+
+	_, err = handler.jaService.DeleteJobApplication(jobID, user.ID)
+	if err != nil {
+		return locale.Text(user.Language, "application.error.withdraw"), err
+	}
+
+	return locale.Text(user.Language, "application.withdrawn"), nil
+}
+
+// buildMyApplicationsPage is a method that builds a single page of the applications a user has made, newest first,
+// along with the withdraw and pagination buttons. An empty reply is returned if the user hasn't applied for any job.
+func (handler *TelegramBotHandler) buildMyApplicationsPage(user *entity.User, page int64) (string, string) {
+
+	type applicationEntry struct {
+		application *entity.JobApplication
+		job         *entity.Job
+	}
+
+	entries := make([]applicationEntry, 0)
+	for _, jobApplication := range handler.jaService.FindJobApplications(user.ID) {
+		if jobApplication.JobSeekerID != user.ID {
+			continue
+		}
+
+		job, err := handler.jbService.FindJob(jobApplication.JobID)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, applicationEntry{application: jobApplication, job: job})
+	}
+
+	if len(entries) == 0 {
+		return "", ""
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].application.CreatedAt.After(entries[j].application.CreatedAt)
+	})
+
+	pageCount := (int64(len(entries)) + bot.ApplicationsPerPage - 1) / bot.ApplicationsPerPage
+	if page >= pageCount {
+		page = pageCount - 1
+	}
+
+	start := page * bot.ApplicationsPerPage
+	end := start + bot.ApplicationsPerPage
+	if end > int64(len(entries)) {
+		end = int64(len(entries))
+	}
+
+	reply := locale.Text(user.Language, "application.list.title")
+	withdrawButtons := make([]bot.InlineKeyboardButton, 0)
+
+	for index, entry := range entries[start:end] {
+		number := start + int64(index) + 1
+
+		reply += locale.Text(user.Language, "application.list.entry", number, html.EscapeString(entry.job.Title),
+			bot.GetJobStatus(entry.job.Status, user.Language), applicationStatusName(entry.application, user.Language))
+
+		if isWithdrawable(entry.application, entry.job) {
+			withdrawButtons = append(withdrawButtons, bot.InlineKeyboardButton{
+				Text:         locale.Text(user.Language, "application.withdraw", number),
+				CallbackData: fmt.Sprintf("applications/withdraw/%d/%s", page, entry.job.ID)})
+		}
+	}
+
+	reply += locale.Text(user.Language, "common.page", page+1, pageCount)
+
+	buttons := make([][]bot.InlineKeyboardButton, 0)
+	if len(withdrawButtons) > 0 {
+		buttons = append(buttons, withdrawButtons)
+	}
+
+	navigation := make([]bot.InlineKeyboardButton, 0)
+	if page > 0 {
+		navigation = append(navigation, bot.InlineKeyboardButton{Text: locale.Text(user.Language, "common.prev"),
+			CallbackData: fmt.Sprintf("applications/page/%d", page-1)})
+	}
+
+	if page < pageCount-1 {
+		navigation = append(navigation, bot.InlineKeyboardButton{Text: locale.Text(user.Language, "common.next"),
+			CallbackData: fmt.Sprintf("applications/page/%d", page+1)})
+	}
+
+	if len(navigation) > 0 {
+		buttons = append(buttons, navigation)
+	}
+
+	if len(buttons) == 0 {
+		return reply, ""
+	}
+
+	return reply, bot.CreateInlineKeyboard(buttons...)
+}
+
+// markApplicationAsViewed is a method that moves a submitted application to viewed and notifies the job seeker
+func (handler *TelegramBotHandler) markApplicationAsViewed(jobApplication *entity.JobApplication, job *entity.Job) {
+
+	if jobApplication.Status != entity.JobApplicationSubmitted && jobApplication.Status != "" {
+		return
+	}
+
+	viewedApplication, err := handler.jaService.ChangeJobApplicationStatus(jobApplication.JobID,
+		jobApplication.JobSeekerID, entity.JobApplicationViewed)
+	if err == nil {
+		handler.NotifyJobSeeker(viewedApplication, job)
+	}
+}
+
+// jobSeekerChatID is a method that returns the telegram chat id of a job seeker that can be reached through the bot
+func (handler *TelegramBotHandler) jobSeekerChatID(jobSeekerID string) (int64, error) {
+
+	client, err := handler.clService.FindClient(jobSeekerID)
+	if err != nil || client.Blocked {
+		return 0, errors.New("the job seeker can't be reached through the bot")
+	}
+
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	return chatID, nil
+}
+
+// applicationMenu is a function that returns the buttons attached to an application sent to an employer in the
+// given language, the buttons change with the status of the application
+func applicationMenu(jobApplication *entity.JobApplication, language string) string {
+
+	target := jobApplication.JobID + "/" + jobApplication.JobSeekerID
+	buttons := [][]bot.InlineKeyboardButton{
+		{{Text: locale.Text(language, "application.job_details"), CallbackData: "job/view/" + jobApplication.JobID}},
+	}
+
+	switch jobApplication.Status {
+	case "", entity.JobApplicationSubmitted, entity.JobApplicationViewed:
+		buttons = append(buttons, []bot.InlineKeyboardButton{
+			{Text: locale.Text(language, "application.shortlist"),
+				CallbackData: "application/" + bot.ApplicationShortlist + "/" + target},
+			{Text: locale.Text(language, "application.reject"),
+				CallbackData: "application/" + bot.ApplicationReject + "/" + target},
+		})
+	case entity.JobApplicationShortlisted:
+		buttons = append(buttons, []bot.InlineKeyboardButton{
+			{Text: locale.Text(language, "application.hire"),
+				CallbackData: "application/" + bot.ApplicationHire + "/" + target},
+			{Text: locale.Text(language, "application.reject"),
+				CallbackData: "application/" + bot.ApplicationReject + "/" + target},
+		})
+	}
+
+	buttons = append(buttons, []bot.InlineKeyboardButton{
+		{Text: locale.Text(language, "application.message"),
+			CallbackData: "application/" + bot.ApplicationMessage + "/" + target},
+	})
+
+	return bot.CreateInlineKeyboard(buttons...)
+}
+
+// isWithdrawable is a function that checks whether an application can still be withdrawn by the job seeker,
+// which is only while the job is open and the employer hasn't made a final decision
+func isWithdrawable(jobApplication *entity.JobApplication, job *entity.Job) bool {
+	return job.Status == entity.JobStatusOpened && jobApplication.Status != entity.JobApplicationRejected &&
+		jobApplication.Status != entity.JobApplicationHired
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot/faketelegram"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// addApplication is a function that adds an application of the registered user for the given job
+func addApplication(fakeBot *faketelegram.Bot, jobID, status string, createdAt time.Time) {
+	fakeBot.DB.Insert("job_applications", &entity.JobApplication{JobID: jobID, JobSeekerID: employerID,
+		Status: status, CreatedAt: createdAt})
+}
+
+// pressApplicationsButton is a function that presses a "My Applications" button and returns
+// the answer of the callback along with the text and the buttons of the edited page
+func pressApplicationsButton(t *testing.T, fakeBot *faketelegram.Bot, data string) (string, string, string) {
+	t.Helper()
+
+	answer, text, markup := "", "", ""
+	for _, call := range fakeBot.SendCallback(employerTID, data) {
+		switch call.Method {
+		case "answerCallbackQuery":
+			answer = call.Text()
+		case "editMessageText":
+			text, markup = call.Text(), call.ReplyMarkup()
+		}
+	}
+
+	if text == "" && answer == "" {
+		t.Fatalf("expected %s to be answered", data)
+	}
+
+	return answer, text, markup
+}
+
+func TestMyApplicationsPages(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	now := fakeBot.Clock.Now()
+
+	// JB-11 is the newest application so JB-1 is the only one on the last page
+	for i := 1; i <= 11; i++ {
+		jobID := fmt.Sprintf("JB-%d", i)
+		addJob(fakeBot, jobID, entity.JobStatusOpened, nil)
+		addApplication(fakeBot, jobID, entity.JobApplicationSubmitted, now.Add(time.Duration(i)*time.Minute))
+	}
+
+	tests := []struct {
+		data     string
+		page     string
+		jobs     []string
+		buttons  []string
+		excluded []string
+	}{
+		{"applications/page/0", "Page 1 of 3", []string{"Job JB-11", "Job JB-7"},
+			[]string{"applications/page/1", "applications/withdraw/0/JB-11"}, []string{"applications/page/-1", "Job JB-6"}},
+		{"applications/page/1", "Page 2 of 3", []string{"Job JB-6", "Job JB-2"},
+			[]string{"applications/page/0", "applications/page/2", "applications/withdraw/1/JB-2"}, []string{"Job JB-7"}},
+		{"applications/page/2", "Page 3 of 3", []string{"Job JB-1"},
+			[]string{"applications/page/1", "applications/withdraw/2/JB-1"}, []string{"applications/page/3", "Job JB-2"}},
+		{"applications/page/9", "Page 3 of 3", []string{"Job JB-1"}, []string{"applications/withdraw/2/JB-1"}, nil},
+		{"applications/page/-1", "Page 1 of 3", []string{"Job JB-11"}, nil, nil},
+		{"applications/page/next", "Page 1 of 3", []string{"Job JB-11"}, nil, nil},
+	}
+
+	for _, test := range tests {
+		_, text, markup := pressApplicationsButton(t, fakeBot, test.data)
+		if !strings.Contains(text, test.page) {
+			t.Errorf("%s: expected %q to be shown, got %q", test.data, test.page, text)
+		}
+
+		for _, job := range test.jobs {
+			if !strings.Contains(text, job+"<") {
+				t.Errorf("%s: expected %s to be listed, got %q", test.data, job, text)
+			}
+		}
+
+		for _, button := range test.buttons {
+			if !strings.Contains(markup, `"`+button+`"`) {
+				t.Errorf("%s: expected a %s button, got %s", test.data, button, markup)
+			}
+		}
+
+		for _, excluded := range test.excluded {
+			if strings.Contains(text, excluded+"<") || strings.Contains(markup, `"`+excluded+`"`) {
+				t.Errorf("%s: expected %s not to be shown", test.data, excluded)
+			}
+		}
+	}
+
+	// Withdrawing the only application of the last page shows the new last page
+	answer, text, markup := pressApplicationsButton(t, fakeBot, "applications/withdraw/2/JB-1")
+	if answer != locale.Text(locale.DefaultLanguage, "application.withdrawn") {
+		t.Errorf("expected the application to be withdrawn, got %q", answer)
+	}
+
+	if !strings.Contains(text, "Page 2 of 2") || !strings.Contains(text, "Job JB-2<") ||
+		strings.Contains(markup, `"applications/page/2"`) {
+		t.Errorf("expected the page to be clamped to the new last page, got %q", text)
+	}
+
+	if _, err := fakeBot.Handler.WithdrawJobApplication("JB-1", &entity.User{ID: employerID}); err == nil {
+		t.Errorf("expected a withdrawn application not to be found")
+	}
+
+	// A page that is full after a withdraw keeps its number
+	_, text, _ = pressApplicationsButton(t, fakeBot, "applications/withdraw/0/JB-11")
+	if !strings.Contains(text, "Page 1 of 2") || strings.Contains(text, "Job JB-11<") || !strings.Contains(text, "Job JB-6<") {
+		t.Errorf("expected the first page to be refilled from the next page, got %q", text)
+	}
+}
+
+func TestMyApplicationsEmpty(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	addJob(fakeBot, "JB-1", entity.JobStatusOpened, nil)
+	addApplication(fakeBot, "JB-1", entity.JobApplicationViewed, fakeBot.Clock.Now())
+
+	_, text, markup := pressApplicationsButton(t, fakeBot, "applications/withdraw/0/JB-1")
+	if text != locale.Text(locale.DefaultLanguage, "application.empty") || strings.Contains(markup, "applications/") {
+		t.Errorf("expected no application to be left, got %q with %q", text, markup)
+	}
+
+	answer, _, _ := pressApplicationsButton(t, fakeBot, "applications/withdraw/0/JB-1")
+	if answer != locale.Text(locale.DefaultLanguage, "application.error.not_found") {
+		t.Errorf("expected an application that has been withdrawn not to be found, got %q", answer)
+	}
+}
+
+func TestWithdrawJobApplicationRules(t *testing.T) {
+
+	tests := []struct {
+		jobStatus         string
+		applicationStatus string
+		withdrawable      bool
+	}{
+		{entity.JobStatusOpened, "", true},
+		{entity.JobStatusOpened, entity.JobApplicationSubmitted, true},
+		{entity.JobStatusOpened, entity.JobApplicationViewed, true},
+		{entity.JobStatusOpened, entity.JobApplicationShortlisted, true},
+		{entity.JobStatusOpened, entity.JobApplicationRejected, false},
+		{entity.JobStatusOpened, entity.JobApplicationHired, false},
+		{entity.JobStatusClosed, entity.JobApplicationSubmitted, false},
+		{entity.JobStatusClosed, entity.JobApplicationShortlisted, false},
+		{entity.JobStatusDecelined, entity.JobApplicationSubmitted, false},
+	}
+
+	for _, test := range tests {
+		name := fmt.Sprintf("%s job with a %q application", test.jobStatus, test.applicationStatus)
+
+		fakeBot := newSchedulerBot(t)
+		addJob(fakeBot, "JB-1", test.jobStatus, nil)
+		addApplication(fakeBot, "JB-1", test.applicationStatus, fakeBot.Clock.Now())
+
+		_, _, markup := pressApplicationsButton(t, fakeBot, "applications/page/0")
+		if strings.Contains(markup, `"applications/withdraw/0/JB-1"`) != test.withdrawable {
+			t.Errorf("%s: expected the withdraw button to be shown %t, got %s", name, test.withdrawable, markup)
+		}
+
+		// Pressing an old withdraw button follows the same rules
+		answer, _, _ := pressApplicationsButton(t, fakeBot, "applications/withdraw/0/JB-1")
+		withdrawn := answer == locale.Text(locale.DefaultLanguage, "application.withdrawn")
+		if withdrawn != test.withdrawable {
+			t.Errorf("%s: expected the application to be withdrawn %t, got %q", name, test.withdrawable, answer)
+		}
+
+		if !test.withdrawable && answer != locale.Text(locale.DefaultLanguage, "application.error.not_withdrawable") {
+			t.Errorf("%s: expected the application not to be withdrawable, got %q", name, answer)
+		}
+
+		if kept := len(fakeBot.DB.Select("job_applications", nil)) == 1; kept == test.withdrawable {
+			t.Errorf("%s: expected the application to be kept %t", name, !test.withdrawable)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/Benyam-S/asseri/client/bot/locale"
+)
+
+func TestValidationText(t *testing.T) {
+
+	// The fields of the service validations that are replied to the user
+	fields := []string{"user_name", "phone_number", "category", "email", "notification_fallback", "language",
+		"employer", "title", "description", "link", "contact_info", "type", "sector", "education_level", "experience",
+		"contact_type", "gender", "due_date", "subscription", "comment", "message"}
+
+	for _, language := range locale.Languages() {
+		invalid := locale.Text(language, "validation.invalid")
+		for _, field := range fields {
+			if text := validationText(language, field); text == invalid || text == "validation."+field {
+				t.Errorf("expected %s to have a %s validation message, got %q", field, language, text)
+			}
+		}
+
+		if text := validationText(language, "unknown"); text != invalid {
+			t.Errorf("expected an unknown field to get the generic %s message, got %q", language, text)
+		}
+	}
+
+	if validationText("am", "title") == validationText("en", "title") {
+		t.Errorf("expected the validation message to be translated")
+	}
+}
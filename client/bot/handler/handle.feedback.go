@@ -1,18 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"html"
+	"strconv"
 	"strings"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
-	"github.com/Benyam-S/asseri/tools"
 )
 
 // HandlePromptFeedback is a method that initiate feedback receiving process
-func (handler *TelegramBotHandler) HandlePromptFeedback(update *bot.Update) {
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Please write your feedback in concise and short way",
-		backMenu)
+func (handler *TelegramBotHandler) HandlePromptFeedback(update *bot.Update, user *entity.User) {
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.prompt"), backMenu)
 }
 
 // HandleReceiveFeedback is a method that handles feedback receiving process
@@ -24,19 +26,122 @@ func (handler *TelegramBotHandler) HandleReceiveFeedback(update *bot.Update, use
 	errMap := handler.fdService.ValidateFeedback(feedback)
 
 	if errMap["comment"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["comment"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Please write your feedback in concise and short way")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(user.Language, "comment"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.prompt"))
 		return false
 	}
 
 	err := handler.fdService.AddFeedback(feedback)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to add your feedback!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-enter your feedback")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.error.add"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.reenter"))
 		return false
 	}
 
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Thank you 😁 for your feedback!"+
-		" We will do our best to satisfy your requests.")
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.received"))
 	return true
 }
+
+// DeliverFeedbackReply is a method that sends a staff reply of a feedback to the telegram client of the user
+// that has given the feedback, along with buttons for answering back and closing the thread
+func (handler *TelegramBotHandler) DeliverFeedbackReply(feedback *entity.Feedback, reply *entity.FeedbackReply) error {
+
+	user, err := handler.urService.FindUser(feedback.UserID)
+	if err != nil {
+		return errors.New("the feedback doesn't belong to a user")
+	}
+
+	client, err := handler.clService.FindClient(user.ID)
+	if err != nil || client.Blocked {
+		return errors.New("the user can't be reached through the bot")
+	}
+
+	message := locale.Text(user.Language, "feedback.reply", html.EscapeString(feedback.Comment),
+		html.EscapeString(reply.Message))
+
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	_, err = handler.SendReplyToTelegramChat(chatID, message, feedbackReplyMenu(feedback.ID, user.Language))
+	return err
+}
+
+// HandleFeedbackReplyAction is a method that handles the buttons sent along with a feedback reply and
+// returns the id of the feedback the user is going to answer back to, if any
+func (handler *TelegramBotHandler) HandleFeedbackReplyAction(action string, update *bot.Update,
+	user *entity.User) string {
+
+	var feedbackID string
+	closing := strings.HasPrefix(action, "feedback/close/")
+	if closing {
+		feedbackID = action[len("feedback/close/"):]
+	} else {
+		feedbackID = action[len("feedback/reply/"):]
+	}
+
+	feedback, err := handler.fdService.FindFeedback(feedbackID)
+	if err != nil || feedback.UserID != user.ID {
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, locale.Text(user.Language, "feedback.error.not_found"))
+		return ""
+	}
+
+	if feedback.Status == entity.FeedbackStatusClosed {
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, locale.Text(user.Language, "feedback.error.closed"))
+		return ""
+	}
+
+	if closing {
+		_, err = handler.fdService.ChangeFeedbackStatus(feedback.ID, entity.FeedbackStatusClosed)
+		if err != nil {
+			handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, locale.Text(user.Language, "feedback.error.close"))
+			return ""
+		}
+
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, "")
+		handler.SendReplyToTelegramChat(update.CallbackQuery.User.ID, locale.Text(user.Language, "feedback.closed"))
+		return ""
+	}
+
+	handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, "")
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.CallbackQuery.User.ID, locale.Text(user.Language, "feedback.write_reply"),
+		backMenu)
+	return feedback.ID
+}
+
+// HandleReceiveFeedbackReply is a method that adds the reply of a user to the thread of a feedback
+func (handler *TelegramBotHandler) HandleReceiveFeedbackReply(feedbackID string, update *bot.Update,
+	user *entity.User) bool {
+
+	reply := &entity.FeedbackReply{FeedbackID: feedbackID, SenderID: user.ID,
+		SenderType: entity.FeedbackSenderUser, Message: strings.TrimSpace(update.Message.Text)}
+
+	errMap := handler.fdService.ValidateFeedbackReply(reply)
+	if errMap["message"] != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(user.Language, "message"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.write_reply"))
+		return false
+	}
+
+	_, err := handler.fdService.ReplyToFeedback(reply)
+	if err != nil {
+		if err.Error() == "feedback thread is closed" {
+			handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.error.closed"))
+			return true
+		}
+
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.error.reply"))
+		return true
+	}
+
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "feedback.reply_sent"))
+	return true
+}
+
+// feedbackReplyMenu is a function that returns the buttons sent along with a reply to a feedback in the given language
+func feedbackReplyMenu(feedbackID, language string) string {
+	return bot.CreateInlineKeyboard(
+		[]bot.InlineKeyboardButton{
+			{Text: locale.Text(language, "feedback.reply.answer"), CallbackData: "feedback/reply/" + feedbackID},
+			{Text: locale.Text(language, "feedback.reply.close"), CallbackData: "feedback/close/" + feedbackID},
+		},
+	)
+}
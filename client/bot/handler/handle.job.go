@@ -1,69 +1,44 @@
 package handler
 
 import (
-	"encoding/json"
 	"errors"
-	"fmt"
+	"html"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
 	"github.com/Benyam-S/asseri/tools"
-	"github.com/google/uuid"
 )
 
-// HandlePostJob is a method that sends the job posting website url
-func (handler *TelegramBotHandler) HandlePostJob(update *bot.Update, user *entity.User) {
-
-	if user.Category == entity.UserCategoryJobSeeker {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "🙁 Oops! Can't perform operation for job seeker.")
-		return
-	}
-
-	// storing the job posting access token
-	accessToken := uuid.Must(uuid.NewRandom())
-	handler.store.Add(accessToken.String(), user.ID)
-
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, fmt.Sprintf(`please follow the following link to post a job. 
-	 https://www.asseri.net/job/post.html?employer_id=%s&access_token=%s`, user.ID, accessToken))
-}
-
 // HandleMangeJobs is a method that handles the job managing process
-func (handler *TelegramBotHandler) HandleMangeJobs(update *bot.Update) {
+func (handler *TelegramBotHandler) HandleMangeJobs(update *bot.Update, user *entity.User) {
 
-	jobStatusMenu := bot.CreateReplyKeyboard(true, false, []string{"⌛ Pending", "📖 Opened"},
-		[]string{"📕 Closed", "🚫 Declined"}, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Choose status", jobStatusMenu)
+	jobStatusMenu := bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(user.Language, "button.pending"), locale.Text(user.Language, "button.opened")},
+		[]string{locale.Text(user.Language, "button.closed"), locale.Text(user.Language, "button.declined")},
+		[]string{locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "job.choose_status"), jobStatusMenu)
 }
 
 // HandleViewJobDetail is a method that enables user to view a certain job details
-func (handler *TelegramBotHandler) HandleViewJobDetail(jobID string, chatID int64) string {
+func (handler *TelegramBotHandler) HandleViewJobDetail(jobID string, chatID int64, language string) string {
 
 	var inlineKeyboard string
 	job, err := handler.jbService.FindJob(jobID)
 	if err != nil {
-		return "😳 Oops! unable to view job detail."
+		return locale.Text(language, "job.error.view")
 	}
 
-	reply := fmt.Sprintf(
-		"<b>Job Title</b>:  %s\n\n"+
-			"<b>Job Type</b>:  %s\n"+
-			"<b>Gender</b>:  %s\n"+
-			"<b>Education level</b>:  %s\n"+
-			"<b>Experience</b>:  %s\n"+
-			"<b>Contact Type</b>:  %s\n\n"+
-			"<b>Description</b>:  %s\n\n"+"#%s",
-		job.Title, job.Type, bot.GetGender(job.Gender),
-		job.EducationLevel, job.Experience, job.ContactType,
-		job.Description, tools.ChangeSpaceToUnderscore(job.Sector))
-
 	if job.Status == entity.JobStatusOpened {
 		inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-			{Text: "❌ Close", CallbackData: "job/close/" + job.ID},
+			{Text: locale.Text(language, "job.close"), CallbackData: "job/close/" + job.ID},
 		})
 	}
 
-	bot.SendReplyToTelegramChat(chatID, reply, inlineKeyboard)
+	handler.SendReplyToTelegramChat(chatID, jobDetail(job, language), inlineKeyboard)
 	return ""
 }
 
@@ -80,25 +55,12 @@ func (handler *TelegramBotHandler) HandlePendingJobs(update *bot.Update, user *e
 	}
 
 	if len(pendingJobs) == 0 {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You don't have any pending"+
-			" job waiting for approval.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "job.empty.pending"))
 		return
 	}
 
 	for _, pendingJob := range pendingJobs {
-		reply := fmt.Sprintf(
-			"<b>Job Title</b>:  %s\n\n"+
-				"<b>Job Type</b>:  %s\n"+
-				"<b>Gender</b>:  %s\n"+
-				"<b>Education level</b>:  %s\n"+
-				"<b>Experience</b>:  %s\n"+
-				"<b>Contact Type</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n"+"#%s",
-			pendingJob.Title, pendingJob.Type, bot.GetGender(pendingJob.Gender),
-			pendingJob.EducationLevel, pendingJob.Experience, pendingJob.ContactType,
-			pendingJob.Description, tools.ChangeSpaceToUnderscore(pendingJob.Sector))
-
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, reply)
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, jobDetail(pendingJob, user.Language))
 	}
 }
 
@@ -115,28 +77,18 @@ func (handler *TelegramBotHandler) HandleOpenedJobs(update *bot.Update, user *en
 	}
 
 	if len(openedJobs) == 0 {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You don't have any opened"+
-			" job waiting for an applier.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "job.empty.opened"))
 		return
 	}
 
 	for _, openedJob := range openedJobs {
-		reply := fmt.Sprintf(
-			"<b>Job Title</b>:  %s\n\n"+
-				"<b>Job Type</b>:  %s\n"+
-				"<b>Gender</b>:  %s\n"+
-				"<b>Education level</b>:  %s\n"+
-				"<b>Experience</b>:  %s\n"+
-				"<b>Contact Type</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n"+"#%s",
-			openedJob.Title, openedJob.Type, bot.GetGender(openedJob.Gender),
-			openedJob.EducationLevel, openedJob.Experience, openedJob.ContactType,
-			openedJob.Description, tools.ChangeSpaceToUnderscore(openedJob.Sector))
-
-		inlineKeyboard := bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-			{Text: "❌ Close", CallbackData: "job/close/" + openedJob.ID},
-		})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, reply, inlineKeyboard)
+		applications := len(handler.jaService.FindJobApplications(openedJob.ID))
+		inlineKeyboard := bot.CreateInlineKeyboard(
+			[]bot.InlineKeyboardButton{{Text: locale.Text(user.Language, "job.close"),
+				CallbackData: "job/close/" + openedJob.ID}},
+			applicantsButton(openedJob.ID, applications, user.Language),
+		)
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, jobDetail(openedJob, user.Language), inlineKeyboard)
 	}
 }
 
@@ -153,26 +105,16 @@ func (handler *TelegramBotHandler) HandleClosedJobs(update *bot.Update, user *en
 	}
 
 	if len(closedJobs) == 0 {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You don't have any closed job.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "job.empty.closed"))
 		return
 	}
 
 	for _, closedJob := range closedJobs {
-		reply := fmt.Sprintf(
-			"------------- <b>Closed</b> -------------\n\n"+
-				"<b>Job Title</b>:  %s\n\n"+
-				"<b>Job Type</b>:  %s\n"+
-				"<b>Gender</b>:  %s\n"+
-				"<b>Education level</b>:  %s\n"+
-				"<b>Experience</b>:  %s\n"+
-				"<b>Contact Type</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n"+"#%s\n\n"+
-				"------------- <b>Closed</b> -------------\n\n",
-			closedJob.Title, closedJob.Type, bot.GetGender(closedJob.Gender),
-			closedJob.EducationLevel, closedJob.Experience, closedJob.ContactType,
-			closedJob.Description, tools.ChangeSpaceToUnderscore(closedJob.Sector))
-
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, reply)
+		reply := withBanner(locale.Text(user.Language, "job.banner.closed"), jobDetail(closedJob, user.Language))
+
+		applications := len(handler.jaService.FindJobApplications(closedJob.ID))
+		inlineKeyboard := bot.CreateInlineKeyboard(applicantsButton(closedJob.ID, applications, user.Language))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, reply, inlineKeyboard)
 	}
 }
 
@@ -189,59 +131,108 @@ func (handler *TelegramBotHandler) HandleDeclinedJobs(update *bot.Update, user *
 	}
 
 	if len(declinedJobs) == 0 {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You don't have any declined job.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "job.empty.declined"))
 		return
 	}
 
 	for _, declinedJob := range declinedJobs {
-		reply := fmt.Sprintf(
-			"------------- <b>Declined</b> -------------\n\n"+
-				"<b>Job Title</b>:  %s\n\n"+
-				"<b>Job Type</b>:  %s\n"+
-				"<b>Gender</b>:  %s\n"+
-				"<b>Education level</b>:  %s\n"+
-				"<b>Experience</b>:  %s\n"+
-				"<b>Contact Type</b>:  %s\n\n"+
-				"<b>Description</b>:  %s\n\n"+"#%s\n\n"+
-				"------------- <b>Declined</b> -------------\n\n",
-			declinedJob.Title, declinedJob.Type, bot.GetGender(declinedJob.Gender),
-			declinedJob.EducationLevel, declinedJob.Experience, declinedJob.ContactType,
-			declinedJob.Description, tools.ChangeSpaceToUnderscore(declinedJob.Sector))
-
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, reply)
+		reply := withBanner(locale.Text(user.Language, "job.banner.declined"), jobDetail(declinedJob, user.Language))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, reply)
+	}
+}
+
+// HandleCloseJobAction is a method that closes a job on the request of its employer and marks its channel post as closed
+func (handler *TelegramBotHandler) HandleCloseJobAction(jobID string, update *bot.Update, user *entity.User) {
+
+	reply, err := handler.CloseJob(jobID, user.Language)
+	if err != nil {
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, reply)
+		return
+	}
+
+	handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, "")
+	handler.SendReplyToTelegramChat(update.CallbackQuery.User.ID, reply)
+
+	// Marking the job's channel post as closed
+	if job, err := handler.jbService.FindJob(jobID); err == nil {
+		handler.PushNotificationToChannel(job)
 	}
 }
 
-// CloseJob is a method that closes a certain job so no one can apply for the job
-func (handler *TelegramBotHandler) CloseJob(jobID string) (string, error) {
+// HandleDueDateReminderAction is a method that handles the extend and close buttons of a due date reminder
+func (handler *TelegramBotHandler) HandleDueDateReminderAction(action string, update *bot.Update,
+	user *entity.User) {
+
+	var jobID string
+	extend := strings.HasPrefix(action, "reminder/extend/")
+
+	if extend {
+		jobID = action[len("reminder/extend/"):]
+	} else if strings.HasPrefix(action, "reminder/close/") {
+		jobID = action[len("reminder/close/"):]
+	}
+
 	job, err := handler.jbService.FindJob(jobID)
+	if err != nil || job.Employer != user.ID {
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, locale.Text(user.Language, "job.error.find"))
+		return
+	}
+
+	if !extend {
+		handler.HandleCloseJobAction(jobID, update, user)
+		return
+	}
+
+	reply, err := handler.ExtendJobDueDate(job, bot.DueDateExtension, user.Language)
 	if err != nil {
-		return "🙁 Oops! unable to close the job", err
+		handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, reply)
+		return
 	}
 
+	handler.AnswerToTelegramCallBack(update.CallbackQuery.ID, "")
+	handler.SendReplyToTelegramChat(update.CallbackQuery.User.ID, reply)
+}
+
+// ExtendJobDueDate is a method that moves the due date of an opened job forward by the given duration,
+// the reply is given in the given language
+func (handler *TelegramBotHandler) ExtendJobDueDate(job *entity.Job, extension time.Duration,
+	language string) (string, error) {
+
 	if job.Status != entity.JobStatusOpened {
-		return "🙁 Oops! unable to close the job", errors.New("unable to close unopened job")
+		return locale.Text(language, "job.error.extend"), errors.New("unable to extend unopened job")
+	}
+
+	dueDate := handler.clock.Now()
+	if job.DueDate != nil && job.DueDate.After(dueDate) {
+		dueDate = *job.DueDate
 	}
+	dueDate = dueDate.Add(extension)
+	job.DueDate = &dueDate
 
-	job.Status = entity.JobStatusClosed
-	err = handler.jbService.UpdateJob(job)
+	// The extended due date has to satisfy the same rules as a new one
+	if err := handler.jbService.ValidateJob(job)["due_date"]; err != nil {
+		return locale.Text(language, "common.oops", err.Error()), err
+	}
+
+	err := handler.jbService.UpdateJobSingleValue(job.ID, "due_date", dueDate)
 	if err != nil {
-		return "🙁 Oops! unable to close the job", err
-	}
-
-	reply := fmt.Sprintf(
-		"------------- <b>Closed</b> -------------\n\n"+
-			"<b>Job Title</b>:  %s\n\n"+
-			"<b>Job Type</b>:  %s\n"+
-			"<b>Gender</b>:  %s\n"+
-			"<b>Education level</b>:  %s\n"+
-			"<b>Experience</b>:  %s\n"+
-			"<b>Contact Type</b>:  %s\n\n"+
-			"<b>Description</b>:  %s\n\n"+"#%s\n\n"+
-			"------------- <b>Closed</b> -------------\n\n",
-		job.Title, job.Type, bot.GetGender(job.Gender),
-		job.EducationLevel, job.Experience, job.ContactType,
-		job.Description, tools.ChangeSpaceToUnderscore(job.Sector))
+		return locale.Text(language, "job.error.extend"), err
+	}
+
+	reply := locale.Text(language, "job.extended", html.EscapeString(job.Title), dueDate.Format(bot.DueDateLayout))
+
+	return reply, nil
+}
+
+// CloseJob is a method that closes a certain job so no one can apply for the job, the reply is given in the given language.
+// Only an opened job that hasn't been closed in the meantime, like by the scheduler, is closed.
+func (handler *TelegramBotHandler) CloseJob(jobID, language string) (string, error) {
+	job, err := handler.jbService.ChangeJobStatus(jobID, entity.JobStatusClosed)
+	if err != nil {
+		return locale.Text(language, "job.error.close"), err
+	}
+
+	reply := withBanner(locale.Text(language, "job.banner.closed"), jobDetail(job, language))
 
 	return reply, nil
 }
@@ -250,18 +241,18 @@ func (handler *TelegramBotHandler) CloseJob(jobID string) (string, error) {
 func (handler *TelegramBotHandler) HandleInitApplyForJob(jobID string, user *entity.User, chatID int64) bool {
 
 	if _, errMsg := handler.IsJobApplicable(jobID, user); errMsg != "" {
-		bot.SendReplyToTelegramChat(chatID, errMsg)
+		handler.SendReplyToTelegramChat(chatID, errMsg)
 		return false
 	}
 
 	if handler.jaService.JobApplicationExists(jobID, user.ID) {
-		bot.SendReplyToTelegramChat(chatID, "❌ You have already applied for the job")
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "apply.error.already_applied"))
 		return false
 	}
 
 	cancelMenu := bot.CreateReplyKeyboard(true, false,
-		[]string{"🔙 Cancel Application"})
-	bot.SendReplyToTelegramChat(chatID, "Send CV (*PDF format only)", cancelMenu)
+		[]string{locale.Text(user.Language, "button.cancel_application")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "apply.send_cv"), cancelMenu)
 	return true
 }
 
@@ -270,59 +261,53 @@ func (handler *TelegramBotHandler) HandleApplyForJob(update *bot.Update, jobID s
 
 	job, errMsg := handler.IsJobApplicable(jobID, user)
 	if errMsg != "" {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, errMsg)
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, errMsg)
 		return errors.New("unable to apply for the job")
 	}
 
 	// Verifying the file with type
 	file := update.Message.Document
 	if file.Type != "application/pdf" {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Please send *.pdf file only")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "apply.error.pdf_only"))
 		return errors.New("invalid format")
 	}
 
 	client, err := handler.clService.FindClient(job.Employer)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "🙁 Unable to apply for the job")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "apply.error.apply"))
 		return errors.New("unable to apply for the job")
 	}
 
 	jobApplication := new(entity.JobApplication)
 	jobApplication.JobID = jobID
 	jobApplication.JobSeekerID = user.ID
+	jobApplication.CVFileID = file.ID
 
 	err = handler.jaService.AddJobApplication(jobApplication)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "🙁 Unable to apply for the job")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "apply.error.apply"))
 		return errors.New("unable to apply for the job")
 	}
 
-	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
-	applyCaption := fmt.Sprintf(
-		"------------- <b>Application</b> -------------\n\n"+
-			"<b>Job Title</b>:  %s\n\n"+
-			"<b>Description</b>:  %s\n\n", job.Title, job.Description)
-
-	inlineKeyboard := bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-		{Text: "👀 Job Details", CallbackData: "job/view/" + job.ID},
-	})
-
-	type TelegramResponse struct {
-		Ok bool `json:"ok"`
+	// The application is sent in the language of the employer
+	employerLanguage := locale.DefaultLanguage
+	if employer, err := handler.urService.FindUser(job.Employer); err == nil {
+		employerLanguage = employer.Language
 	}
 
-	response, err := bot.SendDocumentToTelegramChat(chatID, file.ID, applyCaption, inlineKeyboard)
-	result := new(TelegramResponse)
-	json.Unmarshal([]byte(response), result)
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	applyCaption := locale.Text(employerLanguage, "apply.caption", html.EscapeString(job.Title),
+		html.EscapeString(job.Description))
 
-	if err != nil || !result.Ok {
+	_, err = handler.SendDocumentToTelegramChat(chatID, file.ID, applyCaption,
+		applicationMenu(jobApplication, employerLanguage))
+	if err != nil {
 		handler.jaService.DeleteJobApplication(jobID, user.ID)
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "😳 Oops! something went wrong, re-apply again.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "apply.error.send"))
 		return errors.New("application not completed")
 	}
 
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID,
-		"🎉 Your application has been sent to the employer. Good Luck!")
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "apply.sent"))
 	return nil
 }
 
@@ -331,19 +316,33 @@ func (handler *TelegramBotHandler) IsJobApplicable(jobID string, user *entity.Us
 
 	job, err := handler.jbService.FindJob(jobID)
 	if err != nil {
-		return nil, "🙁 Unable to apply for the job"
+		return nil, locale.Text(user.Language, "apply.error.apply")
 	}
 
-	if job.Status == entity.JobStatusClosed {
-		return nil, "🙁 Sorry the job has been closed"
+	// The due date might have passed before the scheduler has closed the job
+	if job.Status == entity.JobStatusClosed ||
+		(job.DueDate != nil && !job.DueDate.After(handler.clock.Now())) {
+		return nil, locale.Text(user.Language, "apply.error.closed")
 
 	} else if job.Status != entity.JobStatusOpened {
-		return nil, "🙁 Unable to apply for the job"
+		return nil, locale.Text(user.Language, "apply.error.apply")
 	}
 
 	if job.Employer == user.ID {
-		return nil, "❌ You can't apply for your own job"
+		return nil, locale.Text(user.Language, "apply.error.own_job")
 	}
 
 	return job, ""
 }
+
+// jobDetail is a function that builds the detail of a job shown to its employer in the given language
+func jobDetail(job *entity.Job, language string) string {
+	return locale.Text(language, "job.detail", html.EscapeString(job.Title), job.Type,
+		bot.GetGender(job.Gender, language), job.EducationLevel, job.Experience, job.ContactType,
+		html.EscapeString(job.Description), tools.ChangeSpaceToUnderscore(job.Sector))
+}
+
+// withBanner is a function that surrounds a text with the given banner
+func withBanner(banner, text string) string {
+	return banner + text + "\n\n" + banner
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// moderationLanguage is a constant that holds the language of the moderators' chat, the chat is shared by every moderator
+const moderationLanguage = locale.DefaultLanguage
+
+// ForwardJobForModeration is a method that sends a pending job to the moderators' chat so it can be reviewed
+func (handler *TelegramBotHandler) ForwardJobForModeration(job *entity.Job) {
+
+	chatID := moderatorsChatID()
+	if chatID == 0 {
+		return
+	}
+
+	_, err := handler.SendReplyToTelegramChat(chatID, handler.buildModerationPost(job, ""), moderationMenu(job.ID))
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to forward job %s for moderation, %s",
+			job.ID, err.Error()), entity.BotLogFile)
+	}
+}
+
+// IsModerationUpdate is a method that checks whether an update has been sent from the moderators' chat
+func (handler *TelegramBotHandler) IsModerationUpdate(update *bot.Update) bool {
+
+	chatID := moderatorsChatID()
+	if chatID == 0 {
+		return false
+	}
+
+	return update.Message.Chat.ID == chatID || update.CallbackQuery.Message.Chat.ID == chatID
+}
+
+// HandleModerationUpdate is a method that handles an update sent from the moderators' chat
+func (handler *TelegramBotHandler) HandleModerationUpdate(update *bot.Update) {
+
+	if update.CallbackQuery.ID != "" {
+		handler.HandleModerationAction(update.CallbackQuery.Data, update)
+		return
+	}
+
+	// Only replies to a reason prompt are handled, the rest is the moderators' own conversation
+	if update.Message.ReplyToMessage != nil {
+		handler.HandleModerationReason(update)
+	}
+}
+
+// HandleModerationAction is a method that handles a moderation button pressed on a pending job's post
+func (handler *TelegramBotHandler) HandleModerationAction(action string, update *bot.Update) {
+
+	query := update.CallbackQuery
+
+	if !isStaff(query.User.ID) {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(moderationLanguage, "moderation.error.staff_only"))
+		return
+	}
+
+	reg := regexp.MustCompile(`^job/moderate/(approve|decline|changes)/(.+)$`)
+	matches := reg.FindStringSubmatch(action)
+	if matches == nil {
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		return
+	}
+
+	moderationAction, jobID := matches[1], matches[2]
+
+	job, err := handler.jbService.FindJob(jobID)
+	if err != nil || job.Status != entity.JobStatusPending {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(moderationLanguage, "moderation.error.reviewed"))
+		handler.EditTelegramReplyMarkup(query.Message.Chat.ID, query.Message.MessageID, "")
+		return
+	}
+
+	if moderationAction == bot.ModerationApprove {
+		reply, err := handler.ModerateJob(jobID, moderationAction, query.User, "")
+		handler.AnswerToTelegramCallBack(query.ID, reply)
+		if err == nil {
+			handler.updateModerationPost(query.Message.Chat.ID, query.Message.MessageID, jobID, query.User)
+		}
+		return
+	}
+
+	// Declining or requesting changes needs a reason, so the moderator is asked to reply with one
+	prompt := locale.Text(moderationLanguage, "moderation.reason_prompt."+moderationAction,
+		mentionModerator(query.User), html.EscapeString(job.Title))
+
+	message, err := handler.SendReplyToTelegramChat(query.Message.Chat.ID, prompt, bot.CreateForceReply(true))
+	if err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(moderationLanguage, "moderation.error.prompt"))
+		return
+	}
+
+	reason := &bot.ModerationReason{Action: moderationAction, JobID: jobID, MessageID: query.Message.MessageID}
+	reasonS, _ := json.Marshal(reason)
+	handler.store.Add(fmt.Sprintf(bot.ModerationReasonKey, message.MessageID), string(reasonS))
+
+	handler.AnswerToTelegramCallBack(query.ID, "")
+}
+
+// HandleModerationReason is a method that handles a moderator's reply to a reason prompt
+func (handler *TelegramBotHandler) HandleModerationReason(update *bot.Update) {
+
+	message := update.Message
+	key := fmt.Sprintf(bot.ModerationReasonKey, message.ReplyToMessage.MessageID)
+
+	if !isStaff(message.User.ID) || handler.store.Get(key) == "" {
+		return
+	}
+
+	reasonText := strings.TrimSpace(message.Text)
+	if reasonText == "" {
+		handler.SendReplyToTelegramChat(message.Chat.ID, locale.Text(moderationLanguage, "moderation.error.reason"))
+		return
+	}
+
+	// Taking the prompt makes sure the reason is only applied once, even if replied to more than once
+	reasonS := handler.store.Take(key)
+	if reasonS == "" {
+		return
+	}
+
+	reason := new(bot.ModerationReason)
+	err := json.Unmarshal([]byte(reasonS), reason)
+	if err != nil {
+		return
+	}
+
+	reply, err := handler.ModerateJob(reason.JobID, reason.Action, message.User, reasonText)
+	handler.SendReplyToTelegramChat(message.Chat.ID, reply)
+	if err == nil {
+		handler.updateModerationPost(message.Chat.ID, reason.MessageID, reason.JobID, message.User)
+	}
+}
+
+// ModerateJob is a method that applies a moderation action to a pending job, records the moderator that has acted
+// and notifies the employer. An approved job is also pushed to the channel and the subscribers.
+func (handler *TelegramBotHandler) ModerateJob(jobID, action string, moderator bot.TUser,
+	reason string) (string, error) {
+
+	var job *entity.Job
+	var err error
+
+	reviewedBy := strconv.FormatInt(moderator.ID, 10)
+
+	switch action {
+	case bot.ModerationApprove:
+		job, err = handler.jbService.ReviewJob(jobID, entity.JobStatusOpened, reviewedBy, reason)
+	case bot.ModerationDecline:
+		job, err = handler.jbService.ReviewJob(jobID, entity.JobStatusDecelined, reviewedBy, reason)
+	case bot.ModerationRequestChanges:
+		// The job stays pending until it is approved or declined
+		job, err = handler.jbService.FindJob(jobID)
+		if err == nil && job.Status != entity.JobStatusPending {
+			err = errors.New("job has already been reviewed")
+		}
+	default:
+		err = errors.New("invalid moderation action")
+	}
+
+	if err != nil {
+		return locale.Text(moderationLanguage, "moderation.error.moderate."+action, err.Error()), err
+	}
+
+	if action == bot.ModerationRequestChanges {
+		// Only the review columns are written so changes made to the job in the meantime are kept
+		job.ReviewedBy = reviewedBy
+		job.ReviewNote = reason
+		for columnName, columnValue := range map[string]string{"reviewed_by": reviewedBy, "review_note": reason} {
+			if err := handler.jbService.UpdateJobSingleValue(job.ID, columnName, columnValue); err != nil {
+				handler.logger.LogFileError(fmt.Sprintf("Unable to record the review of job %s, %s",
+					job.ID, err.Error()), entity.BotLogFile)
+			}
+		}
+
+		if pushErr := handler.RequestJobChanges(job); pushErr != nil {
+			return locale.Text(moderationLanguage, "moderation.error.notify", pushErr.Message), pushErr
+		}
+		return locale.Text(moderationLanguage, "moderation.changes_requested"), nil
+	}
+
+	handler.PublishJobStatus(job)
+
+	if action == bot.ModerationDecline {
+		return locale.Text(moderationLanguage, "moderation.declined"), nil
+	}
+
+	return locale.Text(moderationLanguage, "moderation.approved"), nil
+}
+
+// RequestJobChanges is a method that sends the changes requested by a moderator to the employer of a pending job
+func (handler *TelegramBotHandler) RequestJobChanges(job *entity.Job) *PushError {
+
+	user, err := handler.urService.FindUser(job.Employer)
+	if err != nil {
+		return &PushError{Code: PushCodeNotFound, Message: "no user found for the job employer"}
+	}
+
+	client, err := handler.clService.FindClient(user.ID)
+	if err != nil || client.Blocked {
+		return &PushError{Code: PushCodeNotFound, Message: "the employer can't be reached through the bot"}
+	}
+
+	reply := locale.Text(user.Language, "moderation.request_changes", html.EscapeString(job.Title),
+		html.EscapeString(job.ReviewNote))
+
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	_, err = handler.SendReplyToTelegramChat(chatID, reply)
+	return handler.ToPushError(err)
+}
+
+// updateModerationPost is a method that shows the latest review of a job on its post in the moderators' chat.
+// Only a job that is still pending keeps the moderation buttons.
+func (handler *TelegramBotHandler) updateModerationPost(chatID, messageID int64, jobID string,
+	moderator bot.TUser) {
+
+	job, err := handler.jbService.FindJob(jobID)
+	if err != nil {
+		return
+	}
+
+	var outcome string
+	var menu string
+
+	switch job.Status {
+	case entity.JobStatusOpened:
+		outcome = locale.Text(moderationLanguage, "moderation.outcome.approved", mentionModerator(moderator))
+	case entity.JobStatusDecelined:
+		outcome = locale.Text(moderationLanguage, "moderation.outcome.declined", mentionModerator(moderator),
+			html.EscapeString(job.ReviewNote))
+	default:
+		outcome = locale.Text(moderationLanguage, "moderation.outcome.changes", mentionModerator(moderator),
+			html.EscapeString(job.ReviewNote))
+		menu = moderationMenu(job.ID)
+	}
+
+	_, err = handler.EditTelegramMessage(chatID, messageID, handler.buildModerationPost(job, outcome), menu)
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to update the moderation post of job %s, %s",
+			job.ID, err.Error()), entity.BotLogFile)
+	}
+}
+
+// buildModerationPost is a method that builds the preview of a job as it will be posted on the channel
+// followed by the outcome of its review
+func (handler *TelegramBotHandler) buildModerationPost(job *entity.Job, outcome string) string {
+
+	pack := &bot.StructuredPackage{Employer: job.Employer}
+
+	if job.PostType == entity.PostCategoryUser {
+		if user, err := handler.urService.FindUser(job.Employer); err == nil {
+			pack.Employer = user.UserName
+			pack.Contact = locale.Text(moderationLanguage, "job.field.contact",
+				strings.ReplaceAll(user.PhoneNumber, "+251", "0"))
+		}
+	} else if job.PostType == entity.PostCategoryInternal {
+		pack.Contact = locale.Text(moderationLanguage, "job.field.contact", html.EscapeString(job.ContactInfo))
+	}
+
+	post := locale.Text(moderationLanguage, "moderation.post.title") +
+		bot.BuildNotification(job, pack, moderationLanguage) +
+		locale.Text(moderationLanguage, "moderation.post.job_id", job.ID)
+
+	if job.DueDate != nil {
+		post += locale.Text(moderationLanguage, "moderation.post.due_date", job.DueDate.Format(bot.DueDateLayout))
+	}
+
+	if outcome != "" {
+		post += "\n" + outcome
+	}
+
+	return post
+}
+
+// moderationMenu is a function that returns the moderation buttons of a pending job
+func moderationMenu(jobID string) string {
+	return bot.CreateInlineKeyboard(
+		[]bot.InlineKeyboardButton{
+			{Text: locale.Text(moderationLanguage, "moderation.approve"),
+				CallbackData: "job/moderate/" + bot.ModerationApprove + "/" + jobID},
+			{Text: locale.Text(moderationLanguage, "moderation.decline"),
+				CallbackData: "job/moderate/" + bot.ModerationDecline + "/" + jobID},
+		},
+		[]bot.InlineKeyboardButton{
+			{Text: locale.Text(moderationLanguage, "moderation.request_changes.button"),
+				CallbackData: "job/moderate/" + bot.ModerationRequestChanges + "/" + jobID},
+		},
+	)
+}
+
+// mentionModerator is a function that returns a mention of a moderator, which also makes a selective
+// force reply target the moderator
+func mentionModerator(moderator bot.TUser) string {
+
+	name := moderator.FirstName
+	if name == "" {
+		name = moderator.UserName
+	}
+
+	if name == "" {
+		name = strconv.FormatInt(moderator.ID, 10)
+	}
+
+	return fmt.Sprintf(`<a href="tg://user?id=%d">%s</a>`, moderator.ID, html.EscapeString(name))
+}
+
+// moderatorsChatID is a function that returns the id of the moderators' chat, 0 if it isn't configured
+func moderatorsChatID() int64 {
+	chatID, _ := strconv.ParseInt(strings.TrimSpace(os.Getenv("moderators_chat_id")), 10, 64)
+	return chatID
+}
+
+// isStaff is a function that checks whether the given Telegram id is listed as a staff member
+func isStaff(telegramID int64) bool {
+
+	id := strconv.FormatInt(telegramID, 10)
+	for _, staffID := range strings.Split(os.Getenv("staff_telegram_ids"), ",") {
+		if strings.TrimSpace(staffID) == id {
+			return true
+		}
+	}
+
+	return false
+}
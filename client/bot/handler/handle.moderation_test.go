@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+func TestModerateJobConcurrently(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	addJob(fakeBot, "JB-1", entity.JobStatusPending, nil)
+
+	// Moderators pressing approve and decline at the same time can't both change the pending job
+	actions := []string{bot.ModerationApprove, bot.ModerationApprove, bot.ModerationDecline, bot.ModerationApprove}
+	errs := make([]error, len(actions))
+
+	var wg sync.WaitGroup
+	for i, action := range actions {
+		wg.Add(1)
+		go func(i int, action string) {
+			defer wg.Done()
+			_, errs[i] = fakeBot.Handler.ModerateJob("JB-1", action, bot.TUser{ID: int64(9000 + i)}, "")
+		}(i, action)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		}
+	}
+
+	if succeeded != 1 {
+		t.Fatalf("expected a single moderation to succeed, got %d", succeeded)
+	}
+
+	job := fakeBot.Job("JB-1")
+	if job.Status != entity.JobStatusOpened && job.Status != entity.JobStatusDecelined {
+		t.Fatalf("expected the job to be reviewed, got %s", job.Status)
+	}
+
+	posts := 0
+	for _, call := range callsTo(fakeBot.Telegram.Calls(), channelName) {
+		if call.Method == "sendMessage" {
+			posts++
+		}
+	}
+
+	expected := 0
+	if job.Status == entity.JobStatusOpened {
+		expected = 1
+	}
+
+	if posts != expected {
+		t.Errorf("expected the %s job to be posted to the channel %d time(s), got %d", job.Status, expected, posts)
+	}
+}
+
+func TestModerateJobRecordsReview(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	addJob(fakeBot, "JB-1", entity.JobStatusPending, nil)
+	addJob(fakeBot, "JB-2", entity.JobStatusPending, nil)
+
+	if _, err := fakeBot.Handler.ModerateJob("JB-1", bot.ModerationDecline, bot.TUser{ID: 9001},
+		"Missing salary"); err != nil {
+		t.Fatalf("expected the job to be declined, got %s", err)
+	}
+
+	if job := fakeBot.Job("JB-1"); job.Status != entity.JobStatusDecelined || job.ReviewedBy != "9001" ||
+		job.ReviewNote != "Missing salary" {
+		t.Errorf("expected the decline to be recorded with the status, got %+v", job)
+	}
+
+	// Requesting changes only writes the review so the pending job keeps every other entry
+	fakeBot.DB.Update("jobs", map[string]interface{}{"title": "Edited title"},
+		func(row interface{}) bool { return row.(*entity.Job).ID == "JB-2" })
+	if _, err := fakeBot.Handler.ModerateJob("JB-2", bot.ModerationRequestChanges, bot.TUser{ID: 9002},
+		"Add a due date"); err != nil {
+		t.Fatalf("expected the changes to be requested, got %s", err)
+	}
+
+	if job := fakeBot.Job("JB-2"); job.Status != entity.JobStatusPending || job.ReviewedBy != "9002" ||
+		job.ReviewNote != "Add a due date" || job.Title != "Edited title" {
+		t.Errorf("expected only the review to be recorded, got %+v", job)
+	}
+}
+
+func TestModerateJobEscapesJobPost(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	addJob(fakeBot, "JB-1", entity.JobStatusPending, nil)
+	fakeBot.DB.Update("jobs", map[string]interface{}{"title": "<b>Cashier</b>", "description": "Salary < 5000 & tips"},
+		func(row interface{}) bool { return row.(*entity.Job).ID == "JB-1" })
+
+	if _, err := fakeBot.Handler.ModerateJob("JB-1", bot.ModerationApprove, bot.TUser{ID: 9001}, ""); err != nil {
+		t.Fatalf("expected the job to be approved, got %s", err)
+	}
+
+	// The job is posted with HTML parse mode, so the text entered by the employer must be escaped
+	posts := 0
+	for _, call := range callsTo(fakeBot.Telegram.Calls(), channelName) {
+		if call.Method != "sendMessage" {
+			continue
+		}
+
+		posts++
+		text := call.Text()
+		if !strings.Contains(text, "&lt;b&gt;Cashier&lt;/b&gt;") || !strings.Contains(text, "Salary &lt; 5000 &amp; tips") {
+			t.Errorf("expected the title and description to be escaped, got %q", text)
+		}
+	}
+
+	if posts != 1 {
+		t.Errorf("expected the job to be posted to the channel once, got %d", posts)
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"html"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// HandleNotificationSettings is a method that shows the notification preferences of a user
+func (handler *TelegramBotHandler) HandleNotificationSettings(update *bot.Update, user *entity.User) {
+
+	notificationMenu := bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(user.Language, "button.set_email"), locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "notification.choose_fallback"),
+		notificationMenu)
+
+	text, inlineKeyboard := notificationSettings(user)
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, text, inlineKeyboard)
+}
+
+// HandleNotificationFallbackAction is a method that changes the notification fallback of a user.
+// The action has the form notification/fallback/<SMS|Email|None>
+func (handler *TelegramBotHandler) HandleNotificationFallbackAction(action string, update *bot.Update,
+	user *entity.User) {
+
+	query := update.CallbackQuery
+	fallback := strings.TrimPrefix(action, "notification/fallback/")
+
+	if fallback == entity.NotificationFallbackEmail && user.Email == "" {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "notification.error.no_email"))
+		return
+	}
+
+	profile := *user
+	profile.NotificationFallback = fallback
+	if err := handler.saveNotificationPreferences(&profile); err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, err.Error())
+		return
+	}
+
+	*user = profile
+	handler.AnswerToTelegramCallBack(query.ID, "")
+
+	text, inlineKeyboard := notificationSettings(user)
+	handler.EditTelegramMessage(query.Message.Chat.ID, query.Message.MessageID, text, inlineKeyboard)
+}
+
+// HandlePromptEmail is a method that asks a user for the email address notifications can be sent to
+func (handler *TelegramBotHandler) HandlePromptEmail(update *bot.Update, user *entity.User) {
+	cancelMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "notification.enter_email"),
+		cancelMenu)
+}
+
+// HandleUpdateEmail is a method that sets the email address of a user and returns true if it has been saved
+func (handler *TelegramBotHandler) HandleUpdateEmail(update *bot.Update, user *entity.User) bool {
+
+	profile := *user
+	profile.Email = update.Message.Text
+	if err := handler.saveNotificationPreferences(&profile); err != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, err.Error())
+		return false
+	}
+
+	*user = profile
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "notification.email_saved"))
+	return true
+}
+
+// saveNotificationPreferences is a method that validates and saves the notification preferences of a user profile
+func (handler *TelegramBotHandler) saveNotificationPreferences(profile *entity.User) error {
+
+	// The errors are replied to the user so they are given in the user's language
+	errMap := handler.urService.ValidateUserProfile(profile)
+	if errMap["email"] != nil {
+		return errors.New(validationText(profile.Language, "email"))
+	} else if errMap["notification_fallback"] != nil {
+		return errors.New(validationText(profile.Language, "notification_fallback"))
+	}
+
+	if err := handler.urService.UpdateUser(profile); err != nil {
+		return errors.New(locale.Text(profile.Language, "notification.error.save"))
+	}
+
+	return nil
+}
+
+// notificationSettings is a function that returns the current notification preferences of a user
+// along with the buttons used to change the notification fallback
+func notificationSettings(user *entity.User) (string, string) {
+
+	email := "-"
+	if user.Email != "" {
+		email = html.EscapeString(user.Email)
+	}
+
+	fallback := user.NotificationFallback
+	if fallback == "" {
+		fallback = entity.NotificationFallbackSMS
+	}
+
+	buttons := make([]bot.InlineKeyboardButton, 0)
+	for _, option := range []string{entity.NotificationFallbackSMS, entity.NotificationFallbackEmail,
+		entity.NotificationFallbackNone} {
+
+		text := fallbackName(option, user.Language)
+		if option == fallback {
+			text = "✅ " + text
+		}
+		buttons = append(buttons, bot.InlineKeyboardButton{Text: text,
+			CallbackData: "notification/fallback/" + option})
+	}
+
+	text := locale.Text(user.Language, "notification.settings", email, fallbackName(fallback, user.Language))
+
+	return text, bot.CreateInlineKeyboard(buttons)
+}
+
+// fallbackName is a function that returns the name of a notification fallback in the given language
+func fallbackName(fallback, language string) string {
+	return locale.Text(language, "notification.fallback."+strings.ToLower(fallback))
+}
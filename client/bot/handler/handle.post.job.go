@@ -0,0 +1,516 @@
+package handler
+
+import (
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// HandlePostJob is a method that starts the job posting process and returns whether it has been started or not
+func (handler *TelegramBotHandler) HandlePostJob(update *bot.Update, user *entity.User) bool {
+
+	if user.Category == entity.UserCategoryJobSeeker {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "post.error.job_seeker"))
+		return false
+	}
+
+	draft := &bot.JobDraft{Job: &entity.Job{Employer: user.ID, InitiatorID: user.ID,
+		PostType: entity.PostCategoryUser}}
+
+	err := handler.saveJobDraft(update.Message.Chat.ID, draft)
+	if err != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "post.error.start"))
+		return false
+	}
+
+	handler.PromptJobDraftStep(bot.JobDraftTitle, update.Message.Chat.ID, draft, user.Language)
+
+	if formURL := handler.jobPostingFormURL(user); formURL != "" {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "post.web_form"),
+			bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
+				{Text: locale.Text(user.Language, "post.web_form.open"), URL: formURL}}))
+	}
+	return true
+}
+
+// HandleCancelJobDraft is a method that discards the job that is being posted in the given chat
+func (handler *TelegramBotHandler) HandleCancelJobDraft(chatID int64) {
+	handler.sessions.Clear(strconv.FormatInt(chatID, 10), bot.PayloadJobDraft)
+}
+
+// HandleJobDraftInput is a method that handles a text entered for the current job posting step
+// and returns the next step, an empty step is returned if the input isn't accepted
+func (handler *TelegramBotHandler) HandleJobDraftInput(step, command string, update *bot.Update,
+	user *entity.User) string {
+
+	chatID := update.Message.Chat.ID
+	text := strings.TrimSpace(update.Message.Text)
+
+	draft, err := handler.loadJobDraft(chatID, user)
+	if err != nil {
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "post.error.expired"))
+		handler.HandleShowMainMenu(update, user)
+		return bot.StateMainMenu
+	}
+
+	var field string
+	switch step {
+	case bot.JobDraftTitle:
+		field = "title"
+		draft.Job.Title = text
+
+	case bot.JobDraftDescription:
+		field = "description"
+		draft.Job.Description = text
+
+	case bot.JobDraftDueDate:
+		field = "due_date"
+		draft.Job.DueDate = nil
+
+		if command != "button.skip" {
+			dueDate, err := time.ParseInLocation(bot.DueDateInputLayout, text, time.Local)
+			if err != nil {
+				handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "post.error.due_date"))
+				return ""
+			}
+			draft.Job.DueDate = &dueDate
+		}
+
+	default:
+		return ""
+	}
+
+	if err := handler.validateJobDraftField(draft, field); err != nil {
+		handler.SendReplyToTelegramChat(chatID, "🙁 "+validationText(user.Language, field))
+		return ""
+	}
+
+	return handler.advanceJobDraft(step, chatID, draft, user.Language)
+}
+
+// HandleJobDraftAction is a method that handles an inline keyboard button pressed during the job posting process
+// and returns the next step, an empty step is returned if the job posting step hasn't changed
+func (handler *TelegramBotHandler) HandleJobDraftAction(step, action string, update *bot.Update,
+	user *entity.User) string {
+
+	query := update.CallbackQuery
+	chatID := query.User.ID
+
+	draft, err := handler.loadJobDraft(chatID, user)
+	if err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "post.error.expired"))
+		return ""
+	}
+
+	// Buttons of a step that is no longer active are ignored
+	parts := strings.Split(strings.TrimPrefix(action, "job/draft/"), "/")
+	if len(parts) < 1 || jobDraftActionStep(parts[0]) != step {
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		return ""
+	}
+
+	value := ""
+	if len(parts) > 1 {
+		value = parts[1]
+	}
+
+	var field string
+	switch parts[0] {
+	case "sector", "type":
+		attributes := handler.cmService.GetValidJobSectors()
+		selected := &draft.Job.Sector
+		field = "sector"
+		if parts[0] == "type" {
+			attributes = handler.cmService.GetValidJobTypes()
+			selected = &draft.Job.Type
+			field = "type"
+		}
+
+		if value != "next" {
+			index, err := strconv.Atoi(value)
+			if err != nil || index < 0 || index >= len(attributes) {
+				handler.AnswerToTelegramCallBack(query.ID, "")
+				return ""
+			}
+
+			*selected = toggleJobDraftValue(*selected, attributes[index].Name)
+			handler.saveJobDraft(chatID, draft)
+			handler.EditTelegramReplyMarkup(chatID, query.Message.MessageID,
+				jobDraftMultiSelectMenu(parts[0], attributes, *selected, user.Language))
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			return ""
+		}
+
+	case "education_level":
+		field = "education_level"
+		attributes := handler.cmService.GetValidEducationLevels()
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(attributes) {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			return ""
+		}
+		draft.Job.EducationLevel = attributes[index].Name
+
+	case "experience":
+		field = "experience"
+		experiences := handler.cmService.GetValidWorkExperiences()
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(experiences) {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			return ""
+		}
+		draft.Job.Experience = experiences[index]
+
+	case "gender":
+		field = "gender"
+		draft.Job.Gender = value
+
+	case "contact_type":
+		field = "contact_type"
+		contactTypes := handler.cmService.GetValidContactTypes()
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(contactTypes) {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			return ""
+		}
+		draft.Job.ContactType = contactTypes[index]
+
+	case "edit":
+		if value == "" {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "post.choose_edit"),
+				jobDraftEditMenu(user.Language))
+			return ""
+		}
+
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 || index >= len(bot.JobDraftSteps)-1 {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			return ""
+		}
+
+		draft.Editing = true
+		handler.saveJobDraft(chatID, draft)
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		handler.PromptJobDraftStep(bot.JobDraftSteps[index], chatID, draft, user.Language)
+		return bot.JobDraftSteps[index]
+
+	case "submit":
+		reply, err := handler.SubmitJobDraft(chatID, draft, user.Language)
+		if err != nil {
+			handler.AnswerToTelegramCallBack(query.ID, "")
+			handler.SendReplyToTelegramChat(chatID, reply)
+			return ""
+		}
+
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		handler.SendReplyToTelegramChat(chatID, reply, mainMenu(user.Language, user.Category))
+		return bot.StateMainMenu
+	}
+
+	if err := handler.validateJobDraftField(draft, field); err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, validationText(user.Language, field))
+		return ""
+	}
+
+	handler.AnswerToTelegramCallBack(query.ID, "")
+	return handler.advanceJobDraft(step, chatID, draft, user.Language)
+}
+
+// SubmitJobDraft is a method that validates the whole job draft of the given chat and adds it as a pending job,
+// the reply is given in the given language
+func (handler *TelegramBotHandler) SubmitJobDraft(chatID int64, draft *bot.JobDraft, language string) (string, error) {
+
+	job := draft.Job
+	job.Status = entity.JobStatusPending
+
+	errMap := handler.jbService.ValidateJob(job)
+	if len(errMap) > 0 {
+		reply := locale.Text(language, "post.error.correct")
+		for field := range errMap {
+			reply += "• " + validationText(language, field) + "\n"
+		}
+		return reply, errors.New("invalid job draft")
+	}
+
+	err := handler.jbService.AddJob(job)
+	if err != nil {
+		return locale.Text(language, "post.error.submit"), err
+	}
+
+	handler.HandleCancelJobDraft(chatID)
+	handler.ForwardJobForModeration(job)
+	return locale.Text(language, "post.submitted"), nil
+}
+
+// PromptJobDraftStep is a method that asks the user for the entry of the given job posting step in the given language
+func (handler *TelegramBotHandler) PromptJobDraftStep(step string, chatID int64, draft *bot.JobDraft, language string) {
+
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(language, "button.main_menu")})
+
+	switch step {
+	case bot.JobDraftTitle:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.enter_title"), backMenu)
+
+	case bot.JobDraftDescription:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.enter_description"), backMenu)
+
+	case bot.JobDraftSector:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_sectors"), backMenu)
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_sectors.hint"),
+			jobDraftMultiSelectMenu("sector", handler.cmService.GetValidJobSectors(), draft.Job.Sector, language))
+
+	case bot.JobDraftType:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_types"), backMenu)
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_types.hint"),
+			jobDraftMultiSelectMenu("type", handler.cmService.GetValidJobTypes(), draft.Job.Type, language))
+
+	case bot.JobDraftEducationLevel:
+		names := make([]string, 0)
+		for _, educationLevel := range handler.cmService.GetValidEducationLevels() {
+			names = append(names, educationLevel.Name)
+		}
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_education_level"),
+			jobDraftSelectMenu("education_level", names))
+
+	case bot.JobDraftExperience:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_experience"),
+			jobDraftSelectMenu("experience", handler.cmService.GetValidWorkExperiences()))
+
+	case bot.JobDraftGender:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_gender"),
+			bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
+				{Text: bot.GetGender("M", language), CallbackData: "job/draft/gender/M"},
+				{Text: bot.GetGender("F", language), CallbackData: "job/draft/gender/F"},
+				{Text: bot.GetGender("B", language), CallbackData: "job/draft/gender/B"},
+			}))
+
+	case bot.JobDraftContactType:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.choose_contact_type"),
+			jobDraftSelectMenu("contact_type", handler.cmService.GetValidContactTypes()))
+
+	case bot.JobDraftDueDate:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.enter_due_date"),
+			bot.CreateReplyKeyboard(true, false,
+				[]string{locale.Text(language, "button.skip"), locale.Text(language, "button.main_menu")}))
+
+	case bot.JobDraftPreview:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.preview"), backMenu)
+		handler.SendReplyToTelegramChat(chatID, buildJobDraftPreview(draft.Job, language),
+			bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
+				{Text: locale.Text(language, "post.edit"), CallbackData: "job/draft/edit"},
+				{Text: locale.Text(language, "post.submit"), CallbackData: "job/draft/submit"},
+			}))
+	}
+}
+
+// advanceJobDraft is a method that stores the draft and prompts the step after the given one,
+// a step changed from the preview returns to the preview
+func (handler *TelegramBotHandler) advanceJobDraft(step string, chatID int64, draft *bot.JobDraft,
+	language string) string {
+
+	next := bot.JobDraftPreview
+	if !draft.Editing {
+		for index, draftStep := range bot.JobDraftSteps {
+			if draftStep == step && index+1 < len(bot.JobDraftSteps) {
+				next = bot.JobDraftSteps[index+1]
+				break
+			}
+		}
+	}
+
+	if next == bot.JobDraftPreview {
+		draft.Editing = false
+	}
+
+	err := handler.saveJobDraft(chatID, draft)
+	if err != nil {
+		handler.SendReplyToTelegramChat(chatID, locale.Text(language, "post.error.save"))
+		return ""
+	}
+
+	handler.PromptJobDraftStep(next, chatID, draft, language)
+	return next
+}
+
+// validateJobDraftField is a method that validates the draft and returns the error of the given field only,
+// since the entries of the later steps haven't been filled yet
+func (handler *TelegramBotHandler) validateJobDraftField(draft *bot.JobDraft, field string) error {
+
+	// ValidateJob normalizes some of the entries so a copy is validated
+	job := *draft.Job
+	errMap := handler.jbService.ValidateJob(&job)
+
+	if errMap[field] != nil {
+		return errMap[field]
+	}
+
+	if field == "gender" {
+		draft.Job.Gender = job.Gender
+	}
+
+	return nil
+}
+
+// loadJobDraft is a method that returns the job draft of the given chat, a draft that has expired
+// or that doesn't belong to the given user isn't returned
+func (handler *TelegramBotHandler) loadJobDraft(chatID int64, user *entity.User) (*bot.JobDraft, error) {
+
+	draft := new(bot.JobDraft)
+	err := handler.sessions.Load(strconv.FormatInt(chatID, 10), bot.PayloadJobDraft, draft)
+	if err != nil || draft.Job == nil || draft.Job.Employer != user.ID {
+		return nil, errors.New("no job draft found")
+	}
+
+	return draft, nil
+}
+
+// saveJobDraft is a method that stores the job draft of the given chat
+func (handler *TelegramBotHandler) saveJobDraft(chatID int64, draft *bot.JobDraft) error {
+	return handler.sessions.Save(strconv.FormatInt(chatID, 10), bot.PayloadJobDraft, draft)
+}
+
+// jobDraftActionStep is a function that returns the job posting step an inline keyboard action belongs to
+func jobDraftActionStep(action string) string {
+	switch action {
+	case "sector":
+		return bot.JobDraftSector
+	case "type":
+		return bot.JobDraftType
+	case "education_level":
+		return bot.JobDraftEducationLevel
+	case "experience":
+		return bot.JobDraftExperience
+	case "gender":
+		return bot.JobDraftGender
+	case "contact_type":
+		return bot.JobDraftContactType
+	case "edit", "submit":
+		return bot.JobDraftPreview
+	}
+
+	return ""
+}
+
+// toggleJobDraftValue is a function that adds or removes a value from a comma separated list of values
+func toggleJobDraftValue(values, value string) string {
+
+	toggled := make([]string, 0)
+	found := false
+
+	for _, selected := range strings.Split(values, ",") {
+		selected = strings.TrimSpace(selected)
+		if selected == "" {
+			continue
+		}
+
+		if selected == value {
+			found = true
+			continue
+		}
+		toggled = append(toggled, selected)
+	}
+
+	if !found {
+		toggled = append(toggled, value)
+	}
+
+	return strings.Join(toggled, ",")
+}
+
+// jobDraftMultiSelectMenu is a function that creates an inline keyboard where the selected attributes are checked
+func jobDraftMultiSelectMenu(action string, attributes []*entity.JobAttribute, selected, language string) string {
+
+	selectedValues := make(map[string]bool)
+	for _, value := range strings.Split(selected, ",") {
+		selectedValues[strings.TrimSpace(value)] = true
+	}
+
+	buttons := [][]bot.InlineKeyboardButton{}
+	row := []bot.InlineKeyboardButton{}
+
+	for index, attribute := range attributes {
+
+		text := attribute.Name
+		if selectedValues[attribute.Name] {
+			text = "✅ " + text
+		}
+
+		row = append(row, bot.InlineKeyboardButton{Text: text,
+			CallbackData: "job/draft/" + action + "/" + strconv.Itoa(index)})
+
+		if len(row) == 2 || index == len(attributes)-1 {
+			buttons = append(buttons, row)
+			row = []bot.InlineKeyboardButton{}
+		}
+	}
+
+	buttons = append(buttons, []bot.InlineKeyboardButton{
+		{Text: locale.Text(language, "post.next"), CallbackData: "job/draft/" + action + "/next"},
+	})
+
+	return bot.CreateInlineKeyboard(buttons...)
+}
+
+// jobDraftSelectMenu is a function that creates an inline keyboard for selecting a single value
+func jobDraftSelectMenu(action string, values []string) string {
+
+	buttons := [][]bot.InlineKeyboardButton{}
+	row := []bot.InlineKeyboardButton{}
+
+	for index, value := range values {
+
+		row = append(row, bot.InlineKeyboardButton{Text: value,
+			CallbackData: "job/draft/" + action + "/" + strconv.Itoa(index)})
+
+		if len(row) == 2 || index == len(values)-1 {
+			buttons = append(buttons, row)
+			row = []bot.InlineKeyboardButton{}
+		}
+	}
+
+	return bot.CreateInlineKeyboard(buttons...)
+}
+
+// jobDraftEditMenu is a function that creates an inline keyboard for selecting the job posting step to change
+func jobDraftEditMenu(language string) string {
+
+	entries := []string{"post.entry.title", "post.entry.description", "post.entry.sectors", "post.entry.types",
+		"post.entry.education_level", "post.entry.experience", "post.entry.gender", "post.entry.contact_type",
+		"post.entry.due_date"}
+	buttons := [][]bot.InlineKeyboardButton{}
+	row := []bot.InlineKeyboardButton{}
+
+	for index, entry := range entries {
+
+		row = append(row, bot.InlineKeyboardButton{Text: locale.Text(language, entry),
+			CallbackData: "job/draft/edit/" + strconv.Itoa(index)})
+
+		if len(row) == 2 || index == len(entries)-1 {
+			buttons = append(buttons, row)
+			row = []bot.InlineKeyboardButton{}
+		}
+	}
+
+	return bot.CreateInlineKeyboard(buttons...)
+}
+
+// buildJobDraftPreview is a function that builds the preview of a job that is being posted in the given language
+func buildJobDraftPreview(job *entity.Job, language string) string {
+
+	dueDate := locale.Text(language, "post.no_due_date")
+	if job.DueDate != nil {
+		dueDate = job.DueDate.Format(bot.DueDateLayout)
+	}
+
+	return locale.Text(language, "post.preview.detail", html.EscapeString(job.Title), job.Type,
+		bot.GetGender(job.Gender, language), job.EducationLevel, job.Experience, job.ContactType, dueDate,
+		html.EscapeString(job.Description), tools.ChangeSpaceToUnderscore(job.Sector))
+}
@@ -1,117 +1,269 @@
 package handler
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
-	"github.com/Benyam-S/asseri/tools"
 )
 
 // HandleViewProfile is a method that handles the profile viewing process
 func (handler *TelegramBotHandler) HandleViewProfile(update *bot.Update, user *entity.User) {
 
-	category := user.Category
-	if user.Category == entity.UserCategoryasseri {
-		category = "አሰሪ"
-	}
-
-	userProfile := fmt.Sprintf(
-		"<b>Name</b>:   %s\n"+
-			"<b>Category</b>:   %s\n"+
-			"<b>Phonenumber</b>:   %s\n\n",
-		strings.Title(strings.ToLower(user.UserName)), category, user.PhoneNumber)
+	userProfile := locale.Text(user.Language, "profile.view", strings.Title(strings.ToLower(user.UserName)),
+		categoryName(user.Category, user.Language), user.PhoneNumber)
 
-	profileMenu := bot.CreateReplyKeyboard(true, false, []string{"🔧 Update Profile", "🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, userProfile, profileMenu)
+	profileMenu := bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(user.Language, "button.update_profile"), locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, userProfile, profileMenu)
 }
 
-// HandleInitUpdateProfile is a method that initiates the profile updating process
+// HandleInitUpdateProfile is a method that initiates the profile updating process.
+// The entered changes are kept in the session of the chat and only saved once the last step has been passed.
 func (handler *TelegramBotHandler) HandleInitUpdateProfile(update *bot.Update, user *entity.User) {
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"↖️ Skip", "🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter new name", backMenu)
+	handler.HandleCancelUpdateProfile(update.Message.Chat.ID)
+
+	backMenu := bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(user.Language, "button.skip"), locale.Text(user.Language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.enter_name"), backMenu)
+}
+
+// HandleCancelUpdateProfile is a method that discards the profile changes that haven't been saved
+func (handler *TelegramBotHandler) HandleCancelUpdateProfile(chatID int64) {
+	handler.sessions.Clear(strconv.FormatInt(chatID, 10), bot.PayloadProfileDraft)
 }
 
 // HandleUpdateName is a method that handles user name updating process
 func (handler *TelegramBotHandler) HandleUpdateName(update *bot.Update, user *entity.User) bool {
-	user.UserName = strings.TrimSpace(update.Message.Text)
 
-	errMap := handler.urService.ValidateUserProfile(user)
+	draft := handler.loadProfileDraft(update.Message.Chat.ID)
+	draft.UserName = strings.TrimSpace(update.Message.Text)
+
+	profile := applyProfileDraft(user, draft)
+	errMap := handler.urService.ValidateUserProfile(profile)
 	if errMap["user_name"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["user_name"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter new name")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(user.Language, "user_name"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.enter_name"))
 		return false
 	}
 
-	err := handler.urService.UpdateUser(user)
+	err := handler.saveProfileDraft(update.Message.Chat.ID, draft)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to update name!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-enter new name")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.error.name"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.reenter_name"))
 		return false
 	}
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"↖️ Skip", "🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter new phonenumber", backMenu)
+	handler.promptProfilePhone(update.Message.Chat.ID, user.Language)
 	return true
 }
 
-// HandleUpdatePhone is a method that handles phonenumber updating process
-func (handler *TelegramBotHandler) HandleUpdatePhone(update *bot.Update, user *entity.User) bool {
-	user.PhoneNumber = strings.TrimSpace(update.Message.Text)
+// HandleUpdatePhone is a method that handles phonenumber updating process and returns the next state of the profile update.
+// A contact shared by its own owner is accepted as it is, while a typed phonenumber has to be verified with a code first.
+// An empty state is returned if the phonenumber isn't accepted.
+func (handler *TelegramBotHandler) HandleUpdatePhone(update *bot.Update, user *entity.User) string {
+
+	chatID := update.Message.Chat.ID
+	contact := update.Message.Contact
+	if contact.PhoneNumber != "" && !isOwnContact(update.Message) {
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "phone.error.own_contact"))
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.enter_phone"))
+		return ""
+	}
 
-	errMap := handler.urService.ValidateUserProfile(user)
+	phoneNumber := contact.PhoneNumber
+	if phoneNumber == "" {
+		phoneNumber = update.Message.Text
+	}
+
+	draft := handler.loadProfileDraft(chatID)
+	draft.PhoneNumber = strings.TrimSpace(phoneNumber)
+
+	profile := applyProfileDraft(user, draft)
+	errMap := handler.urService.ValidateUserProfile(profile)
 	if errMap["phone_number"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["phone_number"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter new phonenumber")
-		return false
+		handler.SendReplyToTelegramChat(chatID, validationText(user.Language, "phone_number"))
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.enter_phone"))
+		return ""
+	}
+
+	// A typed phonenumber other than the current one is only kept once the code sent to it has been entered
+	if contact.PhoneNumber == "" && profile.PhoneNumber != user.PhoneNumber {
+		err := handler.SendPhoneVerificationCode(chatID, profile.PhoneNumber, user.Language)
+		if err != nil {
+			handler.SendReplyToTelegramChat(chatID, "❌ "+err.Error())
+			handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.enter_phone"))
+			return ""
+		}
+
+		backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(user.Language, "button.main_menu")})
+		handler.SendReplyToTelegramChat(chatID,
+			locale.Text(user.Language, "phone.enter_code", profile.PhoneNumber), backMenu)
+		return bot.StateVerifyPhoneNumber
 	}
 
-	err := handler.urService.UpdateUser(user)
+	if !handler.keepProfilePhone(chatID, user.Language, profile.PhoneNumber) {
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.error.phone"))
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.reenter_phone"))
+		return ""
+	}
+
+	return bot.StateUpdateProfileCategory
+}
+
+// HandleVerifyUpdatePhone is a method that handles the verification code sent to the new phonenumber of a user
+// and returns the next state of the profile update, an empty state is returned if the code can be entered again
+func (handler *TelegramBotHandler) HandleVerifyUpdatePhone(update *bot.Update, user *entity.User) string {
+
+	chatID := update.Message.Chat.ID
+	phoneNumber, result := handler.CheckPhoneVerificationCode(chatID, update.Message.Text)
+
+	switch result {
+	case bot.VerificationFailed:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "phone.error.invalid_code",
+			handler.verificationAttemptsLeft(chatID)))
+		return ""
+
+	case bot.VerificationExpired:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "phone.error.code_expired"))
+		handler.promptProfilePhone(chatID, user.Language)
+		return bot.StateUpdateProfilePhoneNumber
+	}
+
+	if !handler.keepProfilePhone(chatID, user.Language, phoneNumber) {
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "profile.error.phone"))
+		handler.promptProfilePhone(chatID, user.Language)
+		return bot.StateUpdateProfilePhoneNumber
+	}
+
+	return bot.StateUpdateProfileCategory
+}
+
+// keepProfilePhone is a method that adds an accepted phonenumber to the unsaved profile changes
+// and moves on to the category step, it returns whether the phonenumber has been kept or not
+func (handler *TelegramBotHandler) keepProfilePhone(chatID int64, language, phoneNumber string) bool {
+
+	draft := handler.loadProfileDraft(chatID)
+	draft.PhoneNumber = phoneNumber
+
+	err := handler.saveProfileDraft(chatID, draft)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to update phonenumber!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-enter new phonenumber")
 		return false
 	}
 
-	keyboard := bot.CreateReplyKeyboard(true, false, []string{"አሰሪ", "Job Seeker", "Agent"},
-		[]string{"↖️ Skip", "🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Wish to change category?", keyboard)
+	handler.promptProfileCategory(chatID, language)
 	return true
 }
 
-// HandleUpdateCategory is a method that handles user category updating process
-func (handler *TelegramBotHandler) HandleUpdateCategory(update *bot.Update, user *entity.User) bool {
-	category := strings.TrimSpace(update.Message.Text)
+// promptProfilePhone is a method that asks for the new phonenumber of a user
+func (handler *TelegramBotHandler) promptProfilePhone(chatID int64, language string) {
+	keyboard := bot.CreateReplyKeyboardWExtra(true, false,
+		[]bot.ReplyKeyboardButton{{Text: locale.Text(language, "button.share_phone"), RequestContact: true}},
+		[]bot.ReplyKeyboardButton{{Text: locale.Text(language, "button.skip")}, {Text: locale.Text(language, "button.main_menu")}})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "profile.enter_phone"), keyboard)
+}
+
+// promptProfileCategory is a method that asks for the new category of a user
+func (handler *TelegramBotHandler) promptProfileCategory(chatID int64, language string) {
+	keyboard := bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(language, "button.category_asseri"), locale.Text(language, "button.category_job_seeker"),
+			locale.Text(language, "button.category_agent")},
+		[]string{locale.Text(language, "button.skip"), locale.Text(language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "profile.choose_category"), keyboard)
+}
 
-	if category == "አሰሪ" {
-		category = entity.UserCategoryasseri
+// HandleUpdateCategory is a method that handles user category updating process,
+// category is the last step so the profile changes are saved here
+func (handler *TelegramBotHandler) HandleUpdateCategory(update *bot.Update, user *entity.User) bool {
+	draft := handler.loadProfileDraft(update.Message.Chat.ID)
+	draft.Category = userCategory(update.Message.Text)
 
-		// We changed it to lower for formality purpose
-	} else if strings.ToLower(category) == "job seeker" {
-		category = entity.UserCategoryJobSeeker
-	} else if strings.ToLower(category) == "agent" {
-		category = entity.UserCategoryAgent
+	errMap := handler.urService.ValidateUserProfile(applyProfileDraft(user, draft))
+	if errMap["category"] != nil {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(user.Language, "category"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.choose_category"))
+		return false
 	}
 
-	user.Category = category
+	handler.saveProfileDraft(update.Message.Chat.ID, draft)
+	return handler.HandleSaveProfile(update, user)
+}
 
-	errMap := handler.urService.ValidateUserProfile(user)
-	if errMap["category"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["category"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Wish to change category?")
+// HandleSaveProfile is a method that saves the profile changes entered during the profile updating process
+// and returns whether they have been saved or not
+func (handler *TelegramBotHandler) HandleSaveProfile(update *bot.Update, user *entity.User) bool {
+
+	profile := applyProfileDraft(user, handler.loadProfileDraft(update.Message.Chat.ID))
+
+	errMap := handler.urService.ValidateUserProfile(profile)
+	if len(errMap) > 0 {
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.error.update"))
+		for field := range errMap {
+			handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(user.Language, field))
+			break
+		}
 		return false
 	}
 
-	err := handler.urService.UpdateUser(user)
+	err := handler.urService.UpdateUser(profile)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to update category!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-select category")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.error.update"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.reselect_category"))
 		return false
 	}
 
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID,
-		"Congratulations 🎉 you have successfully update your profile!")
+	*user = *profile
+	handler.HandleCancelUpdateProfile(update.Message.Chat.ID)
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "profile.updated"))
 	return true
 }
+
+// loadProfileDraft is a method that returns the unsaved profile changes of the given chat,
+// an empty draft is returned if there isn't any
+func (handler *TelegramBotHandler) loadProfileDraft(chatID int64) *bot.ProfileDraft {
+	draft := new(bot.ProfileDraft)
+	handler.sessions.Load(strconv.FormatInt(chatID, 10), bot.PayloadProfileDraft, draft)
+	return draft
+}
+
+// saveProfileDraft is a method that stores the unsaved profile changes of the given chat
+func (handler *TelegramBotHandler) saveProfileDraft(chatID int64, draft *bot.ProfileDraft) error {
+	return handler.sessions.Save(strconv.FormatInt(chatID, 10), bot.PayloadProfileDraft, draft)
+}
+
+// categoryName is a function that returns the name of a user category in the given language
+func categoryName(category, language string) string {
+
+	switch category {
+	case entity.UserCategoryasseri:
+		return locale.Text(language, "button.category_asseri")
+	case entity.UserCategoryJobSeeker:
+		return locale.Text(language, "button.category_job_seeker")
+	case entity.UserCategoryAgent:
+		return locale.Text(language, "button.category_agent")
+	}
+
+	return category
+}
+
+// applyProfileDraft is a function that returns a copy of the user profile with the draft entries applied
+func applyProfileDraft(user *entity.User, draft *bot.ProfileDraft) *entity.User {
+
+	profile := *user
+	if draft.UserName != "" {
+		profile.UserName = draft.UserName
+	}
+
+	if draft.PhoneNumber != "" {
+		profile.PhoneNumber = draft.PhoneNumber
+	}
+
+	if draft.Category != "" {
+		profile.Category = draft.Category
+	}
+
+	return &profile
+}
@@ -5,8 +5,8 @@ import (
 	"strings"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
-	"github.com/Benyam-S/asseri/tools"
 )
 
 // HandleRegistration is a method that handles the whole registration process
@@ -16,6 +16,8 @@ func (handler *TelegramBotHandler) HandleRegistration(update *bot.Update, tempUs
 		handler.HandleRegistrationName(update, tempUser)
 	case bot.RegistrationStatusUserName:
 		handler.HandleRegistrationPhone(update, tempUser)
+	case bot.RegistrationStatusPhoneVerification:
+		handler.HandleRegistrationVerifyPhone(update, tempUser)
 	case bot.RegistrationStatusPhoneNumber:
 		handler.HandleRegistrationCategory(update, tempUser)
 	}
@@ -25,16 +27,17 @@ func (handler *TelegramBotHandler) HandleRegistration(update *bot.Update, tempUs
 func (handler *TelegramBotHandler) HandleRegistrationInit(update *bot.Update) {
 	tempUser := new(bot.TempUser)
 	tempUser.TelegramID = strconv.FormatInt(update.Message.User.ID, 10)
+	tempUser.Language = locale.Language(update.Message.User.LanguageCode)
 	err := handler.tuService.AddTempUser(tempUser)
 	if err != nil {
-		keyboard := bot.CreateReplyKeyboard(true, true, []string{"🏁 Start"})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID,
-			"❌ Error unable to initiate registration!", keyboard)
+		keyboard := bot.CreateReplyKeyboard(true, true, []string{locale.Text(tempUser.Language, "button.start")})
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID,
+			locale.Text(tempUser.Language, "registration.error.init"), keyboard)
 		return
 	}
 
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Welcome 👋 to አሰሪ, please register first!")
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter company/individual name")
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(tempUser.Language, "registration.welcome"))
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(tempUser.Language, "registration.enter_name"))
 }
 
 // HandleRegistrationName is a method that handles user name registration
@@ -44,81 +47,171 @@ func (handler *TelegramBotHandler) HandleRegistrationName(update *bot.Update, te
 
 	errMap := handler.tuService.ValidateTempUserProfile(tempUser)
 	if errMap["user_name"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["user_name"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Enter company/individual name")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, validationText(tempUser.Language, "user_name"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(tempUser.Language, "registration.enter_name"))
 		return
 	}
 
 	err := handler.tuService.UpdateTempUser(tempUser)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to register username!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-enter your name")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(tempUser.Language, "registration.error.name"))
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(tempUser.Language, "registration.reenter_name"))
 		return
 	}
 
-	keyboard := bot.CreateReplyKeyboardWExtra(true, false, []bot.ReplyKeyboardButton{{Text: "Add 📱", RequestContact: true}})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Add your phonenumber, use 'Add 📱' button to add your phone number", keyboard)
+	handler.promptRegistrationPhone(update.Message.Chat.ID, tempUser.Language, "")
 }
 
-// HandleRegistrationPhone is a method that handles phonenumber registration
+// HandleRegistrationPhone is a method that handles phonenumber registration.
+// A contact shared by its own owner is accepted as it is, while a typed phonenumber has to be verified with a code.
 func (handler *TelegramBotHandler) HandleRegistrationPhone(update *bot.Update, tempUser *bot.TempUser) {
 
-	phoneNumber := update.Message.Contact.PhoneNumber
+	chatID := update.Message.Chat.ID
+	contact := update.Message.Contact
+	if contact.PhoneNumber != "" && !isOwnContact(update.Message) {
+		handler.promptRegistrationPhone(chatID, tempUser.Language, locale.Text(tempUser.Language, "phone.error.own_contact"))
+		return
+	}
+
+	phoneNumber := contact.PhoneNumber
+	if phoneNumber == "" {
+		phoneNumber = update.Message.Text
+	}
+
 	tempUser.PhoneNumber = strings.TrimSpace(phoneNumber)
-	tempUser.Status = bot.RegistrationStatusPhoneNumber
 
 	errMap := handler.tuService.ValidateTempUserProfile(tempUser)
 	if errMap["phone_number"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["phone_number"].Error()))
+		handler.promptRegistrationPhone(chatID, tempUser.Language, validationText(tempUser.Language, "phone_number"))
+		return
+	}
 
-		keyboard := bot.CreateReplyKeyboardWExtra(true, false, []bot.ReplyKeyboardButton{{Text: "Add 📱", RequestContact: true}})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Add your phonenumber, use 'Add 📱' button to add your phone number", keyboard)
+	if contact.PhoneNumber == "" {
+		err := handler.SendPhoneVerificationCode(chatID, tempUser.PhoneNumber, tempUser.Language)
+		if err != nil {
+			handler.promptRegistrationPhone(chatID, tempUser.Language, "❌ "+err.Error())
+			return
+		}
+
+		// The phonenumber is only registered once it has been verified
+		verifiedPhoneNumber := tempUser.PhoneNumber
+		tempUser.PhoneNumber = ""
+		tempUser.Status = bot.RegistrationStatusPhoneVerification
+
+		err = handler.tuService.UpdateTempUser(tempUser)
+		if err != nil {
+			handler.HandleCancelPhoneVerification(chatID)
+			handler.promptRegistrationPhone(chatID, tempUser.Language, locale.Text(tempUser.Language, "registration.error.phone"))
+			return
+		}
+
+		handler.SendReplyToTelegramChat(chatID, locale.Text(tempUser.Language, "phone.enter_code", verifiedPhoneNumber),
+			bot.CreateReplyKeyboard(true, false, []string{locale.Text(tempUser.Language, "button.change_phone")}))
 		return
 	}
 
+	tempUser.Status = bot.RegistrationStatusPhoneNumber
+
 	err := handler.tuService.UpdateTempUser(tempUser)
 	if err != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to register phonenumber!")
+		handler.promptRegistrationPhone(chatID, tempUser.Language, locale.Text(tempUser.Language, "registration.error.phone"))
+		return
+	}
 
-		keyboard := bot.CreateReplyKeyboardWExtra(true, false, []bot.ReplyKeyboardButton{{Text: "Add 📱", RequestContact: true}})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-enter your phonenumber", keyboard)
+	handler.promptRegistrationCategory(chatID, tempUser.Language, "")
+}
+
+// HandleRegistrationVerifyPhone is a method that handles the verification code sent to the phonenumber being registered
+func (handler *TelegramBotHandler) HandleRegistrationVerifyPhone(update *bot.Update, tempUser *bot.TempUser) {
+
+	chatID := update.Message.Chat.ID
+	if locale.Command(update.Message.Text) == "button.change_phone" {
+		handler.HandleCancelPhoneVerification(chatID)
+		handler.restartRegistrationPhone(chatID, tempUser, "")
 		return
 	}
 
-	keyboard := bot.CreateReplyKeyboard(true, true, []string{"አሰሪ", "Job Seeker"}, []string{"Agent"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You wish to be categorized as ?", keyboard)
+	phoneNumber, result := handler.CheckPhoneVerificationCode(chatID, update.Message.Text)
+	switch result {
+	case bot.VerificationFailed:
+		handler.SendReplyToTelegramChat(chatID, locale.Text(tempUser.Language, "phone.error.invalid_code",
+			handler.verificationAttemptsLeft(chatID)))
+		return
+
+	case bot.VerificationExpired:
+		handler.restartRegistrationPhone(chatID, tempUser, locale.Text(tempUser.Language, "phone.error.code_expired"))
+		return
+	}
+
+	tempUser.PhoneNumber = phoneNumber
+	tempUser.Status = bot.RegistrationStatusPhoneNumber
+
+	// The phonenumber might have been registered by someone else while it was being verified
+	errMap := handler.tuService.ValidateTempUserProfile(tempUser)
+	if errMap["phone_number"] != nil {
+		handler.restartRegistrationPhone(chatID, tempUser, validationText(tempUser.Language, "phone_number"))
+		return
+	}
+
+	err := handler.tuService.UpdateTempUser(tempUser)
+	if err != nil {
+		handler.restartRegistrationPhone(chatID, tempUser, locale.Text(tempUser.Language, "registration.error.phone"))
+		return
+	}
+
+	handler.promptRegistrationCategory(chatID, tempUser.Language, "")
 }
 
-// HandleRegistrationCategory is a method that handles user category registration
-func (handler *TelegramBotHandler) HandleRegistrationCategory(update *bot.Update, tempUser *bot.TempUser) {
-	category := strings.TrimSpace(update.Message.Text)
+// restartRegistrationPhone is a method that takes the registration back to the phonenumber step
+func (handler *TelegramBotHandler) restartRegistrationPhone(chatID int64, tempUser *bot.TempUser, reply string) {
+	tempUser.PhoneNumber = ""
+	tempUser.Status = bot.RegistrationStatusUserName
+	handler.tuService.UpdateTempUser(tempUser)
+	handler.promptRegistrationPhone(chatID, tempUser.Language, reply)
+}
+
+// promptRegistrationPhone is a method that asks for the phonenumber of the user being registered,
+// the given reply is sent before the prompt if it isn't empty
+func (handler *TelegramBotHandler) promptRegistrationPhone(chatID int64, language, reply string) {
+	if reply != "" {
+		handler.SendReplyToTelegramChat(chatID, reply)
+	}
 
-	if category == "አሰሪ" {
-		category = entity.UserCategoryasseri
+	keyboard := bot.CreateReplyKeyboardWExtra(true, false,
+		[]bot.ReplyKeyboardButton{{Text: locale.Text(language, "button.share_phone"), RequestContact: true}})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "phone.add"), keyboard)
+}
 
-		// We changed it to lower for formality purpose
-	} else if strings.ToLower(category) == "job seeker" {
-		category = entity.UserCategoryJobSeeker
-	} else if strings.ToLower(category) == "agent" {
-		category = entity.UserCategoryAgent
+// promptRegistrationCategory is a method that asks for the category of the user being registered,
+// the given reply is sent before the prompt if it isn't empty
+func (handler *TelegramBotHandler) promptRegistrationCategory(chatID int64, language, reply string) {
+	if reply != "" {
+		handler.SendReplyToTelegramChat(chatID, reply)
 	}
 
-	tempUser.Category = category
+	keyboard := bot.CreateReplyKeyboard(true, true,
+		[]string{locale.Text(language, "button.category_asseri"), locale.Text(language, "button.category_job_seeker")},
+		[]string{locale.Text(language, "button.category_agent")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "registration.choose_category"), keyboard)
+}
+
+// HandleRegistrationCategory is a method that handles user category registration
+func (handler *TelegramBotHandler) HandleRegistrationCategory(update *bot.Update, tempUser *bot.TempUser) {
+	tempUser.Category = userCategory(update.Message.Text)
 	tempUser.Status = bot.RegistrationStatusCategory
 
 	errMap := handler.tuService.ValidateTempUserProfile(tempUser)
 	if errMap["category"] != nil {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, tools.ToSentenceCase(errMap["category"].Error()))
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You wish to be categorized as ?")
+		handler.promptRegistrationCategory(update.Message.Chat.ID, tempUser.Language,
+			validationText(tempUser.Language, "category"))
 		return
 	}
 
 	user := tempUser.ToUser()
 	err := handler.urService.AddUser(user)
 	if err != nil {
-		keyboard := bot.CreateReplyKeyboard(true, true, []string{"አሰሪ", "Job Seeker"}, []string{"Agent"})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to add new user!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-select category", keyboard)
+		handler.promptRegistrationCategory(update.Message.Chat.ID, tempUser.Language,
+			locale.Text(tempUser.Language, "registration.error.user"))
 		return
 	}
 
@@ -128,19 +221,29 @@ func (handler *TelegramBotHandler) HandleRegistrationCategory(update *bot.Update
 		// Removing the added user this their is no client link
 		handler.urService.DeleteUser(user.ID)
 
-		keyboard := bot.CreateReplyKeyboard(true, true, []string{"አሰሪ", "Job Seeker"}, []string{"Agent"})
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "❌ Error unable to add new user!")
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Re-select category", keyboard)
+		handler.promptRegistrationCategory(update.Message.Chat.ID, tempUser.Language,
+			locale.Text(tempUser.Language, "registration.error.user"))
 		return
 	}
 
 	handler.tuService.DeleteTempUser(tempUser.TelegramID)
 
-	menu := bot.MainMenuW
-	if user.Category == entity.UserCategoryJobSeeker {
-		menu = bot.MainMenuWO
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "registration.completed"),
+		mainMenu(user.Language, user.Category))
+}
+
+// userCategory is a function that returns the user category of a category button in any language,
+// a text that isn't a category button is returned as it is so it fails the validation
+func userCategory(text string) string {
+
+	switch locale.Command(text) {
+	case "button.category_asseri":
+		return entity.UserCategoryasseri
+	case "button.category_job_seeker":
+		return entity.UserCategoryJobSeeker
+	case "button.category_agent":
+		return entity.UserCategoryAgent
 	}
 
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID,
-		"Congratulations 🎉 you have been successfully registered!", menu)
+	return strings.TrimSpace(text)
 }
@@ -1,11 +1,82 @@
 package handler
 
-import "github.com/Benyam-S/asseri/client/bot"
+import (
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
 
 // HandleSettings is a method that handles settings menu viewing
-func (handler *TelegramBotHandler) HandleSettings(update *bot.Update) {
+func (handler *TelegramBotHandler) HandleSettings(update *bot.Update, user *entity.User) {
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "settings.choose"),
+		settingsMenu(user.Language))
+}
+
+// HandleLanguageSettings is a method that shows the languages the bot can talk to the user in
+func (handler *TelegramBotHandler) HandleLanguageSettings(update *bot.Update, user *entity.User) {
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "language.choose"),
+		languageMenu(user.Language))
+}
+
+// HandleChangeLanguage is a method that changes the language of the user using the language buttons,
+// the action has the form language/<language>
+func (handler *TelegramBotHandler) HandleChangeLanguage(action string, update *bot.Update, user *entity.User) {
+
+	query := update.CallbackQuery
+	profile := *user
+	profile.Language = strings.TrimPrefix(action, "language/")
+
+	errMap := handler.urService.ValidateUserProfile(&profile)
+	if errMap["language"] != nil {
+		handler.AnswerToTelegramCallBack(query.ID, validationText(user.Language, "language"))
+		return
+	}
+
+	if profile.Language == user.Language {
+		handler.AnswerToTelegramCallBack(query.ID, "")
+		return
+	}
+
+	err := handler.urService.UpdateUserSingleValue(user.ID, "language", profile.Language)
+	if err != nil {
+		handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "language.error.update"))
+		return
+	}
+
+	user.Language = profile.Language
+	handler.AnswerToTelegramCallBack(query.ID, locale.Text(user.Language, "language.changed"))
+	handler.EditTelegramMessage(query.User.ID, query.Message.MessageID, locale.Text(user.Language, "language.choose"),
+		languageMenu(user.Language))
+
+	// The reply keyboard is still in the previous language so it is sent again
+	handler.SendReplyToTelegramChat(query.User.ID, locale.Text(user.Language, "settings.choose"),
+		settingsMenu(user.Language))
+}
+
+// settingsMenu is a function that returns the settings menu in the given language
+func settingsMenu(language string) string {
+	return bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(language, "button.profile"), locale.Text(language, "button.notifications")},
+		[]string{locale.Text(language, "button.language"), locale.Text(language, "button.feedback")},
+		[]string{locale.Text(language, "button.main_menu")})
+}
+
+// languageMenu is a function that creates an inline keyboard of the supported languages
+// where the given language is checked
+func languageMenu(language string) string {
+
+	buttons := make([]bot.InlineKeyboardButton, 0)
+	for _, supported := range locale.Languages() {
+
+		text := locale.Text(supported, "language.name")
+		if supported == language {
+			text = "✅ " + text
+		}
+
+		buttons = append(buttons, bot.InlineKeyboardButton{Text: text, CallbackData: "language/" + supported})
+	}
 
-	settingsMenu := bot.CreateReplyKeyboard(true, false, []string{"👥 Profile", "🗣️ Feedback"},
-		[]string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Choose preference", settingsMenu)
+	return bot.CreateInlineKeyboard(buttons)
 }
@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
-	"github.com/Benyam-S/asseri/tools"
 )
 
 // HandleJobSubscription is a method that handles job subscription menu and registered subscription viewing
@@ -21,9 +21,8 @@ func (handler *TelegramBotHandler) HandleJobSubscription(update *bot.Update, use
 		}
 	}
 
-	subscriptionMenu := bot.CreateReplyKeyboard(true, false,
-		[]string{"➕ Add Subscription", "📝 Edit Subscriptions"}, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(update.Message.Chat.ID, "Choose option", subscriptionMenu)
+	handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "subscription.choose_option"),
+		subscriptionMenu(user.Language))
 }
 
 // HandleEditJobSubscriptions is a method that handles registered subscription viewing and editing process
@@ -31,8 +30,7 @@ func (handler *TelegramBotHandler) HandleEditJobSubscriptions(update *bot.Update
 	subscriptions := handler.sbService.FindMultipleSubscriptions(user.ID)
 
 	if len(subscriptions) == 0 {
-		bot.SendReplyToTelegramChat(update.Message.Chat.ID, "You haven't subscribed for a job!"+
-			" please subscribe using the add subscription button.")
+		handler.SendReplyToTelegramChat(update.Message.Chat.ID, locale.Text(user.Language, "subscription.empty"))
 		return
 	}
 
@@ -40,24 +38,17 @@ func (handler *TelegramBotHandler) HandleEditJobSubscriptions(update *bot.Update
 
 		errMap := handler.sbService.ValidateSubscription(subscription)
 		if len(errMap) == 0 {
-			reply := fmt.Sprintf(
-				"<b>Job Subscription</b>\n\n"+
-					"<b>Job Type</b>:  %s\n"+
-					"<b>Sector</b>:  %s\n"+
-					"<b>Education Level</b>:  %s\n"+
-					"<b>Experience</b>:  %s\n\n",
-				subscription.Type, subscription.Sector, subscription.EducationLevel, subscription.Experience)
-
 			removeButton := bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-				{Text: "🗑️ Remove", CallbackData: "subscription/remove/" + subscription.ID},
+				{Text: locale.Text(user.Language, "subscription.remove"), CallbackData: "subscription/remove/" + subscription.ID},
 			})
-			bot.SendReplyToTelegramChat(update.Message.Chat.ID, reply, removeButton)
+			handler.SendReplyToTelegramChat(update.Message.Chat.ID, subscriptionDetail(subscription, user.Language),
+				removeButton)
 		}
 	}
 }
 
 // HandleInitAddSubscriptionSector is a method that initiates the job subscription sector adding process
-func (handler *TelegramBotHandler) HandleInitAddSubscriptionSector(client *bot.Client) {
+func (handler *TelegramBotHandler) HandleInitAddSubscriptionSector(client *bot.Client, language string) {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
 	validJobSectors := handler.cmService.GetValidJobSectorsForSubscription()
@@ -85,16 +76,16 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionSector(client *bot.C
 
 	}
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(chatID, "Select job sector", backMenu)
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_sector"), backMenu)
 
 	validJobSectorMenu := bot.CreateInlineKeyboard(validJobSectorButtons...)
-	bot.SendReplyToTelegramChat(chatID,
-		"<b>The following are the valid job sectors avaliable</b>", validJobSectorMenu)
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_sector.hint"),
+		validJobSectorMenu)
 }
 
 // HandleInitAddSubscriptionType is a method that shows the valid job types avaliable for subscription
-func (handler *TelegramBotHandler) HandleInitAddSubscriptionType(subscriptionID string, client *bot.Client) {
+func (handler *TelegramBotHandler) HandleInitAddSubscriptionType(client *bot.Client, language string) {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
 	ValidJobTypes := handler.cmService.GetValidJobTypesForSubscription()
@@ -102,20 +93,20 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionType(subscriptionID
 
 	for _, validJobType := range ValidJobTypes {
 		validJobTypesButtons = append(validJobTypesButtons, []bot.InlineKeyboardButton{
-			{Text: validJobType.Name, CallbackData: "subscription/" + subscriptionID + "/add/type/" + validJobType.ID},
+			{Text: validJobType.Name, CallbackData: "subscription/add/type/" + validJobType.ID},
 		})
 	}
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(chatID, "Select job type", backMenu)
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_type"), backMenu)
 
 	validJobTypeMenu := bot.CreateInlineKeyboard(validJobTypesButtons...)
-	bot.SendReplyToTelegramChat(chatID,
-		"<b>The following are the valid job types avaliable</b>", validJobTypeMenu)
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_type.hint"),
+		validJobTypeMenu)
 }
 
 // HandleInitAddSubscriptionEducationLevel is a method that shows the valid education levels avaliable for subscription
-func (handler *TelegramBotHandler) HandleInitAddSubscriptionEducationLevel(subscriptionID string, client *bot.Client) {
+func (handler *TelegramBotHandler) HandleInitAddSubscriptionEducationLevel(client *bot.Client, language string) {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
 	validEducationLevels := handler.cmService.GetValidEducationLevelsForSubscription()
@@ -128,11 +119,11 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionEducationLevel(subsc
 			row = []bot.InlineKeyboardButton{}
 			row = append(row,
 				bot.InlineKeyboardButton{
-					Text: validEducationLevel.Name, CallbackData: "subscription/" + subscriptionID + "/add/education_level/" + validEducationLevel.ID})
+					Text: validEducationLevel.Name, CallbackData: "subscription/add/education_level/" + validEducationLevel.ID})
 		} else {
 			row = append(row,
 				bot.InlineKeyboardButton{
-					Text: validEducationLevel.Name, CallbackData: "subscription/" + subscriptionID + "/add/education_level/" + validEducationLevel.ID})
+					Text: validEducationLevel.Name, CallbackData: "subscription/add/education_level/" + validEducationLevel.ID})
 			validEducationLevelsButtons = append(validEducationLevelsButtons, row)
 		}
 
@@ -143,16 +134,16 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionEducationLevel(subsc
 
 	}
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(chatID, "Select education level", backMenu)
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_education_level"), backMenu)
 
 	validEducationLevelMenu := bot.CreateInlineKeyboard(validEducationLevelsButtons...)
-	bot.SendReplyToTelegramChat(chatID,
-		"<b>The following are the valid education levels avaliable</b>", validEducationLevelMenu)
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_education_level.hint"),
+		validEducationLevelMenu)
 }
 
 // HandleInitAddSubscriptionExperience is a method that shows the valid work experience avaliable for subscription
-func (handler *TelegramBotHandler) HandleInitAddSubscriptionExperience(subscriptionID string, client *bot.Client) {
+func (handler *TelegramBotHandler) HandleInitAddSubscriptionExperience(client *bot.Client, language string) {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
 	validExperiences := handler.cmService.GetValidWorkExperiencesForSubscription()
@@ -165,11 +156,11 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionExperience(subscript
 			row = []bot.InlineKeyboardButton{}
 			row = append(row,
 				bot.InlineKeyboardButton{
-					Text: validExperience, CallbackData: "subscription/" + subscriptionID + "/add/experience/" + validExperience})
+					Text: validExperience, CallbackData: "subscription/add/experience/" + validExperience})
 		} else {
 			row = append(row,
 				bot.InlineKeyboardButton{
-					Text: validExperience, CallbackData: "subscription/" + subscriptionID + "/add/experience/" + validExperience})
+					Text: validExperience, CallbackData: "subscription/add/experience/" + validExperience})
 			validExperiencesButtons = append(validExperiencesButtons, row)
 		}
 
@@ -180,16 +171,17 @@ func (handler *TelegramBotHandler) HandleInitAddSubscriptionExperience(subscript
 
 	}
 
-	backMenu := bot.CreateReplyKeyboard(true, false, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(chatID, "Select work experience", backMenu)
+	backMenu := bot.CreateReplyKeyboard(true, false, []string{locale.Text(language, "button.main_menu")})
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_experience"), backMenu)
 
 	validExperienceMenu := bot.CreateInlineKeyboard(validExperiencesButtons...)
-	bot.SendReplyToTelegramChat(chatID,
-		"<b>The following are the valid work experiences avaliable</b>", validExperienceMenu)
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_experience.hint"),
+		validExperienceMenu)
 }
 
-// AddSubscriptionSector is a method that handles job subscription sector adding process
-func (handler *TelegramBotHandler) AddSubscriptionSector(jobSectorID string, user *entity.User, client *bot.Client) {
+// AddSubscriptionSector is a method that handles job subscription sector adding process.
+// The subscription is kept in the session of the chat until all of its entries have been selected.
+func (handler *TelegramBotHandler) AddSubscriptionSector(jobSectorID string, user *entity.User, client *bot.Client) int {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
 	subscription := new(entity.Subscription)
@@ -198,33 +190,31 @@ func (handler *TelegramBotHandler) AddSubscriptionSector(jobSectorID string, use
 
 	errMap := handler.sbService.ValidateSubscription(subscription)
 	if errMap["sector"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["sector"].Error()))
-		handler.HandleInitAddSubscriptionSector(client)
-		return
+		handler.SendReplyToTelegramChat(chatID, "❌ "+validationText(user.Language, "sector"))
+		handler.HandleInitAddSubscriptionSector(client, user.Language)
+		return bot.SubscriptionError
 	}
 
-	err := handler.sbService.AddSubscription(subscription)
+	err := handler.sessions.Save(client.TelegramID, bot.PayloadSubscriptionDraft, subscription)
 	if err != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ Error unable to add job subscription sector!")
-		handler.HandleInitAddSubscriptionSector(client)
-		return
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.error.sector"))
+		handler.HandleInitAddSubscriptionSector(client, user.Language)
+		return bot.SubscriptionError
 	}
 
-	handler.HandleInitAddSubscriptionType(subscription.ID, client)
+	handler.HandleInitAddSubscriptionType(client, user.Language)
+	return bot.SubscriptionModified
 }
 
 // AddSubscriptionType is a method that handles job subscription type adding process
-func (handler *TelegramBotHandler) AddSubscriptionType(subscriptionID, jobTypeID string, user *entity.User, client *bot.Client) int {
+func (handler *TelegramBotHandler) AddSubscriptionType(jobTypeID string, user *entity.User, client *bot.Client) int {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
-	subscription, err := handler.sbService.FindSubscription(subscriptionID)
+	subscription, err := handler.loadSubscriptionDraft(client, user)
 
-	// subscription.Type != "" is used so we can't edit existing subscription
+	// subscription.Type != "" is used so we can't change an entry that has already been selected
 	if err != nil || subscription.Type != "" {
-		subscriptionMenu := bot.CreateReplyKeyboard(true, false,
-			[]string{"➕ Add Subscription", "📝 Edit Subscriptions"}, []string{"🔙 Main Menu"})
-		bot.SendReplyToTelegramChat(chatID, "Oops 😳 something terribly went wrong!")
-		bot.SendReplyToTelegramChat(chatID, "Choose option", subscriptionMenu)
+		handler.handleSubscriptionDraftNotFound(chatID, user.Language)
 		return bot.SubscriptionNotFound
 	}
 
@@ -232,40 +222,31 @@ func (handler *TelegramBotHandler) AddSubscriptionType(subscriptionID, jobTypeID
 
 	errMap := handler.sbService.ValidateSubscription(subscription)
 	if errMap["type"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["type"].Error()))
-		handler.HandleInitAddSubscriptionType(subscriptionID, client)
-		return bot.SubscriptionError
-	}
-
-	if errMap["error"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["error"].Error()))
-		handler.HandleInitAddSubscriptionType(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, "❌ "+validationText(user.Language, "type"))
+		handler.HandleInitAddSubscriptionType(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	err = handler.sbService.UpdateSubscription(subscription)
+	err = handler.sessions.Save(client.TelegramID, bot.PayloadSubscriptionDraft, subscription)
 	if err != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ Error unable to add job subscription type!")
-		handler.HandleInitAddSubscriptionType(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.error.type"))
+		handler.HandleInitAddSubscriptionType(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	handler.HandleInitAddSubscriptionEducationLevel(subscription.ID, client)
+	handler.HandleInitAddSubscriptionEducationLevel(client, user.Language)
 	return bot.SubscriptionModified
 }
 
 // AddSubscriptionEducationLevel is a method that handles job subscription education level adding process
-func (handler *TelegramBotHandler) AddSubscriptionEducationLevel(subscriptionID, educationLevelID string, user *entity.User, client *bot.Client) int {
+func (handler *TelegramBotHandler) AddSubscriptionEducationLevel(educationLevelID string, user *entity.User, client *bot.Client) int {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
-	subscription, err := handler.sbService.FindSubscription(subscriptionID)
-
-	// subscription.EducationLevel != "" is used so we can't edit existing subscription
-	if err != nil || subscription.EducationLevel != "" {
-		subscriptionMenu := bot.CreateReplyKeyboard(true, false,
-			[]string{"➕ Add Subscription", "📝 Edit Subscriptions"}, []string{"🔙 Main Menu"})
-		bot.SendReplyToTelegramChat(chatID, "Oops 😳 something terribly went wrong!")
-		bot.SendReplyToTelegramChat(chatID, "Choose option", subscriptionMenu)
+	subscription, err := handler.loadSubscriptionDraft(client, user)
+
+	// subscription.EducationLevel != "" is used so we can't change an entry that has already been selected
+	if err != nil || subscription.Type == "" || subscription.EducationLevel != "" {
+		handler.handleSubscriptionDraftNotFound(chatID, user.Language)
 		return bot.SubscriptionNotFound
 	}
 
@@ -273,40 +254,32 @@ func (handler *TelegramBotHandler) AddSubscriptionEducationLevel(subscriptionID,
 
 	errMap := handler.sbService.ValidateSubscription(subscription)
 	if errMap["education_level"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["education_level"].Error()))
-		handler.HandleInitAddSubscriptionEducationLevel(subscriptionID, client)
-		return bot.SubscriptionError
-	}
-
-	if errMap["error"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["error"].Error()))
-		handler.HandleInitAddSubscriptionEducationLevel(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, "❌ "+validationText(user.Language, "education_level"))
+		handler.HandleInitAddSubscriptionEducationLevel(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	err = handler.sbService.UpdateSubscription(subscription)
+	err = handler.sessions.Save(client.TelegramID, bot.PayloadSubscriptionDraft, subscription)
 	if err != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ Error unable to add education level for the job subscription!")
-		handler.HandleInitAddSubscriptionEducationLevel(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.error.education_level"))
+		handler.HandleInitAddSubscriptionEducationLevel(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	handler.HandleInitAddSubscriptionExperience(subscription.ID, client)
+	handler.HandleInitAddSubscriptionExperience(client, user.Language)
 	return bot.SubscriptionModified
 }
 
-// AddSubscriptionExperience is a method that handles job subscription work experience adding process
-func (handler *TelegramBotHandler) AddSubscriptionExperience(subscriptionID, experience string, user *entity.User, client *bot.Client) int {
+// AddSubscriptionExperience is a method that handles job subscription work experience adding process.
+// Experience is the last entry of a subscription so the subscription is only added to the system here.
+func (handler *TelegramBotHandler) AddSubscriptionExperience(experience string, user *entity.User, client *bot.Client) int {
 
 	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
-	subscription, err := handler.sbService.FindSubscription(subscriptionID)
-
-	// subscription.Experience != "" is used so we can't edit existing subscription
-	if err != nil || subscription.Experience != "" {
-		subscriptionMenu := bot.CreateReplyKeyboard(true, false,
-			[]string{"➕ Add Subscription", "📝 Edit Subscriptions"}, []string{"🔙 Main Menu"})
-		bot.SendReplyToTelegramChat(chatID, "Oops 😳 something terribly went wrong!")
-		bot.SendReplyToTelegramChat(chatID, "Choose option", subscriptionMenu)
+	subscription, err := handler.loadSubscriptionDraft(client, user)
+
+	// subscription.Experience != "" is used so we can't change an entry that has already been selected
+	if err != nil || subscription.EducationLevel == "" || subscription.Experience != "" {
+		handler.handleSubscriptionDraftNotFound(chatID, user.Language)
 		return bot.SubscriptionNotFound
 	}
 
@@ -314,56 +287,80 @@ func (handler *TelegramBotHandler) AddSubscriptionExperience(subscriptionID, exp
 
 	errMap := handler.sbService.ValidateSubscription(subscription)
 	if errMap["experience"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["experience"].Error()))
-		handler.HandleInitAddSubscriptionExperience(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, "❌ "+validationText(user.Language, "experience"))
+		handler.HandleInitAddSubscriptionExperience(client, user.Language)
 		return bot.SubscriptionError
 	}
 
 	if errMap["error"] != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ "+tools.ToSentenceCase(errMap["error"].Error()))
-		handler.HandleInitAddSubscriptionExperience(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, "❌ "+validationText(user.Language, "subscription"))
+		handler.HandleInitAddSubscriptionExperience(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	err = handler.sbService.UpdateSubscription(subscription)
+	err = handler.sbService.AddSubscription(subscription)
 	if err != nil {
-		bot.SendReplyToTelegramChat(chatID, "❌ Error unable to add work experience for job subscription!")
-		handler.HandleInitAddSubscriptionExperience(subscriptionID, client)
+		handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.error.add"))
+		handler.HandleInitAddSubscriptionExperience(client, user.Language)
 		return bot.SubscriptionError
 	}
 
-	reply := fmt.Sprintf(
-		"<b>Job Subscription</b>\n\n"+
-			"<b>Job Type</b>:  %s\n"+
-			"<b>Sector</b>:  %s\n"+
-			"<b>Education Level</b>:  %s\n"+
-			"<b>Experience</b>:  %s\n\n",
-		subscription.Type, subscription.Sector, subscription.EducationLevel, subscription.Experience)
-
-	bot.SendReplyToTelegramChat(chatID,
-		"Congratulations 🎉 you have successfully added new job subscription!")
-	bot.SendReplyToTelegramChat(chatID, reply)
-
-	subscriptionMenu := bot.CreateReplyKeyboard(true, false,
-		[]string{"➕ Add Subscription", "📝 Edit Subscriptions"}, []string{"🔙 Main Menu"})
-	bot.SendReplyToTelegramChat(chatID, "Choose option", subscriptionMenu)
+	handler.HandleCancelSubscriptionDraft(client)
+
+	handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.added"))
+	handler.SendReplyToTelegramChat(chatID, subscriptionDetail(subscription, user.Language))
+	handler.SendReplyToTelegramChat(chatID, locale.Text(user.Language, "subscription.choose_option"),
+		subscriptionMenu(user.Language))
 	return bot.SubscriptionModified
 }
 
+// HandleCancelSubscriptionDraft is a method that discards the job subscription that is being added by the client
+func (handler *TelegramBotHandler) HandleCancelSubscriptionDraft(client *bot.Client) {
+	handler.sessions.Clear(client.TelegramID, bot.PayloadSubscriptionDraft)
+}
+
 // RemoveSubscription is a method that removes a certain job subscription of a user
 func (handler *TelegramBotHandler) RemoveSubscription(subscriptionID string, user *entity.User) (string, error) {
 	subscription, err := handler.sbService.DeleteSubscription(subscriptionID)
 	if err != nil {
-		return "🙁 Oops! unable to remove the subscription", err
+		return locale.Text(user.Language, "subscription.error.remove"), err
 	}
 
-	reply := fmt.Sprintf(
-		"------------- <b>Removed</b> -------------\n\n"+
-			"<b>Job Subscription</b>\n\n"+
-			"<b>Job Type</b>:  %s\n"+
-			"<b>Sector</b>:  %s\n\n"+
-			"------------- <b>Removed</b> -------------\n\n",
-		subscription.Type, subscription.Sector)
+	reply := withBanner(locale.Text(user.Language, "subscription.banner.removed"),
+		locale.Text(user.Language, "subscription.removed", subscription.Type, subscription.Sector))
 
 	return reply, nil
 }
+
+// loadSubscriptionDraft is a method that returns the job subscription that is being added by the client
+func (handler *TelegramBotHandler) loadSubscriptionDraft(client *bot.Client, user *entity.User) (*entity.Subscription, error) {
+
+	subscription := new(entity.Subscription)
+	err := handler.sessions.Load(client.TelegramID, bot.PayloadSubscriptionDraft, subscription)
+	if err != nil || subscription.UserID != user.ID || subscription.Sector == "" {
+		return nil, errors.New("no subscription draft found")
+	}
+
+	return subscription, nil
+}
+
+// handleSubscriptionDraftNotFound is a method that takes the user back to the subscription menu
+// once the subscription that is being added has expired
+func (handler *TelegramBotHandler) handleSubscriptionDraftNotFound(chatID int64, language string) {
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.error.expired"))
+	handler.SendReplyToTelegramChat(chatID, locale.Text(language, "subscription.choose_option"),
+		subscriptionMenu(language))
+}
+
+// subscriptionMenu is a function that returns the job subscription menu in the given language
+func subscriptionMenu(language string) string {
+	return bot.CreateReplyKeyboard(true, false,
+		[]string{locale.Text(language, "button.add_subscription"), locale.Text(language, "button.edit_subscriptions")},
+		[]string{locale.Text(language, "button.main_menu")})
+}
+
+// subscriptionDetail is a function that builds the detail of a job subscription in the given language
+func subscriptionDetail(subscription *entity.Subscription, language string) string {
+	return locale.Text(language, "subscription.detail", subscription.Type, subscription.Sector,
+		subscription.EducationLevel, subscription.Experience)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// SendPhoneVerificationCode is a method that sends a verification code to the given phonenumber in the given language,
+// the code is kept in the session of the chat until it is entered, expires or runs out of attempts.
+// A chat or a phonenumber that has been sent too many codes or has entered too many codes isn't sent a code.
+func (handler *TelegramBotHandler) SendPhoneVerificationCode(chatID int64, phoneNumber, language string) error {
+
+	chatKey := strconv.FormatInt(chatID, 10)
+	now := handler.clock.Now()
+
+	prevVerification := new(bot.PhoneVerification)
+	err := handler.sessions.Load(chatKey, bot.PayloadPhoneVerification, prevVerification)
+	if err == nil && now.Sub(prevVerification.SentAt) < bot.VerificationCodeResendInterval {
+		return errors.New(locale.Text(language, "phone.error.resend_wait"))
+	}
+
+	// The send is counted before the code is sent so parallel requests can't all pass the limit
+	subjects := verificationSubjects(chatID, phoneNumber)
+	sends, err := handler.countVerification(bot.VerificationSendsKey, subjects...)
+	if err != nil {
+		return errors.New(locale.Text(language, "phone.error.send_code"))
+	}
+
+	if sends > bot.VerificationMaxSends ||
+		handler.verificationCount(bot.VerificationAttemptsKey, subjects...) >= bot.VerificationMaxTotalAttempts {
+		return errors.New(locale.Text(language, "phone.error.too_many_codes"))
+	}
+
+	code, err := tools.GenerateOTP()
+	if err != nil {
+		return errors.New(locale.Text(language, "phone.error.send_code"))
+	}
+
+	verification := &bot.PhoneVerification{PhoneNumber: phoneNumber, Code: code, SentAt: now}
+	err = handler.smsSender.Send(phoneNumber, locale.Text(language, "phone.sms_code",
+		verification.Code, int64(bot.VerificationCodeExpiration.Minutes())))
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to send verification code to %s, %s", phoneNumber, err.Error()),
+			entity.BotLogFile)
+		return errors.New(locale.Text(language, "phone.error.send_code"))
+	}
+
+	err = handler.sessions.Save(chatKey, bot.PayloadPhoneVerification, verification)
+	if err != nil {
+		return errors.New(locale.Text(language, "phone.error.send_code"))
+	}
+
+	return nil
+}
+
+// CheckPhoneVerificationCode is a method that checks the code entered by a chat against the code that has been sent.
+// It returns the verified phonenumber along with VerificationPassed, VerificationFailed or VerificationExpired.
+// Every entered code is counted for the code, the chat and the phonenumber before it is compared,
+// so neither sending a new code nor entering codes in parallel allows more guesses.
+func (handler *TelegramBotHandler) CheckPhoneVerificationCode(chatID int64, code string) (string, int) {
+
+	chatKey := strconv.FormatInt(chatID, 10)
+	verification := new(bot.PhoneVerification)
+
+	err := handler.sessions.Load(chatKey, bot.PayloadPhoneVerification, verification)
+	if err != nil || handler.clock.Now().Sub(verification.SentAt) > bot.VerificationCodeExpiration {
+		handler.HandleCancelPhoneVerification(chatID)
+		return "", bot.VerificationExpired
+	}
+
+	attempts, err := handler.store.Increment(verificationCodeKey(chatID, verification), bot.VerificationCodeExpiration)
+	totalAttempts, totalErr := handler.countVerification(bot.VerificationAttemptsKey,
+		verificationSubjects(chatID, verification.PhoneNumber)...)
+	if err != nil || totalErr != nil || attempts > bot.VerificationMaxAttempts ||
+		totalAttempts > bot.VerificationMaxTotalAttempts {
+		handler.HandleCancelPhoneVerification(chatID)
+		return "", bot.VerificationExpired
+	}
+
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(code)), []byte(verification.Code)) == 1 {
+		handler.HandleCancelPhoneVerification(chatID)
+		return verification.PhoneNumber, bot.VerificationPassed
+	}
+
+	if attempts >= bot.VerificationMaxAttempts || totalAttempts >= bot.VerificationMaxTotalAttempts {
+		handler.HandleCancelPhoneVerification(chatID)
+		return "", bot.VerificationExpired
+	}
+
+	return "", bot.VerificationFailed
+}
+
+// HandleCancelPhoneVerification is a method that discards the verification code sent to the given chat
+func (handler *TelegramBotHandler) HandleCancelPhoneVerification(chatID int64) {
+	handler.sessions.Clear(strconv.FormatInt(chatID, 10), bot.PayloadPhoneVerification)
+}
+
+// verificationAttemptsLeft is a method that returns how many times the verification code of the chat can still be entered
+func (handler *TelegramBotHandler) verificationAttemptsLeft(chatID int64) int64 {
+	verification := new(bot.PhoneVerification)
+	handler.sessions.Load(strconv.FormatInt(chatID, 10), bot.PayloadPhoneVerification, verification)
+
+	attempts, _ := strconv.ParseInt(handler.store.Get(verificationCodeKey(chatID, verification)), 10, 64)
+	attemptsLeft := bot.VerificationMaxAttempts - attempts
+	totalAttemptsLeft := bot.VerificationMaxTotalAttempts -
+		handler.verificationCount(bot.VerificationAttemptsKey, verificationSubjects(chatID, verification.PhoneNumber)...)
+
+	if totalAttemptsLeft < attemptsLeft {
+		return totalAttemptsLeft
+	}
+	return attemptsLeft
+}
+
+// verificationCount is a method that returns the highest count of the given subjects that has been kept
+// under the given key format within the limit window
+func (handler *TelegramBotHandler) verificationCount(keyFormat string, subjects ...string) int64 {
+
+	var highest int64
+	for _, subject := range subjects {
+		count, _ := strconv.ParseInt(handler.store.Get(fmt.Sprintf(keyFormat, subject)), 10, 64)
+		if count > highest {
+			highest = count
+		}
+	}
+
+	return highest
+}
+
+// countVerification is a method that atomically increments the count of every given subject kept under the given
+// key format and returns the highest new count, a count is kept until nothing has been counted for the limit window
+func (handler *TelegramBotHandler) countVerification(keyFormat string, subjects ...string) (int64, error) {
+
+	var highest int64
+	for _, subject := range subjects {
+		count, err := handler.store.Increment(fmt.Sprintf(keyFormat, subject), bot.VerificationLimitWindow)
+		if err != nil {
+			return 0, err
+		}
+
+		if count > highest {
+			highest = count
+		}
+	}
+
+	return highest, nil
+}
+
+// verificationCodeKey is a function that returns the store key the entered codes of a single verification code
+// are counted under
+func verificationCodeKey(chatID int64, verification *bot.PhoneVerification) string {
+	return fmt.Sprintf(bot.VerificationCodeAttemptsKey, strconv.FormatInt(chatID, 10), verification.SentAt.UnixNano())
+}
+
+// verificationSubjects is a function that returns the subjects the verification limits of a chat
+// and a phonenumber are counted for
+func verificationSubjects(chatID int64, phoneNumber string) []string {
+	return []string{"chat/" + strconv.FormatInt(chatID, 10), "phone/" + phoneNumber}
+}
+
+// isOwnContact is a function that checks whether the contact shared in a message belongs to the sender,
+// Telegram has already verified the phonenumber of such a contact
+func isOwnContact(message bot.Message) bool {
+	return message.Contact.PhoneNumber != "" && message.Contact.UserID == message.User.ID
+}
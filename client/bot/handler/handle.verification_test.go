@@ -0,0 +1,164 @@
+package handler_test
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// sentCode is a function that returns the verification code kept in the session of the given chat
+func sentCode(t *testing.T, store tools.IStore, chatID int64) string {
+	t.Helper()
+
+	verification := new(bot.PhoneVerification)
+	err := tools.NewSessionStore(store, bot.SessionExpiration).Load(strconv.FormatInt(chatID, 10),
+		bot.PayloadPhoneVerification, verification)
+	if err != nil {
+		t.Fatalf("expected a verification code to be kept for chat %d", chatID)
+	}
+
+	return verification.Code
+}
+
+func TestVerificationCodeSendLimit(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	phoneNumber := "+251911000009"
+
+	for i := 0; i < bot.VerificationMaxSends; i++ {
+		if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err != nil {
+			t.Fatalf("expected code %d to be sent, got %s", i+1, err)
+		}
+
+		if code := sentCode(t, fakeBot.Store, 1); !regexp.MustCompile(`^\d{6}$`).MatchString(code) {
+			t.Errorf("expected a 6 digit code, got %q", code)
+		}
+
+		fakeBot.Clock.Advance(bot.VerificationCodeResendInterval)
+	}
+
+	if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err == nil {
+		t.Errorf("expected no more codes to be sent to the chat")
+	}
+
+	// The limit of the phonenumber is kept when another chat asks for a code
+	if err := fakeBot.Handler.SendPhoneVerificationCode(2, phoneNumber, locale.DefaultLanguage); err == nil {
+		t.Errorf("expected no more codes to be sent to the phonenumber")
+	}
+
+	if err := fakeBot.Handler.SendPhoneVerificationCode(2, "+251911000010", locale.DefaultLanguage); err != nil {
+		t.Errorf("expected a code to be sent to another phonenumber, got %s", err)
+	}
+
+	// The counts start over once nothing has been counted for the limit window
+	fakeBot.Clock.Advance(bot.VerificationLimitWindow + time.Minute)
+	if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err != nil {
+		t.Errorf("expected a code to be sent once the limit window has passed, got %s", err)
+	}
+}
+
+func TestVerificationAttemptLimit(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	phoneNumber := "+251911000009"
+
+	// Sending a new code renews the attempts of the code but not the total attempts
+	attempts := 0
+	for attempts < bot.VerificationMaxTotalAttempts {
+		if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err != nil {
+			t.Fatalf("expected a code to be sent after %d wrong codes, got %s", attempts, err)
+		}
+
+		for result := bot.VerificationFailed; result == bot.VerificationFailed; attempts++ {
+			_, result = fakeBot.Handler.CheckPhoneVerificationCode(1, "wrong")
+		}
+
+		fakeBot.Clock.Advance(bot.VerificationCodeResendInterval)
+	}
+
+	if attempts != bot.VerificationMaxTotalAttempts {
+		t.Errorf("expected %d wrong codes to be entered in total, got %d", bot.VerificationMaxTotalAttempts, attempts)
+	}
+
+	if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err == nil {
+		t.Errorf("expected no more codes to be sent once the total attempts have run out")
+	}
+
+	if err := fakeBot.Handler.SendPhoneVerificationCode(2, phoneNumber, locale.DefaultLanguage); err == nil {
+		t.Errorf("expected no more codes to be sent to the phonenumber from another chat")
+	}
+
+	// A correct code still passes within the limits
+	if err := fakeBot.Handler.SendPhoneVerificationCode(3, "+251911000010", locale.DefaultLanguage); err != nil {
+		t.Fatalf("expected a code to be sent to another chat and phonenumber, got %s", err)
+	}
+
+	verified, result := fakeBot.Handler.CheckPhoneVerificationCode(3, sentCode(t, fakeBot.Store, 3))
+	if result != bot.VerificationPassed || verified != "+251911000010" {
+		t.Errorf("expected the phonenumber to be verified, got %q and %d", verified, result)
+	}
+}
+
+func TestVerificationLimitsConcurrently(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	phoneNumber := "+251911000009"
+
+	if err := fakeBot.Handler.SendPhoneVerificationCode(1, phoneNumber, locale.DefaultLanguage); err != nil {
+		t.Fatalf("expected a code to be sent, got %s", err)
+	}
+	code := sentCode(t, fakeBot.Store, 1)
+
+	// Wrong codes entered in parallel are all counted, so only the attempts of a single code are answered as failed
+	results := make([]int, 20)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, results[i] = fakeBot.Handler.CheckPhoneVerificationCode(1, "wrong")
+		}(i)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result == bot.VerificationFailed {
+			failed++
+		}
+	}
+
+	if failed > bot.VerificationMaxAttempts-1 {
+		t.Errorf("expected at most %d wrong codes to be allowed, got %d", bot.VerificationMaxAttempts-1, failed)
+	}
+
+	if _, result := fakeBot.Handler.CheckPhoneVerificationCode(1, code); result != bot.VerificationExpired {
+		t.Errorf("expected the code to run out of attempts, got %d", result)
+	}
+
+	// Codes requested in parallel by different chats for the same phonenumber can't pass the send limit
+	sent := make([]bool, 20)
+	for i := range sent {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sent[i] = fakeBot.Handler.SendPhoneVerificationCode(int64(100+i), "+251911000011",
+				locale.DefaultLanguage) == nil
+		}(i)
+	}
+	wg.Wait()
+
+	sends := 0
+	for _, ok := range sent {
+		if ok {
+			sends++
+		}
+	}
+
+	if sends != bot.VerificationMaxSends {
+		t.Errorf("expected %d codes to be sent, got %d", bot.VerificationMaxSends, sends)
+	}
+}
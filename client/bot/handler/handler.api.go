@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/google/uuid"
+)
+
+// IssueJobPostingToken is a method that issues a single use access token that allows the user to post a job
+// through the job submission api
+func (handler *TelegramBotHandler) IssueJobPostingToken(user *entity.User) string {
+	accessToken := uuid.Must(uuid.NewRandom()).String()
+	handler.store.Add(fmt.Sprintf(bot.JobPostTokenKey, accessToken), user.ID)
+	return accessToken
+}
+
+// HandleSubmitJob is a handler func that adds a job posted with an access token issued by the bot as a pending job
+func (handler *TelegramBotHandler) HandleSubmitJob(w http.ResponseWriter, r *http.Request) {
+
+	w.Header().Set("Content-Type", "application/json")
+
+	submission := new(bot.JobSubmission)
+	err := json.NewDecoder(r.Body).Decode(submission)
+	if err != nil {
+		output, _ := json.MarshalIndent(map[string]string{"error": "unable to parse job"}, "", "\t")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(output)
+		return
+	}
+
+	tokenKey := fmt.Sprintf(bot.JobPostTokenKey, submission.AccessToken)
+	if submission.AccessToken == "" || submission.EmployerID == "" ||
+		handler.store.Get(tokenKey) != submission.EmployerID {
+		output, _ := json.MarshalIndent(map[string]string{"error": "invalid access token"}, "", "\t")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write(output)
+		return
+	}
+
+	job := &entity.Job{Employer: submission.EmployerID, InitiatorID: submission.EmployerID,
+		Title: submission.Title, Description: submission.Description, Type: submission.Type,
+		Sector: submission.Sector, EducationLevel: submission.EducationLevel,
+		Experience: submission.Experience, Gender: submission.Gender, ContactType: submission.ContactType,
+		DueDate: submission.DueDate, PostType: entity.PostCategoryUser, Status: entity.JobStatusPending}
+
+	// The token isn't used up by an invalid job so the form can be corrected and submitted again
+	errMap := handler.jbService.ValidateJob(job)
+	if len(errMap) > 0 {
+		output, _ := json.MarshalIndent(map[string]interface{}{"errors": errMap.StringMap()}, "", "\t")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(output)
+		return
+	}
+
+	// Taking the token makes sure concurrent submissions can't use it twice, its time left is read
+	// beforehand so the token doesn't outlive its original expiration if it has to be put back
+	tokenTTL := handler.store.TTL(tokenKey)
+	if handler.store.Take(tokenKey) != submission.EmployerID {
+		output, _ := json.MarshalIndent(map[string]string{"error": "invalid access token"}, "", "\t")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write(output)
+		return
+	}
+
+	err = handler.jbService.AddJob(job)
+	if err != nil {
+		// The job hasn't been added so the token is put back for the form to be submitted again
+		handler.store.AddWithExpiry(tokenKey, submission.EmployerID, tokenTTL)
+
+		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(output)
+		return
+	}
+
+	handler.ForwardJobForModeration(job)
+
+	output, _ := json.MarshalIndent(map[string]string{"id": job.ID, "status": job.Status}, "", "\t")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(output)
+}
+
+// jobPostingFormURL is a method that returns the link of the web job posting form for the given user,
+// empty string is returned if no form has been configured
+func (handler *TelegramBotHandler) jobPostingFormURL(user *entity.User) string {
+
+	formURL := os.Getenv("job_post_url")
+	if formURL == "" {
+		return ""
+	}
+
+	query := url.Values{"employer_id": {user.ID}, "access_token": {handler.IssueJobPostingToken(user)}}
+	return formURL + "?" + query.Encode()
+}
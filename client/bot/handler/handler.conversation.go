@@ -0,0 +1,461 @@
+package handler
+
+import (
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/fsm"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// newConversation is a method that returns the machine that routes the commands and callback actions of a client.
+// Every flow of the bot is declared here as a state with the inputs it accepts, their handlers and the next state.
+func (handler *TelegramBotHandler) newConversation() *fsm.Machine {
+
+	// A job posting step can move to any other step and ends at the main menu once the job is submitted
+	jobDraftReturns := append([]string{bot.StateMainMenu}, bot.JobDraftSteps...)
+
+	jobDraftStates := make([]*fsm.State, 0)
+	for _, step := range bot.JobDraftSteps {
+		jobDraftStates = append(jobDraftStates, &fsm.State{
+			Name: step,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Handle: handler.handleJobDraftInput, Returns: jobDraftReturns},
+			},
+			OnEscape: func(r *fsm.Request) { handler.HandleCancelJobDraft(r.Update.Message.Chat.ID) },
+		})
+	}
+
+	states := []*fsm.State{
+		{Name: bot.StateMainMenu},
+		{Name: bot.StateMyApplications},
+
+		// ----- Manage jobs -----
+		{Name: bot.StateManageJobs, Commands: handler.manageJobsRoutes()},
+		{Name: bot.StatePendingJobs, Commands: handler.manageJobsRoutes()},
+		{Name: bot.StateClosedJobs, Commands: handler.manageJobsRoutes()},
+		{Name: bot.StateDeclinedJobs, Commands: handler.manageJobsRoutes()},
+		{
+			Name:     bot.StateOpenedJobs,
+			Commands: handler.manageJobsRoutes(),
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("job/close/"), Handle: func(r *fsm.Request) string {
+					handler.HandleCloseJobAction(r.Input[len("job/close/"):], r.Update, r.User)
+					return fsm.Stay
+				}},
+			},
+		},
+
+		// ----- Job subscriptions -----
+		{
+			Name: bot.StateJobSubscriptions,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.add_subscription"), Next: bot.StateAddSubscription, Handle: func(r *fsm.Request) string {
+					handler.HandleInitAddSubscriptionSector(r.Client, r.User.Language)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Exact("button.edit_subscriptions"), Handle: func(r *fsm.Request) string {
+					handler.HandleEditJobSubscriptions(r.Update, r.User)
+					return fsm.Stay
+				}},
+			},
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("subscription/remove/"), Handle: handler.handleRemoveSubscription},
+			},
+		},
+		{
+			Name: bot.StateAddSubscription,
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("subscription/add/sector/"), Next: bot.StateAddSubscriptionSector,
+					Returns: []string{bot.StateJobSubscriptions}, Handle: handler.subscriptionStepHandler("subscription/add/sector/", handler.AddSubscriptionSector)},
+			},
+			OnEscape: handler.cancelSubscriptionDraft,
+		},
+		{
+			Name: bot.StateAddSubscriptionSector,
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("subscription/add/type/"), Next: bot.StateAddSubscriptionType,
+					Returns: []string{bot.StateJobSubscriptions}, Handle: handler.subscriptionStepHandler("subscription/add/type/", handler.AddSubscriptionType)},
+			},
+			OnEscape: handler.cancelSubscriptionDraft,
+		},
+		{
+			Name: bot.StateAddSubscriptionType,
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("subscription/add/education_level/"), Next: bot.StateAddSubscriptionEducationLevel,
+					Returns: []string{bot.StateJobSubscriptions}, Handle: handler.subscriptionStepHandler("subscription/add/education_level/",
+						handler.AddSubscriptionEducationLevel)},
+			},
+			OnEscape: handler.cancelSubscriptionDraft,
+		},
+		{
+			Name: bot.StateAddSubscriptionEducationLevel,
+			Actions: []*fsm.Route{
+				// Experience is the last step of a subscription so a modified subscription is complete
+				{Match: fsm.Prefix("subscription/add/experience/"), Next: bot.StateJobSubscriptions,
+					Handle: handler.subscriptionStepHandler("subscription/add/experience/", handler.AddSubscriptionExperience)},
+			},
+			OnEscape: handler.cancelSubscriptionDraft,
+		},
+
+		// ----- Settings -----
+		{
+			Name: bot.StateSettings,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.profile"), Next: bot.StateProfile, Handle: func(r *fsm.Request) string {
+					handler.HandleViewProfile(r.Update, r.User)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Exact("button.notifications"), Next: bot.StateNotifications, Handle: func(r *fsm.Request) string {
+					handler.HandleNotificationSettings(r.Update, r.User)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Exact("button.language"), Handle: func(r *fsm.Request) string {
+					handler.HandleLanguageSettings(r.Update, r.User)
+					return fsm.Stay
+				}},
+				{Match: fsm.Exact("button.feedback"), Next: bot.StateFeedback, Handle: func(r *fsm.Request) string {
+					handler.HandlePromptFeedback(r.Update, r.User)
+					return fsm.Proceed
+				}},
+			},
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("language/"), Handle: func(r *fsm.Request) string {
+					handler.HandleChangeLanguage(r.Input, r.Update, r.User)
+					return fsm.Stay
+				}},
+			},
+		},
+		{
+			Name: bot.StateNotifications,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.set_email"), Next: bot.StateUpdateEmail, Handle: func(r *fsm.Request) string {
+					handler.HandlePromptEmail(r.Update, r.User)
+					return fsm.Proceed
+				}},
+			},
+			Actions: []*fsm.Route{
+				{Match: fsm.Prefix("notification/fallback/"), Handle: func(r *fsm.Request) string {
+					handler.HandleNotificationFallbackAction(r.Input, r.Update, r.User)
+					return fsm.Stay
+				}},
+			},
+		},
+		{
+			Name: bot.StateUpdateEmail,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Next: bot.StateNotifications, Handle: func(r *fsm.Request) string {
+					if !handler.HandleUpdateEmail(r.Update, r.User) {
+						return fsm.Stay
+					}
+					handler.HandleNotificationSettings(r.Update, r.User)
+					return fsm.Proceed
+				}},
+			},
+		},
+		{
+			Name: bot.StateFeedback,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Next: bot.StateMainMenu, Handle: func(r *fsm.Request) string {
+					return handler.thenShowMainMenu(r, handler.HandleReceiveFeedback(r.Update, r.User))
+				}},
+			},
+		},
+		{
+			Name: bot.StateProfile,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.update_profile"), Next: bot.StateUpdateProfile, Handle: func(r *fsm.Request) string {
+					handler.HandleInitUpdateProfile(r.Update, r.User)
+					return fsm.Proceed
+				}},
+			},
+		},
+		{
+			Name: bot.StateUpdateProfile,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.skip"), Next: bot.StateUpdateProfilePhoneNumber, Handle: func(r *fsm.Request) string {
+					handler.promptProfilePhone(r.Update.Message.Chat.ID, r.User.Language)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Any(), Next: bot.StateUpdateProfilePhoneNumber, Handle: func(r *fsm.Request) string {
+					return proceedIf(handler.HandleUpdateName(r.Update, r.User))
+				}},
+			},
+			OnEscape: handler.cancelUpdateProfile,
+		},
+		{
+			Name: bot.StateUpdateProfilePhoneNumber,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.skip"), Next: bot.StateUpdateProfileCategory, Handle: func(r *fsm.Request) string {
+					handler.promptProfileCategory(r.Update.Message.Chat.ID, r.User.Language)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Any(), Returns: []string{bot.StateVerifyPhoneNumber, bot.StateUpdateProfileCategory},
+					Handle: func(r *fsm.Request) string {
+						return stayIfEmpty(handler.HandleUpdatePhone(r.Update, r.User))
+					}},
+			},
+			OnEscape: handler.cancelUpdateProfile,
+		},
+		{
+			Name: bot.StateVerifyPhoneNumber,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Returns: []string{bot.StateUpdateProfilePhoneNumber, bot.StateUpdateProfileCategory},
+					Handle: func(r *fsm.Request) string {
+						return stayIfEmpty(handler.HandleVerifyUpdatePhone(r.Update, r.User))
+					}},
+			},
+			OnEscape: func(r *fsm.Request) {
+				handler.HandleCancelPhoneVerification(r.Update.Message.Chat.ID)
+				handler.cancelUpdateProfile(r)
+			},
+		},
+		{
+			Name: bot.StateUpdateProfileCategory,
+			Commands: []*fsm.Route{
+				{Match: fsm.Exact("button.skip"), Next: bot.StateProfile, Handle: func(r *fsm.Request) string {
+					if !handler.HandleSaveProfile(r.Update, r.User) {
+						return fsm.Stay
+					}
+					handler.HandleViewProfile(r.Update, r.User)
+					return fsm.Proceed
+				}},
+				{Match: fsm.Any(), Next: bot.StateProfile, Handle: func(r *fsm.Request) string {
+					if !handler.HandleUpdateCategory(r.Update, r.User) {
+						return fsm.Stay
+					}
+					handler.HandleViewProfile(r.Update, r.User)
+					return fsm.Proceed
+				}},
+			},
+			OnEscape: handler.cancelUpdateProfile,
+		},
+
+		// ----- Applications and feedback threads -----
+		{
+			Name: bot.StateApply,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Next: bot.StateMainMenu, Handle: func(r *fsm.Request) string {
+					err := handler.HandleApplyForJob(r.Update, r.Session.Get(bot.SessionJobID), r.User)
+					return handler.thenShowMainMenu(r, err == nil || err.Error() == "unable to apply for the job")
+				}},
+			},
+		},
+		{
+			Name: bot.StateFeedbackReply,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Next: bot.StateMainMenu, Handle: func(r *fsm.Request) string {
+					return handler.thenShowMainMenu(r,
+						handler.HandleReceiveFeedbackReply(r.Session.Get(bot.SessionFeedbackID), r.Update, r.User))
+				}},
+			},
+		},
+		{
+			Name: bot.StateMessageApplicant,
+			Commands: []*fsm.Route{
+				{Match: fsm.Any(), Next: bot.StateMainMenu, Handle: func(r *fsm.Request) string {
+					return handler.thenShowMainMenu(r, handler.HandleMessageApplicant(r.Session.Get(bot.SessionJobID),
+						r.Session.Get(bot.SessionJobSeekerID), r.Update, r.User))
+				}},
+			},
+		},
+	}
+
+	return fsm.NewMachine(&fsm.Config{
+		Initial: bot.StateMainMenu,
+		States:  append(states, jobDraftStates...),
+
+		// Leaves any flow and goes back to the main menu
+		Escape: &fsm.Route{Match: fsm.Exact("button.main_menu", "button.cancel_application"), Next: bot.StateMainMenu,
+			Handle: func(r *fsm.Request) string {
+				handler.HandleShowMainMenu(r.Update, r.User)
+				return fsm.Proceed
+			}},
+
+		// The main menu commands
+		Commands: []*fsm.Route{
+			{Match: fsm.Exact("button.post_job"), Next: bot.JobDraftTitle, Returns: []string{bot.StateMainMenu}, Handle: func(r *fsm.Request) string {
+				if !handler.HandlePostJob(r.Update, r.User) {
+					return bot.StateMainMenu
+				}
+				return fsm.Proceed
+			}},
+			{Match: fsm.Exact("button.manage_jobs"), Next: bot.StateManageJobs, Handle: func(r *fsm.Request) string {
+				handler.HandleMangeJobs(r.Update, r.User)
+				return fsm.Proceed
+			}},
+			{Match: fsm.Exact("button.job_subscriptions"), Next: bot.StateJobSubscriptions, Handle: func(r *fsm.Request) string {
+				handler.HandleJobSubscription(r.Update, r.User)
+				return fsm.Proceed
+			}},
+			{Match: fsm.Exact("button.my_applications"), Next: bot.StateMyApplications, Handle: func(r *fsm.Request) string {
+				handler.HandleMyApplications(r.Update, r.User)
+				return fsm.Proceed
+			}},
+			{Match: fsm.Exact("button.settings"), Next: bot.StateSettings, Handle: func(r *fsm.Request) string {
+				handler.HandleSettings(r.Update, r.User)
+				return fsm.Proceed
+			}},
+
+			// Start command for job application and get menu flow
+			{Match: fsm.Prefix("/start apply_"), Next: bot.StateApply, Returns: []string{bot.StateMainMenu}, Handle: func(r *fsm.Request) string {
+				jobID := r.Input[len("/start apply_"):]
+				if !handler.HandleInitApplyForJob(jobID, r.User, r.Update.Message.Chat.ID) {
+					handler.HandleShowMainMenu(r.Update, r.User)
+					return bot.StateMainMenu
+				}
+
+				r.Session.Set(bot.SessionJobID, jobID)
+				return fsm.Proceed
+			}},
+			{Match: fsm.Exact("/start"), Next: bot.StateMainMenu, Handle: func(r *fsm.Request) string {
+				handler.HandleShowMainMenu(r.Update, r.User)
+				return fsm.Proceed
+			}},
+		},
+
+		// Buttons that can be pressed at any point of the conversation
+		Actions: []*fsm.Route{
+			{Match: fsm.Prefix("reminder/"), Handle: func(r *fsm.Request) string {
+				handler.HandleDueDateReminderAction(r.Input, r.Update, r.User)
+				return fsm.Stay
+			}},
+			{Match: fsm.Prefix("feedback/reply/", "feedback/close/"), Next: bot.StateFeedbackReply,
+				Handle: func(r *fsm.Request) string {
+					feedbackID := handler.HandleFeedbackReplyAction(r.Input, r.Update, r.User)
+					if feedbackID == "" {
+						return fsm.Stay
+					}
+
+					r.Session.Set(bot.SessionFeedbackID, feedbackID)
+					return fsm.Proceed
+				}},
+			{Match: fsm.Prefix("application/"), Next: bot.StateMessageApplicant, Handle: func(r *fsm.Request) string {
+				jobApplication := handler.HandleApplicationAction(r.Input, r.Update, r.User)
+				if jobApplication == nil {
+					return fsm.Stay
+				}
+
+				r.Session.Set(bot.SessionJobID, jobApplication.JobID)
+				r.Session.Set(bot.SessionJobSeekerID, jobApplication.JobSeekerID)
+				return fsm.Proceed
+			}},
+			{Match: fsm.Prefix("applications/"), Handle: func(r *fsm.Request) string {
+				handler.HandleMyApplicationsAction(r.Input, r.Update, r.User)
+				return fsm.Stay
+			}},
+			{Match: fsm.Prefix("applicants/"), Handle: func(r *fsm.Request) string {
+				handler.HandleApplicantsAction(r.Input, r.Update, r.User)
+				return fsm.Stay
+			}},
+			{Match: fsm.Prefix("job/draft/"), Returns: jobDraftReturns, Handle: func(r *fsm.Request) string {
+				return stayIfEmpty(handler.HandleJobDraftAction(r.Session.State, r.Input, r.Update, r.User))
+			}},
+			{Match: fsm.Prefix("job/view/"), Handle: func(r *fsm.Request) string {
+				reply := handler.HandleViewJobDetail(r.Input[len("job/view/"):], r.Update.CallbackQuery.User.ID,
+					r.User.Language)
+				handler.AnswerToTelegramCallBack(r.Update.CallbackQuery.ID, reply)
+				return fsm.Stay
+			}},
+		},
+	})
+}
+
+// manageJobsRoutes is a method that returns the routes of the manage jobs sub menu
+func (handler *TelegramBotHandler) manageJobsRoutes() []*fsm.Route {
+	return []*fsm.Route{
+		{Match: fsm.Exact("button.pending"), Next: bot.StatePendingJobs, Handle: func(r *fsm.Request) string {
+			handler.HandlePendingJobs(r.Update, r.User)
+			return fsm.Proceed
+		}},
+		{Match: fsm.Exact("button.opened"), Next: bot.StateOpenedJobs, Handle: func(r *fsm.Request) string {
+			handler.HandleOpenedJobs(r.Update, r.User)
+			return fsm.Proceed
+		}},
+		{Match: fsm.Exact("button.closed"), Next: bot.StateClosedJobs, Handle: func(r *fsm.Request) string {
+			handler.HandleClosedJobs(r.Update, r.User)
+			return fsm.Proceed
+		}},
+		{Match: fsm.Exact("button.declined"), Next: bot.StateDeclinedJobs, Handle: func(r *fsm.Request) string {
+			handler.HandleDeclinedJobs(r.Update, r.User)
+			return fsm.Proceed
+		}},
+	}
+}
+
+// handleJobDraftInput is a method that passes a text entered while posting a job to the current job posting step
+func (handler *TelegramBotHandler) handleJobDraftInput(r *fsm.Request) string {
+	return stayIfEmpty(handler.HandleJobDraftInput(r.Session.State, r.Input, r.Update, r.User))
+}
+
+// handleRemoveSubscription is a method that removes a subscription using the remove button of the subscription
+func (handler *TelegramBotHandler) handleRemoveSubscription(r *fsm.Request) string {
+
+	subscriptionID := r.Input[len("subscription/remove/"):]
+	reply, err := handler.RemoveSubscription(subscriptionID, r.User)
+
+	if err != nil {
+		handler.AnswerToTelegramCallBack(r.Update.CallbackQuery.ID, reply)
+	} else {
+		handler.AnswerToTelegramCallBack(r.Update.CallbackQuery.ID, "")
+		handler.SendReplyToTelegramChat(r.Update.CallbackQuery.User.ID, reply)
+	}
+
+	return fsm.Stay
+}
+
+// cancelSubscriptionDraft is a method that discards the job subscription that is being added once the flow is left
+func (handler *TelegramBotHandler) cancelSubscriptionDraft(r *fsm.Request) {
+	handler.HandleCancelSubscriptionDraft(r.Client)
+}
+
+// cancelUpdateProfile is a method that discards the unsaved profile changes once the flow is left
+func (handler *TelegramBotHandler) cancelUpdateProfile(r *fsm.Request) {
+	handler.HandleCancelUpdateProfile(r.Update.Message.Chat.ID)
+}
+
+// subscriptionStepHandler is a method that returns the handler of a subscription step button.
+// The action has the form <prefix><value>, a subscription that can't be found ends the flow
+// while a subscription that hasn't been modified keeps the current step.
+func (handler *TelegramBotHandler) subscriptionStepHandler(prefix string,
+	addStep func(value string, user *entity.User, client *bot.Client) int) fsm.Handler {
+
+	return func(r *fsm.Request) string {
+		result := addStep(r.Input[len(prefix):], r.User, r.Client)
+		handler.AnswerToTelegramCallBack(r.Update.CallbackQuery.ID, "")
+
+		switch result {
+		case bot.SubscriptionNotFound:
+			return bot.StateJobSubscriptions
+		case bot.SubscriptionModified:
+			return fsm.Proceed
+		}
+
+		return fsm.Stay
+	}
+}
+
+// thenShowMainMenu is a method that shows the main menu once a flow has completed, it returns
+// Proceed if the flow has completed and Stay otherwise
+func (handler *TelegramBotHandler) thenShowMainMenu(r *fsm.Request, completed bool) string {
+	if !completed {
+		return fsm.Stay
+	}
+
+	handler.HandleShowMainMenu(r.Update, r.User)
+	return fsm.Proceed
+}
+
+// proceedIf is a function that returns Proceed if the input has been accepted and Stay otherwise
+func proceedIf(accepted bool) string {
+	if accepted {
+		return fsm.Proceed
+	}
+	return fsm.Stay
+}
+
+// stayIfEmpty is a function that returns Stay in place of an empty next state
+func stayIfEmpty(next string) string {
+	if next == "" {
+		return fsm.Stay
+	}
+	return next
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"github.com/Benyam-S/asseri/client/bot"
 	"github.com/Benyam-S/asseri/client/bot/client"
+	"github.com/Benyam-S/asseri/client/bot/fsm"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/client/bot/tempuser"
 	"github.com/Benyam-S/asseri/common"
 	"github.com/Benyam-S/asseri/feedback"
 	"github.com/Benyam-S/asseri/job"
 	"github.com/Benyam-S/asseri/jobapplication"
 	"github.com/Benyam-S/asseri/log"
+	"github.com/Benyam-S/asseri/notifier"
 	"github.com/Benyam-S/asseri/subscription"
 	"github.com/Benyam-S/asseri/tools"
 	"github.com/Benyam-S/asseri/user"
@@ -23,27 +27,48 @@ type TelegramBotHandler struct {
 	sbService subscription.IService
 	fdService feedback.IService
 	cmService common.IService
+	tgClient  bot.ITelegramClient
+	clock     tools.IClock
 	logger    *log.Logger
 	store     tools.IStore
+	sessions  tools.ISessionStore
+	smsSender tools.ISMSSender
+	notifier  notifier.INotifier
 	pushChan  chan string
 	pq        common.IPushQueue
-}
+	limiter   *bot.DeliveryLimiter
 
-// BotResponse is a type that defines a bot response message
-type BotResponse struct {
-	Ok        bool  `json:"ok"`
-	ErrorCode int64 `json:"error_code"`
+	conversation *fsm.Machine
 }
 
 // NewTelegramBotHandler is a function that returns a new telegram bot handler
 func NewTelegramBotHandler(tempUserService tempuser.IService, clientService client.IService,
 	userService user.IService, jobService job.IService,
 	jobApplicationService jobapplication.IService, subscriptionService subscription.IService,
-	feedbackService feedback.IService, commonService common.IService, store tools.IStore,
-	pushChannel chan string, pushQueue common.IPushQueue, log *log.Logger) *TelegramBotHandler {
-	return &TelegramBotHandler{
+	feedbackService feedback.IService, commonService common.IService, telegramClient bot.ITelegramClient,
+	clock tools.IClock, store tools.IStore, sessionStore tools.ISessionStore, smsSender tools.ISMSSender,
+	smsNotifier, emailNotifier notifier.INotifier, pushChannel chan string, pushQueue common.IPushQueue, log *log.Logger) *TelegramBotHandler {
+	handler := &TelegramBotHandler{
 		tuService: tempUserService, clService: clientService, urService: userService,
 		jbService: jobService, jaService: jobApplicationService, sbService: subscriptionService,
-		fdService: feedbackService, cmService: commonService, pq: pushQueue, store: store,
-		pushChan: pushChannel, logger: log}
+		fdService: feedbackService, cmService: commonService, tgClient: telegramClient, clock: clock,
+		pq: pushQueue, store: store, sessions: sessionStore, smsSender: smsSender, pushChan: pushChannel, logger: log,
+		limiter: bot.NewDeliveryLimiter(bot.GlobalMessageRate, bot.ChatMessageRate, clock)}
+
+	// Users are notified on telegram first and through their preferred fallback channel if that fails
+	handler.notifier = notifier.NewFallbackNotifier(notifier.NewTelegramNotifier(clientService, handler),
+		smsNotifier, emailNotifier)
+
+	// An invalid transition table is a programming error so the bot shouldn't start with it
+	handler.conversation = handler.newConversation()
+	if err := handler.conversation.Validate(); err != nil {
+		panic(err)
+	}
+
+	// A message missing from one of the languages would be replied with its id so the bot shouldn't start with it
+	if err := locale.Validate(); err != nil {
+		panic(err)
+	}
+
+	return handler
 }
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// HandleUpdatePolling is a method that receives updates using getUpdates long polling instead of a webhook
+// and feeds each of them to HandleUpdate. The next update id is kept in the store so a restart
+// continues from the last processed update.
+func (handler *TelegramBotHandler) HandleUpdatePolling() {
+
+	ctx := context.Background()
+
+	// getUpdates can't be used while an outgoing webhook is set
+	if err := handler.tgClient.DeleteWebhook(ctx); err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to delete webhook, %s", err.Error()), entity.BotLogFile)
+	}
+
+	offset, _ := strconv.ParseInt(handler.store.Get(bot.UpdateOffsetKey), 10, 64)
+
+	for {
+
+		updates, err := handler.tgClient.GetUpdates(ctx,
+			&bot.GetUpdatesRequest{Offset: offset, Timeout: bot.PollingTimeout})
+
+		if err != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to get updates, %s", err.Error()), entity.BotLogFile)
+
+			wait := time.Second * 3
+			if apiErr, ok := err.(*bot.APIError); ok {
+				if apiErr.RetryAfter > 0 {
+					wait = time.Second * time.Duration(apiErr.RetryAfter)
+				}
+
+				// Conflict means a webhook has been set again since startup
+				if apiErr.ErrorCode == 409 {
+					handler.tgClient.DeleteWebhook(ctx)
+				}
+			}
+
+			time.Sleep(wait)
+			continue
+		}
+
+		for _, update := range updates {
+			handler.handlePolledUpdate(update)
+
+			// The offset never expires, otherwise a later restart would handle the held updates once more
+			offset = update.UpdateID + 1
+			handler.store.AddWithExpiry(bot.UpdateOffsetKey, strconv.FormatInt(offset, 10), 0)
+		}
+	}
+}
+
+// handlePolledUpdate is a method that handles a polled update while making sure a panic doesn't stop the poller
+func (handler *TelegramBotHandler) handlePolledUpdate(update *bot.Update) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to handle update %d, %v", update.UpdateID, r),
+				entity.BotLogFile)
+		}
+	}()
+
+	handler.HandleUpdate(update)
+}
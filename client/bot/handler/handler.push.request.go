@@ -1,8 +1,8 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
+	"html"
 	"net/http"
 	"os"
 	"regexp"
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
 	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/notifier"
 	"github.com/Benyam-S/asseri/tools"
 	"github.com/gorilla/mux"
 )
@@ -23,22 +25,51 @@ func (handler *TelegramBotHandler) HandleApprovalResult(w http.ResponseWriter, r
 
 	job, err := handler.jbService.FindJob(jobID)
 	if err != nil {
-		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
+		handler.WritePushResponse(w, &PushError{Code: PushCodeNotFound, Message: err.Error()})
 		return
 	}
 
+	handler.WritePushResponse(w, handler.NotifyEmployer(job))
+}
+
+// NotifyEmployer is a method that sends the current status of a job to its employer, the employer's fallback
+// channel is used when the telegram client of the employer can't be reached
+func (handler *TelegramBotHandler) NotifyEmployer(job *entity.Job) *PushError {
+
 	user, err := handler.urService.FindUser(job.Employer)
 	if err != nil {
 		// Since a job doesn't necessarily need to be owned by a user
-		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	return handler.ProcessJobResult(job, user)
+}
+
+// PublishJobStatus is a method that announces the current status of a reviewed or closed job, it notifies the employer
+// and an opened job is pushed to the channel and the subscribers while a closed job's channel post is updated
+func (handler *TelegramBotHandler) PublishJobStatus(job *entity.Job) {
+
+	if pushErr := handler.NotifyEmployer(job); pushErr != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to notify the employer of job %s, %s",
+			job.ID, pushErr.Error()), entity.BotLogFile)
+	}
+
+	if job.Status != entity.JobStatusOpened && job.Status != entity.JobStatusClosed {
 		return
 	}
 
-	client, err := handler.clService.FindClient(user.ID)
-	if err == nil {
-		handler.ProcessJobResult(job, user, client, w, r)
+	if pushErr := handler.PushNotificationToChannel(job); pushErr != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to post job %s to the channel, %s",
+			job.ID, pushErr.Error()), entity.BotLogFile)
+	}
+
+	if job.Status != entity.JobStatusOpened {
+		return
+	}
+
+	if pushErr := handler.PushNotificationToSubscribers(job); pushErr != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to push job %s to the subscribers, %s",
+			job.ID, pushErr.Error()), entity.BotLogFile)
 	}
 }
 
@@ -49,13 +80,11 @@ func (handler *TelegramBotHandler) HandlePushNotificationToChannel(w http.Respon
 
 	job, err := handler.jbService.FindJob(jobID)
 	if err != nil {
-		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
+		handler.WritePushResponse(w, &PushError{Code: PushCodeNotFound, Message: err.Error()})
 		return
 	}
 
-	handler.PushNotificationToChannel(job, w, r)
+	handler.WritePushResponse(w, handler.PushNotificationToChannel(job))
 }
 
 // HandlePushNotificationToSubscribers is a handler func that handles a request for pushing notification for subscribers
@@ -65,72 +94,61 @@ func (handler *TelegramBotHandler) HandlePushNotificationToSubscribers(w http.Re
 
 	job, err := handler.jbService.FindJob(jobID)
 	if err != nil {
-		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
+		handler.WritePushResponse(w, &PushError{Code: PushCodeNotFound, Message: err.Error()})
 		return
 	}
 
-	handler.PushNotificationToSubscribers(job, w, r)
-
+	handler.WritePushResponse(w, handler.PushNotificationToSubscribers(job))
 }
 
-// ProcessJobResult is a method that process a job and send the need reply to the telegram bot
-func (handler *TelegramBotHandler) ProcessJobResult(job *entity.Job, user *entity.User,
-	client *bot.Client, w http.ResponseWriter, r *http.Request) {
+// ProcessJobResult is a method that process a job and notifies the employer about the result
+func (handler *TelegramBotHandler) ProcessJobResult(job *entity.Job, user *entity.User) *PushError {
 
+	var status string
 	var statusString string
 	var postToChat string
+	var reason string
 
 	if job.Status == entity.JobStatusOpened {
-		statusString = "------------- <b>Approved</b> -------------\n\n"
+		status = "approved"
 	} else if job.Status == entity.JobStatusDecelined {
-		statusString = "------------- <b>Declined</b> -------------\n\n"
+		status = "declined"
 	} else if job.Status == entity.JobStatusClosed {
-		statusString = "------------- <b>Closed</b> -------------\n\n"
+		status = "closed"
 	} else {
-		output, _ := json.MarshalIndent(map[string]string{"error": "unable to perform operation"}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
-		return
+		return &PushError{Code: PushCodeInvalidState, Message: "job has not been reviewed yet"}
 	}
 
-	postToChat = fmt.Sprintf(
-		"%s"+"<b>Job Title</b>:  %s\n\n"+
-			"<b>Job Type</b>:  %s\n"+
-			"<b>Gender</b>:  %s\n"+
-			"<b>Education level</b>:  %s\n"+
-			"<b>Experience</b>:  %s\n"+
-			"<b>Contact Type</b>:  %s\n\n"+
-			"<b>Description</b>:  %s\n\n"+"#%s\n\n"+"%s",
-		statusString, job.Title, job.Type, bot.GetGender(job.Gender),
-		job.EducationLevel, job.Experience, job.ContactType, job.Description,
-		tools.ChangeSpaceToUnderscore(job.Sector), statusString)
-
-	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
-	value, err := bot.SendReplyToTelegramChat(chatID, postToChat)
-	if err != nil {
-		handler.logger.LogFileError(string(err.Error()), entity.BotLogFile)
-		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
-		return
+	statusString = locale.Text(user.Language, "job.banner."+status)
+
+	if job.Status == entity.JobStatusDecelined && job.ReviewNote != "" {
+		reason = locale.Text(user.Language, "job.result.reason", html.EscapeString(job.ReviewNote))
 	}
 
-	botResponse := new(BotResponse)
-	json.Unmarshal([]byte(value), botResponse)
+	postToChat = statusString +
+		locale.Text(user.Language, "job.detail", html.EscapeString(job.Title), job.Type,
+			bot.GetGender(job.Gender, user.Language), job.EducationLevel, job.Experience, job.ContactType,
+			html.EscapeString(job.Description), tools.ChangeSpaceToUnderscore(job.Sector)) +
+		"\n\n" + reason + statusString
 
-	if !botResponse.Ok && botResponse.ErrorCode == 429 {
-		output, _ := json.MarshalIndent(map[string]string{"error": "retry"}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
-		return
+	summary := locale.Text(user.Language, "job.result.summary."+status, job.Title)
+	if job.Status == entity.JobStatusDecelined && job.ReviewNote != "" {
+		summary += locale.Text(user.Language, "job.result.summary.reason", job.ReviewNote)
+	}
+
+	err := handler.notifier.Notify(user, &notifier.Notification{
+		Subject: locale.Text(user.Language, "job.result.subject."+status, job.Title),
+		Message: postToChat, Summary: summary})
+	if err == notifier.ErrUnreachable {
+		// The employer doesn't have any channel that can be notified
+		return nil
 	}
+
+	return handler.ToPushError(err)
 }
 
 // PushNotificationToChannel is a method that pushes job alert notifications to channel
-func (handler *TelegramBotHandler) PushNotificationToChannel(job *entity.Job,
-	w http.ResponseWriter, r *http.Request) {
+func (handler *TelegramBotHandler) PushNotificationToChannel(job *entity.Job) *PushError {
 
 	var contact string
 	var employer string
@@ -138,17 +156,14 @@ func (handler *TelegramBotHandler) PushNotificationToChannel(job *entity.Job,
 
 	if job.Status != entity.JobStatusOpened &&
 		job.Status != entity.JobStatusClosed {
-		return
+		return &PushError{Code: PushCodeInvalidState, Message: "only opened or closed jobs can be posted"}
 	}
 
 	if job.PostType == entity.PostCategoryUser {
 
 		user, err := handler.urService.FindUser(job.Employer)
 		if err != nil {
-			output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write(output)
-			return
+			return &PushError{Code: PushCodeNotFound, Message: "no user found for the job employer"}
 		}
 
 		employer = user.UserName
@@ -157,101 +172,96 @@ func (handler *TelegramBotHandler) PushNotificationToChannel(job *entity.Job,
 		// Means via telegram account
 		if job.ContactType == handler.cmService.GetValidContactTypes()[0] {
 
-			var chatID string
+			var telegramUserName string
 			if client != nil {
-				chatID = client.TelegramID
+				telegramUserName = handler.GetTelegramUserName(client.TelegramID)
 			}
-			var getChatURL string = os.Getenv("api_access_point") +
-				os.Getenv("bot_api_token") + "/getChat?chat_id=" + chatID
-
-			result, _ := http.Get(getChatURL)
-
-			type UserProfile struct {
-				UserName string `json:"username"`
-			}
-
-			chatResponse := &struct {
-				Result UserProfile `json:"result"`
-			}{}
 
-			json.NewDecoder(result.Body).Decode(chatResponse)
-			if chatResponse.Result.UserName != "" {
-				contact = "<b>Contact</b>: @" + chatResponse.Result.UserName + "\n\n"
+			if telegramUserName != "" {
+				contact = locale.Text(locale.DefaultLanguage, "job.field.contact", "@"+telegramUserName)
 			} else {
-				contact = "<b>Contact</b>: " + strings.ReplaceAll(user.PhoneNumber, "+251", "0") + "\n\n"
+				contact = locale.Text(locale.DefaultLanguage, "job.field.contact",
+					strings.ReplaceAll(user.PhoneNumber, "+251", "0"))
 			}
 
 		} else if job.ContactType == handler.cmService.GetValidContactTypes()[1] {
 			inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-				{Text: "🔗 Apply", URL: os.Getenv("bot_url") + "?start=" + "apply_" + job.ID},
+				{Text: locale.Text(locale.DefaultLanguage, "job.apply"),
+					URL: os.Getenv("bot_url") + "?start=" + "apply_" + job.ID},
 			})
 		}
 	} else if job.PostType == entity.PostCategoryInternal {
 		employer = job.Employer
-		contact = "<b>Contact</b>: " + job.ContactInfo + "\n\n"
+		contact = locale.Text(locale.DefaultLanguage, "job.field.contact", html.EscapeString(job.ContactInfo))
 
 	} else if job.PostType == entity.PostCategoryExternal {
 		employer = job.Employer
 		emptyLink, _ := regexp.MatchString(`^\s*$`, job.Link)
 		if !emptyLink {
+			// The channel is read in every language so the link is labeled in all of them
 			inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-				{Text: "Tap to view details / ዝርዝሩን ለማየት ይሄንን ይጫኑ", URL: job.Link},
+				{Text: locale.Join(" / ", "job.view_details"), URL: job.Link},
 			})
 		}
 
 	}
 
 	pack := &bot.StructuredPackage{Employer: employer, Contact: contact}
-	postToChannel := bot.BuildNotification(job, pack)
+	postToChannel := bot.BuildNotification(job, pack, locale.DefaultLanguage)
 
 	if job.Status == entity.JobStatusClosed {
-		postToChannel = "------------- <b>Closed</b> -------------\n\n" +
-			postToChannel +
-			"------------- <b>Closed</b> -------------\n\n"
+		banner := locale.Text(locale.DefaultLanguage, "job.banner.closed")
+		postToChannel = banner + postToChannel + banner
 		inlineKeyboard = ""
 	}
 
-	// Posting to telegram channel if opened
-	value, err := bot.PostToTelegramChannel(postToChannel, inlineKeyboard)
-	if err != nil {
-		handler.logger.LogFileError(err.Error(), entity.BotLogFile)
-		output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
-		return
+	// Editing the existing post keeps the channel free from duplicate open and closed posts
+	if job.ChannelMessageID != 0 {
+		_, err := handler.EditTelegramChannelPost(job.ChannelMessageID, postToChannel, inlineKeyboard)
+		apiErr, ok := err.(*bot.APIError)
+		if err == nil || !ok || !apiErr.IsPermanent() {
+			return handler.ToPushError(err)
+		}
+
+		// The post has been deleted or can't be edited anymore so a new one is posted instead
+		handler.logger.LogFileError(fmt.Sprintf("Unable to edit channel post %d of job %s, %s",
+			job.ChannelMessageID, job.ID, err.Error()), entity.BotLogFile)
 	}
 
-	botResponse := new(BotResponse)
-	json.Unmarshal([]byte(value), botResponse)
+	message, err := handler.PostToTelegramChannel(postToChannel, inlineKeyboard)
+	if err != nil {
+		return handler.ToPushError(err)
+	}
 
-	if !botResponse.Ok && botResponse.ErrorCode == 429 {
-		output, _ := json.MarshalIndent(map[string]string{"error": "retry"}, "", "\t")
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(output)
-		return
+	job.ChannelMessageID = message.MessageID
+	err = handler.jbService.UpdateJobSingleValue(job.ID, "channel_message_id", message.MessageID)
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to store channel post %d of job %s, %s",
+			message.MessageID, job.ID, err.Error()), entity.BotLogFile)
 	}
+
+	return nil
 }
 
-// PushNotificationToSubscribers is a method that pushes job alert notifications to subscribers
-func (handler *TelegramBotHandler) PushNotificationToSubscribers(job *entity.Job,
-	w http.ResponseWriter, r *http.Request) {
+// PushNotificationToSubscribers is a method that pushes job alert notifications to subscribers,
+// each subscriber gets the notification in their own language
+func (handler *TelegramBotHandler) PushNotificationToSubscribers(job *entity.Job) *PushError {
 
 	var contact string
 	var employer string
-	var inlineKeyboard string
+	var labelContact bool
+	var applyButton bool
+	var detailLink bool
 
 	if job.Status != entity.JobStatusOpened {
-		return
+		return &PushError{Code: PushCodeInvalidState, Message: "only opened jobs can be pushed to subscribers"}
 	}
 
 	if job.PostType == entity.PostCategoryUser {
 
 		user, err := handler.urService.FindUser(job.Employer)
 		if err != nil {
-			output, _ := json.MarshalIndent(map[string]string{"error": err.Error()}, "", "\t")
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write(output)
-			return
+			return &PushError{Code: PushCodeNotFound, Message: "no user found for the job employer"}
 		}
 
 		employer = user.UserName
@@ -260,116 +270,161 @@ func (handler *TelegramBotHandler) PushNotificationToSubscribers(job *entity.Job
 		// Means via telegram account
 		if job.ContactType == handler.cmService.GetValidContactTypes()[0] {
 
-			var chatID string
+			var telegramUserName string
 			if client != nil {
-				chatID = client.TelegramID
-			}
-			var getChatURL string = os.Getenv("api_access_point") +
-				os.Getenv("bot_api_token") + "/getChat?chat_id=" + chatID
-
-			result, _ := http.Get(getChatURL)
-
-			type UserProfile struct {
-				UserName string `json:"username"`
+				telegramUserName = handler.GetTelegramUserName(client.TelegramID)
 			}
 
-			chatResponse := &struct {
-				Result UserProfile `json:"result"`
-			}{}
-
-			json.NewDecoder(result.Body).Decode(chatResponse)
-			if chatResponse.Result.UserName != "" {
-				contact = "@" + chatResponse.Result.UserName + "\n\n"
+			if telegramUserName != "" {
+				contact = "@" + telegramUserName
 			} else {
-				contact = "<b>Contact</b>: " + strings.ReplaceAll(user.PhoneNumber, "+251", "0") + "\n\n"
+				contact = strings.ReplaceAll(user.PhoneNumber, "+251", "0")
+				labelContact = true
 			}
 
 		} else if job.ContactType == handler.cmService.GetValidContactTypes()[1] {
-			inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-				{Text: "🔗 Apply", URL: os.Getenv("bot_url") + "?start=" + "apply_" + job.ID},
-			})
+			applyButton = true
 		}
 	} else if job.PostType == entity.PostCategoryInternal {
 		employer = job.Employer
-		contact = "<b>Contact</b>: " + job.ContactInfo + "\n\n"
+		contact = html.EscapeString(job.ContactInfo)
+		labelContact = true
 
 	} else if job.PostType == entity.PostCategoryExternal {
 		employer = job.Employer
 		emptyLink, _ := regexp.MatchString(`^\s*$`, job.Link)
-		if !emptyLink {
+		detailLink = !emptyLink
+	}
+
+	// posts holds the post and its inline keyboard built for each language of the subscribers
+	posts := make(map[string][2]string)
+	buildPost := func(language string) (string, string) {
+
+		if post, ok := posts[language]; ok {
+			return post[0], post[1]
+		}
+
+		var inlineKeyboard string
+		pack := &bot.StructuredPackage{Employer: employer}
+
+		if labelContact {
+			pack.Contact = locale.Text(language, "job.field.contact", contact)
+		} else if contact != "" {
+			pack.Contact = contact + "\n\n"
+		}
+
+		if applyButton {
 			inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
-				{Text: "Tap to view details / ዝርዝሩን ለማየት ይሄንን ይጫኑ", URL: job.Link},
+				{Text: locale.Text(language, "job.apply"), URL: os.Getenv("bot_url") + "?start=" + "apply_" + job.ID},
+			})
+		} else if detailLink {
+			inlineKeyboard = bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
+				{Text: locale.Text(language, "job.view_details"), URL: job.Link},
 			})
 		}
 
+		post := locale.Text(language, "job.banner.subscription") + bot.BuildNotification(job, pack, language)
+		posts[language] = [2]string{post, inlineKeyboard}
+		return post, inlineKeyboard
 	}
 
-	pack := &bot.StructuredPackage{Employer: employer, Contact: contact}
-	postToSubscribers := "------------- <b>Subscription</b> -------------\n\n" +
-		bot.BuildNotification(job, pack)
-
 	subscribers := handler.sbService.FindSubscriptionMatch(job.Sector, job.Type, job.EducationLevel, job.Experience)
 	for _, subscriber := range subscribers {
 		subscriberClient, err := handler.clService.FindClient(subscriber.UserID)
-		if err != nil || job.Employer == subscriber.UserID {
+		if err != nil || job.Employer == subscriber.UserID || subscriberClient.Blocked {
 			continue
 		}
 
+		language := locale.DefaultLanguage
+		if subscriberUser, err := handler.urService.FindUser(subscriber.UserID); err == nil {
+			language = subscriberUser.Language
+		}
+
 		chatID, _ := strconv.ParseInt(subscriberClient.TelegramID, 10, 64)
+		postToSubscriber, inlineKeyboard := buildPost(language)
 
 		newRequest := new(entity.ChannelRequest)
 		newRequest.ChatID = chatID
-		newRequest.Value = postToSubscribers
+		newRequest.Value = postToSubscriber
 		newRequest.Extra = inlineKeyboard
 
-		handler.pq.AddToQueue(newRequest)
+		err = handler.pq.AddToQueue(newRequest)
+		if err != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to queue push request for %d, %s",
+				chatID, err.Error()), entity.BotLogFile)
+		}
 	}
 
-	handler.pushChan <- entity.StartPush
+	// The worker also checks the queue periodically so a signal can be dropped if one is already waiting
+	select {
+	case handler.pushChan <- entity.StartPush:
+	default:
+	}
+	return nil
 }
 
-// HandlePushRequest is a method that handles push notification sending process to bot handler
+// HandlePushRequest is a method that handles push notification sending process to bot handler.
+// Besides every push signal, the queue is checked periodically so requests that were pending before a restart
+// or released for a later retry are also sent.
 func (handler *TelegramBotHandler) HandlePushRequest() {
 
-	for range handler.pushChan {
-
-		for {
+	ticker := time.NewTicker(time.Second * 30)
+	defer ticker.Stop()
 
-			i := 0
-			requestCount := 0
+	for {
 
-			if len(handler.pq.GetQueue()) == 0 {
-				break
-			}
+		handler.processPushQueue()
 
-			for {
-
-				if i >= len(handler.pq.GetQueue()) {
-					break
-				}
+		select {
+		case <-handler.pushChan:
+		case <-ticker.C:
+		}
+	}
+}
 
-				request := handler.pq.GetQueue()[i]
+// processPushQueue is a method that sends every request that can be claimed from the push queue
+// while staying within the global and per chat message limits
+func (handler *TelegramBotHandler) processPushQueue() {
 
-				if requestCount > 15 {
-					time.Sleep(time.Second * 15)
-					requestCount = 0
-				}
+	for {
 
-				value, _ := bot.SendReplyToTelegramChat(request.ChatID, request.Value, request.Extra)
+		queued, err := handler.pq.Claim()
+		if err != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to claim push request, %s", err.Error()),
+				entity.BotLogFile)
+			return
+		}
 
-				botResponse := new(BotResponse)
-				json.Unmarshal([]byte(value), botResponse)
+		if queued == nil {
+			return
+		}
 
-				if !botResponse.Ok && botResponse.ErrorCode == 429 {
-					i++
-					requestCount++
-					continue
-				}
+		request := queued.Request
+		handler.limiter.Wait(request.ChatID)
 
-				handler.pq.RemoveFromQueueWithIndex(i)
-				requestCount++
-			}
+		_, err = handler.SendReplyToTelegramChat(request.ChatID, request.Value, request.Extra)
+		if err == nil {
+			handler.pq.Ack(queued.ID)
+			continue
 		}
 
+		apiErr, ok := err.(*bot.APIError)
+		switch {
+		case ok && apiErr.IsRateLimited():
+			// Telegram applies retry_after to the whole bot so every delivery waits, not only this one
+			retryAfter := time.Second * time.Duration(apiErr.RetryAfter)
+			handler.limiter.Pause(retryAfter)
+			handler.pq.Postpone(queued.ID, retryAfter)
+
+		case ok && apiErr.IsPermanent():
+			handler.logger.LogFileError(fmt.Sprintf("Dropped push request for %d, %s",
+				request.ChatID, err.Error()), entity.BotLogFile)
+			handler.pq.Ack(queued.ID)
+
+		default:
+			handler.logger.LogFileError(fmt.Sprintf("Unable to send push request for %d, attempt %d, %s",
+				request.ChatID, queued.Attempts, err.Error()), entity.BotLogFile)
+			handler.pq.Release(queued.ID, time.Second*time.Duration(30*queued.Attempts))
+		}
 	}
 }
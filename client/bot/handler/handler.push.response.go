@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// PushCodeOK is a constant that defines the code of a push request that has been handled
+const PushCodeOK = "ok"
+
+// PushCodeUnauthorized is a constant that defines the code of a push request that isn't signed properly
+const PushCodeUnauthorized = "unauthorized"
+
+// PushCodeNotFound is a constant that defines the code of a push request that targets a job or user that doesn't exist
+const PushCodeNotFound = "not_found"
+
+// PushCodeInvalidState is a constant that defines the code of a push request for a job that can't be pushed in its current status
+const PushCodeInvalidState = "invalid_state"
+
+// PushCodeRateLimited is a constant that defines the code of a push request that has been throttled by Telegram
+const PushCodeRateLimited = "rate_limited"
+
+// PushCodeError is a constant that defines the code of a push request that has failed for any other reason
+const PushCodeError = "error"
+
+// PushError is a type that defines the reason a push request couldn't be completed
+type PushError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message,omitempty"`
+	RetryAfter int64  `json:"retry_after,omitempty"`
+}
+
+// Error is a method that returns the push error message
+func (pushError *PushError) Error() string {
+	return pushError.Code + ": " + pushError.Message
+}
+
+// StatusCode is a method that returns the http status code that corresponds to the push error code
+func (pushError *PushError) StatusCode() int {
+	switch pushError.Code {
+	case PushCodeOK:
+		return http.StatusOK
+	case PushCodeUnauthorized:
+		return http.StatusUnauthorized
+	case PushCodeNotFound:
+		return http.StatusNotFound
+	case PushCodeInvalidState:
+		return http.StatusConflict
+	case PushCodeRateLimited:
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ToPushError is a method that converts an error returned by the Telegram client to a push error
+func (handler *TelegramBotHandler) ToPushError(err error) *PushError {
+
+	if err == nil {
+		return nil
+	}
+
+	if apiErr, ok := err.(*bot.APIError); ok && apiErr.IsRateLimited() {
+		return &PushError{Code: PushCodeRateLimited, Message: apiErr.Description, RetryAfter: apiErr.RetryAfter}
+	}
+
+	handler.logger.LogFileError(err.Error(), entity.BotLogFile)
+	return &PushError{Code: PushCodeError, Message: err.Error()}
+}
+
+// WritePushResponse is a method that writes the result of a push request as a structured json response.
+// A nil push error is written as a successful response.
+func (handler *TelegramBotHandler) WritePushResponse(w http.ResponseWriter, pushError *PushError) {
+
+	if pushError == nil {
+		pushError = &PushError{Code: PushCodeOK}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if pushError.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(pushError.RetryAfter, 10))
+	}
+
+	output, _ := json.MarshalIndent(pushError, "", "\t")
+	w.WriteHeader(pushError.StatusCode())
+	w.Write(output)
+}
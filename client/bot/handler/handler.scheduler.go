@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// HandleDueJobs is a method that periodically closes the opened jobs that have passed their due date
+// and reminds the employers of the jobs that are about to
+func (handler *TelegramBotHandler) HandleDueJobs() {
+
+	ticker := time.NewTicker(bot.DueJobsCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		handler.CloseDueJobs()
+		handler.SendDueDateReminders()
+		<-ticker.C
+	}
+}
+
+// CloseDueJobs is a method that closes every opened job whose due date has passed according to the handler's clock.
+// The employer of each closed job is notified and its channel post is marked as closed.
+func (handler *TelegramBotHandler) CloseDueJobs() []*entity.Job {
+
+	closedJobs := handler.jbService.CloseDueJobs(handler.clock.Now(), entity.JobStatusOpened)
+
+	for _, job := range closedJobs {
+
+		// The returned jobs hold the status they had before being closed
+		job.Status = entity.JobStatusClosed
+
+		if pushErr := handler.NotifyEmployer(job); pushErr != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to notify the employer of due job %s, %s",
+				job.ID, pushErr.Error()), entity.BotLogFile)
+		}
+
+		if pushErr := handler.PushNotificationToChannel(job); pushErr != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to update the channel post of due job %s, %s",
+				job.ID, pushErr.Error()), entity.BotLogFile)
+		}
+	}
+
+	return closedJobs
+}
+
+// SendDueDateReminders is a method that reminds the employer of every opened job that will reach its due date
+// within the configured reminder hours, a job is reminded once for each due date it has
+func (handler *TelegramBotHandler) SendDueDateReminders() {
+
+	reminderHours, err := strconv.ParseInt(os.Getenv("due_date_reminder_hours"), 10, 64)
+	if err != nil || reminderHours <= 0 {
+		reminderHours = bot.DefaultDueDateReminderHours
+	}
+
+	now := handler.clock.Now()
+	window := time.Hour * time.Duration(reminderHours)
+
+	for _, job := range handler.jbService.FindDueJobs(now.Add(window), entity.JobStatusOpened) {
+
+		// A reminder sent before the window of the current due date belongs to a due date that has been extended
+		remindFrom := job.DueDate.Add(-window)
+		if job.RemindedAt != nil && job.RemindedAt.After(remindFrom) {
+			continue
+		}
+
+		err := handler.SendDueDateReminder(job)
+		if err != nil {
+			handler.logger.LogFileError(fmt.Sprintf("Unable to send due date reminder of job %s, %s",
+				job.ID, err.Error()), entity.BotLogFile)
+
+			// Telegram is rejecting the message so retrying on every check is pointless
+			if apiErr, ok := err.(*bot.APIError); !ok || !apiErr.IsPermanent() {
+				continue
+			}
+		}
+
+		handler.jbService.UpdateJobSingleValue(job.ID, "reminded_at", now)
+	}
+}
+
+// SendDueDateReminder is a method that sends a due date reminder with the job's application count to its employer
+func (handler *TelegramBotHandler) SendDueDateReminder(job *entity.Job) error {
+
+	user, err := handler.urService.FindUser(job.Employer)
+	if err != nil {
+		// Jobs that aren't owned by a user have no one to remind
+		return nil
+	}
+
+	client, err := handler.clService.FindClient(user.ID)
+	if err != nil || client.Blocked {
+		return nil
+	}
+
+	applications := len(handler.jaService.FindJobApplications(job.ID))
+
+	reminder := locale.Text(user.Language, "reminder.due_date", html.EscapeString(job.Title), job.Type,
+		job.DueDate.Format(bot.DueDateLayout), applications)
+
+	inlineKeyboard := bot.CreateInlineKeyboard([]bot.InlineKeyboardButton{
+		{Text: locale.Text(user.Language, "reminder.extend"), CallbackData: "reminder/extend/" + job.ID},
+		{Text: locale.Text(user.Language, "reminder.close"), CallbackData: "reminder/close/" + job.ID},
+	})
+
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	_, err = handler.SendReplyToTelegramChat(chatID, reminder, inlineKeyboard)
+	return err
+}
@@ -0,0 +1,256 @@
+package handler_test
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/faketelegram"
+	"github.com/Benyam-S/asseri/client/bot/locale"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+const (
+	employerID  = "UR-1"
+	employerTID = 1001
+	channelName = "@asseri_test_channel"
+)
+
+// newSchedulerBot is a function that returns a fake bot with a registered employer
+func newSchedulerBot(t *testing.T) *faketelegram.Bot {
+
+	os.Setenv("channel_name", channelName)
+	os.Unsetenv("due_date_reminder_hours")
+
+	telegram := faketelegram.NewServer()
+	t.Cleanup(telegram.Close)
+
+	fakeBot := faketelegram.NewBot(telegram)
+	fakeBot.DB.Insert("users", &entity.User{ID: employerID, UserName: "Abebe Kebede", PhoneNumber: "+251911000001",
+		Category: entity.UserCategoryasseri, Language: locale.DefaultLanguage})
+	fakeBot.DB.Insert("bot_clients", &bot.Client{UserID: employerID, TelegramID: strconv.Itoa(employerTID)})
+
+	return fakeBot
+}
+
+// addJob is a function that adds a job of the registered employer with the given status and due date
+func addJob(fakeBot *faketelegram.Bot, jobID, status string, dueDate *time.Time) {
+	fakeBot.DB.Insert("jobs", &entity.Job{ID: jobID, Employer: employerID, Title: "Job " + jobID, Description: "Details",
+		Type: "Full Time", Sector: "Accounting", EducationLevel: "Degree", Experience: entity.ValidWorkExperiences[0],
+		Gender: "B", ContactType: entity.ValidContactTypes[0], PostType: entity.PostCategoryUser, Status: status,
+		DueDate: dueDate})
+}
+
+// at is a function that returns a pointer to the given time
+func at(t time.Time) *time.Time {
+	return &t
+}
+
+// jobIDs is a function that returns the ids of the given jobs
+func jobIDs(jobs []*entity.Job) []string {
+	ids := make([]string, 0)
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+// callsTo is a function that returns the calls made to the given chat
+func callsTo(calls []*faketelegram.Call, chatID string) []*faketelegram.Call {
+	chatCalls := make([]*faketelegram.Call, 0)
+	for _, call := range calls {
+		if call.ChatID() == chatID {
+			chatCalls = append(chatCalls, call)
+		}
+	}
+	return chatCalls
+}
+
+func TestCloseDueJobs(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	now := fakeBot.Clock.Now()
+
+	addJob(fakeBot, "JB-1", entity.JobStatusOpened, at(now.Add(-time.Minute)))
+	addJob(fakeBot, "JB-2", entity.JobStatusOpened, at(now.Add(time.Hour)))
+	addJob(fakeBot, "JB-3", entity.JobStatusPending, at(now.Add(-time.Hour)))
+	addJob(fakeBot, "JB-4", entity.JobStatusOpened, nil)
+
+	closed := fakeBot.Handler.CloseDueJobs()
+	if ids := jobIDs(closed); len(ids) != 1 || ids[0] != "JB-1" {
+		t.Fatalf("expected only JB-1 to be closed, got %v", ids)
+	}
+
+	if closed[0].Status != entity.JobStatusClosed {
+		t.Errorf("expected the returned job to be closed, got %s", closed[0].Status)
+	}
+
+	for jobID, status := range map[string]string{"JB-1": entity.JobStatusClosed, "JB-2": entity.JobStatusOpened,
+		"JB-3": entity.JobStatusPending, "JB-4": entity.JobStatusOpened} {
+		if job := fakeBot.Job(jobID); job.Status != status {
+			t.Errorf("expected %s to be %s, got %s", jobID, status, job.Status)
+		}
+	}
+
+	calls := fakeBot.Telegram.Calls()
+	if len(callsTo(calls, strconv.Itoa(employerTID))) == 0 {
+		t.Errorf("expected the employer to be notified about the closed job")
+	}
+
+	if len(callsTo(calls, channelName)) == 0 || fakeBot.Job("JB-1").ChannelMessageID == 0 {
+		t.Errorf("expected the channel post of the closed job to be updated")
+	}
+
+	// Nothing else is due until the clock passes the next due date
+	if closed := fakeBot.Handler.CloseDueJobs(); len(closed) != 0 {
+		t.Errorf("expected no job to be closed, got %v", jobIDs(closed))
+	}
+
+	fakeBot.Clock.Advance(time.Hour)
+	if ids := jobIDs(fakeBot.Handler.CloseDueJobs()); len(ids) != 1 || ids[0] != "JB-2" {
+		t.Errorf("expected only JB-2 to be closed, got %v", ids)
+	}
+}
+
+func TestCloseJob(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	addJob(fakeBot, "JB-1", entity.JobStatusOpened, nil)
+	addJob(fakeBot, "JB-2", entity.JobStatusPending, nil)
+
+	// Closing the same job from several taps at once only closes it once
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = fakeBot.Handler.CloseJob("JB-1", locale.DefaultLanguage)
+		}(i)
+	}
+	wg.Wait()
+
+	closed := 0
+	for _, err := range errs {
+		if err == nil {
+			closed++
+		}
+	}
+
+	if closed != 1 || fakeBot.Job("JB-1").Status != entity.JobStatusClosed {
+		t.Errorf("expected JB-1 to be closed once, got %d", closed)
+	}
+
+	if _, err := fakeBot.Handler.CloseJob("JB-2", locale.DefaultLanguage); err == nil ||
+		fakeBot.Job("JB-2").Status != entity.JobStatusPending {
+		t.Errorf("expected a pending job not to be closed")
+	}
+}
+
+func TestExtendJobDueDate(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+
+	// The clock is far behind the system time so validating with the system time would reject every due date
+	now := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.Local)
+	fakeBot.Clock.Set(now)
+
+	addJob(fakeBot, "JB-1", entity.JobStatusOpened, at(now.Add(time.Hour)))
+	addJob(fakeBot, "JB-2", entity.JobStatusOpened, at(now.Add(-time.Hour)))
+	addJob(fakeBot, "JB-3", entity.JobStatusClosed, at(now.Add(time.Hour)))
+
+	_, err := fakeBot.Handler.ExtendJobDueDate(fakeBot.Job("JB-1"), bot.DueDateExtension, locale.DefaultLanguage)
+	if err != nil {
+		t.Fatalf("expected the due date to be extended, got %s", err)
+	}
+
+	if dueDate := fakeBot.Job("JB-1").DueDate; dueDate == nil || !dueDate.Equal(now.Add(time.Hour+bot.DueDateExtension)) {
+		t.Errorf("expected the due date to move from the current due date, got %v", dueDate)
+	}
+
+	// A passed due date is extended from the current time
+	_, err = fakeBot.Handler.ExtendJobDueDate(fakeBot.Job("JB-2"), bot.DueDateExtension, locale.DefaultLanguage)
+	if dueDate := fakeBot.Job("JB-2").DueDate; err != nil || !dueDate.Equal(now.Add(bot.DueDateExtension)) {
+		t.Errorf("expected the due date to move from the current time, got %v, %v", dueDate, err)
+	}
+
+	// The extended due date has to exceed the current time by 3 hours like a new one
+	if _, err := fakeBot.Handler.ExtendJobDueDate(fakeBot.Job("JB-2"), -bot.DueDateExtension,
+		locale.DefaultLanguage); err == nil {
+		t.Errorf("expected a due date that doesn't exceed the current time to be rejected")
+	}
+
+	if _, err := fakeBot.Handler.ExtendJobDueDate(fakeBot.Job("JB-3"), bot.DueDateExtension,
+		locale.DefaultLanguage); err == nil {
+		t.Errorf("expected a closed job not to be extended")
+	}
+}
+
+// reminders is a function that returns the due date reminders found in the given calls
+func reminders(calls []*faketelegram.Call) []*faketelegram.Call {
+	reminderCalls := make([]*faketelegram.Call, 0)
+	for _, call := range callsTo(calls, strconv.Itoa(employerTID)) {
+		if call.Method == "sendMessage" && strings.Contains(call.ReplyMarkup(), "reminder/extend/") {
+			reminderCalls = append(reminderCalls, call)
+		}
+	}
+	return reminderCalls
+}
+
+func TestSendDueDateReminders(t *testing.T) {
+	fakeBot := newSchedulerBot(t)
+	now := fakeBot.Clock.Now()
+
+	addJob(fakeBot, "JB-1", entity.JobStatusOpened, at(now.Add(time.Hour*10)))
+	addJob(fakeBot, "JB-2", entity.JobStatusOpened, at(now.Add(time.Hour*48)))
+	addJob(fakeBot, "JB-3", entity.JobStatusPending, at(now.Add(time.Hour*10)))
+
+	fakeBot.Handler.SendDueDateReminders()
+
+	sent := reminders(fakeBot.Telegram.Calls())
+	if len(sent) != 1 || !strings.Contains(sent[0].ReplyMarkup(), "reminder/extend/JB-1") {
+		t.Fatalf("expected a single reminder for JB-1, got %d", len(sent))
+	}
+
+	if remindedAt := fakeBot.Job("JB-1").RemindedAt; remindedAt == nil || !remindedAt.Equal(now) {
+		t.Errorf("expected JB-1 to be marked as reminded at %v, got %v", now, remindedAt)
+	}
+
+	// A job is reminded only once for the same due date, however often the scheduler runs
+	for i := 0; i < 3; i++ {
+		fakeBot.Clock.Advance(time.Hour)
+		fakeBot.Handler.SendDueDateReminders()
+	}
+
+	if sent := reminders(fakeBot.Telegram.Calls()); len(sent) != 1 {
+		t.Fatalf("expected the reminder not to be sent again, got %d reminders", len(sent))
+	}
+
+	// An extended due date gets its own reminder once the clock reaches its window
+	_, err := fakeBot.Handler.ExtendJobDueDate(fakeBot.Job("JB-1"), bot.DueDateExtension, locale.DefaultLanguage)
+	if err != nil {
+		t.Fatalf("unable to extend JB-1, %s", err)
+	}
+
+	fakeBot.Handler.SendDueDateReminders()
+	if sent := reminders(fakeBot.Telegram.Calls()); len(sent) != 1 {
+		t.Fatalf("expected no reminder before the window of the extended due date, got %d reminders", len(sent))
+	}
+
+	fakeBot.Telegram.Reset()
+	fakeBot.Clock.Set(fakeBot.Job("JB-1").DueDate.Add(-time.Hour * 2))
+	fakeBot.Handler.SendDueDateReminders()
+	fakeBot.Handler.SendDueDateReminders()
+
+	extended := 0
+	for _, call := range reminders(fakeBot.Telegram.Calls()) {
+		if strings.Contains(call.ReplyMarkup(), "reminder/extend/JB-1") {
+			extended++
+		}
+	}
+
+	if extended != 1 {
+		t.Errorf("expected a single reminder for the extended due date of JB-1, got %d", extended)
+	}
+}
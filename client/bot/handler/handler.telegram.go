@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/Benyam-S/asseri/client/bot"
+)
+
+// SendReplyToTelegramChat is a method that sends a reply to the Telegram chat identified by its chat Id
+// [0] - text, [1] - reply markup
+func (handler *TelegramBotHandler) SendReplyToTelegramChat(chatID int64, reply ...string) (*bot.Message, error) {
+
+	request := &bot.SendMessageRequest{ChatID: strconv.FormatInt(chatID, 10), ParseMode: "html"}
+
+	if len(reply) > 0 {
+		request.Text = reply[0]
+	}
+
+	if len(reply) > 1 {
+		request.ReplyMarkup = reply[1]
+	}
+
+	message, err := handler.tgClient.SendMessage(context.Background(), request)
+	handler.checkBlocked(chatID, err)
+	return message, err
+}
+
+// SendDocumentToTelegramChat is a method that sends a document to the Telegram chat identified by its chat Id
+// [0] - caption, [1] - reply markup
+func (handler *TelegramBotHandler) SendDocumentToTelegramChat(chatID int64, fileID string,
+	reply ...string) (*bot.Message, error) {
+
+	request := &bot.SendDocumentRequest{ChatID: strconv.FormatInt(chatID, 10), Document: fileID,
+		ParseMode: "html"}
+
+	if len(reply) > 0 {
+		request.Caption = reply[0]
+	}
+
+	if len(reply) > 1 {
+		request.ReplyMarkup = reply[1]
+	}
+
+	message, err := handler.tgClient.SendDocument(context.Background(), request)
+	handler.checkBlocked(chatID, err)
+	return message, err
+}
+
+// UploadDocumentToTelegramChat is a method that uploads a file as a document to the Telegram chat identified by its chat Id
+// [0] - caption, [1] - reply markup
+func (handler *TelegramBotHandler) UploadDocumentToTelegramChat(chatID int64, fileName string, content []byte,
+	reply ...string) (*bot.Message, error) {
+
+	request := &bot.UploadDocumentRequest{ChatID: strconv.FormatInt(chatID, 10), FileName: fileName,
+		Content: content, ParseMode: "html"}
+
+	if len(reply) > 0 {
+		request.Caption = reply[0]
+	}
+
+	if len(reply) > 1 {
+		request.ReplyMarkup = reply[1]
+	}
+
+	message, err := handler.tgClient.UploadDocument(context.Background(), request)
+	handler.checkBlocked(chatID, err)
+	return message, err
+}
+
+// PostToTelegramChannel is a method that posts a certain content to the bot's telegram channel
+// [0] - text, [1] - reply markup
+func (handler *TelegramBotHandler) PostToTelegramChannel(post ...string) (*bot.Message, error) {
+
+	request := &bot.SendMessageRequest{ChatID: os.Getenv("channel_name"), ParseMode: "html"}
+
+	if len(post) > 0 {
+		request.Text = post[0]
+	}
+
+	if len(post) > 1 {
+		request.ReplyMarkup = post[1]
+	}
+
+	return handler.tgClient.SendMessage(context.Background(), request)
+}
+
+// EditTelegramReplyMarkup is a method that replaces the inline keyboard of a message sent to a Telegram chat
+func (handler *TelegramBotHandler) EditTelegramReplyMarkup(chatID, messageID int64,
+	replyMarkup string) (*bot.Message, error) {
+	return handler.tgClient.EditMessageReplyMarkup(context.Background(),
+		&bot.EditMessageReplyMarkupRequest{ChatID: strconv.FormatInt(chatID, 10), MessageID: messageID,
+			ReplyMarkup: replyMarkup})
+}
+
+// EditTelegramMessage is a method that replaces the content of a message sent to a Telegram chat
+// [0] - text, [1] - reply markup
+func (handler *TelegramBotHandler) EditTelegramMessage(chatID, messageID int64, reply ...string) (*bot.Message, error) {
+
+	request := &bot.EditMessageTextRequest{ChatID: strconv.FormatInt(chatID, 10), MessageID: messageID,
+		ParseMode: "html"}
+
+	if len(reply) > 0 {
+		request.Text = reply[0]
+	}
+
+	if len(reply) > 1 {
+		request.ReplyMarkup = reply[1]
+	}
+
+	return handler.tgClient.EditMessageText(context.Background(), request)
+}
+
+// EditTelegramChannelPost is a method that replaces the content of a post on the bot's telegram channel.
+// If the post's text is the same only its reply markup is replaced.
+// [0] - text, [1] - reply markup
+func (handler *TelegramBotHandler) EditTelegramChannelPost(messageID int64, post ...string) (*bot.Message, error) {
+
+	request := &bot.EditMessageTextRequest{ChatID: os.Getenv("channel_name"), MessageID: messageID,
+		ParseMode: "html"}
+
+	if len(post) > 0 {
+		request.Text = post[0]
+	}
+
+	if len(post) > 1 {
+		request.ReplyMarkup = post[1]
+	}
+
+	message, err := handler.tgClient.EditMessageText(context.Background(), request)
+	if apiErr, ok := err.(*bot.APIError); ok && apiErr.IsNotModified() {
+		message, err = handler.tgClient.EditMessageReplyMarkup(context.Background(),
+			&bot.EditMessageReplyMarkupRequest{ChatID: request.ChatID, MessageID: messageID,
+				ReplyMarkup: request.ReplyMarkup})
+
+		// Nothing has changed at all
+		if apiErr, ok := err.(*bot.APIError); ok && apiErr.IsNotModified() {
+			return &bot.Message{MessageID: messageID}, nil
+		}
+	}
+
+	return message, err
+}
+
+// AnswerToTelegramCallBack is a method that sends a reply to the Telegram call back request identified by the query id
+func (handler *TelegramBotHandler) AnswerToTelegramCallBack(queryID string, text string) error {
+	return handler.tgClient.AnswerCallbackQuery(context.Background(),
+		&bot.AnswerCallbackQueryRequest{CallbackQueryID: queryID, Text: text})
+}
+
+// GetTelegramUserName is a method that returns the public Telegram username of the given chat if it has one
+func (handler *TelegramBotHandler) GetTelegramUserName(chatID string) string {
+
+	chat, err := handler.tgClient.GetChat(context.Background(), chatID)
+	if err != nil {
+		return ""
+	}
+
+	return chat.UserName
+}
+
+// checkBlocked is a method that marks the client of the given chat as blocked if Telegram has refused
+// to deliver a message to it because the user has blocked the bot
+func (handler *TelegramBotHandler) checkBlocked(chatID int64, err error) {
+	if apiErr, ok := err.(*bot.APIError); ok && apiErr.ErrorCode == 403 {
+		handler.clService.MarkClientBlocked(strconv.FormatInt(chatID, 10))
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/Benyam-S/asseri/client/bot"
 	"github.com/Benyam-S/asseri/entity"
@@ -19,6 +20,24 @@ func (handler *TelegramBotHandler) HandleWebHook(w http.ResponseWriter, r *http.
 		return
 	}
 
+	handler.HandleUpdate(update)
+}
+
+// HandleUpdate is a method that handles a single Telegram update regardless of how it has been received
+func (handler *TelegramBotHandler) HandleUpdate(update *bot.Update) {
+
+	// The bot's own membership has changed, the user has either blocked or unblocked the bot
+	if update.MyChatMember.Chat.ID != 0 {
+		handler.HandleMyChatMember(update)
+		return
+	}
+
+	// Updates from the moderators' chat are never part of a client conversation
+	if handler.IsModerationUpdate(update) {
+		handler.HandleModerationUpdate(update)
+		return
+	}
+
 	telegramID := strconv.FormatInt(update.Message.User.ID, 10)
 
 	// This is used for call back query response so as to identify the user
@@ -45,7 +64,26 @@ func (handler *TelegramBotHandler) HandleWebHook(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Sending /start again means the user can receive messages from the bot again
+	if client.Blocked && strings.HasPrefix(update.Message.Text, "/start") {
+		handler.clService.MarkClientActive(client.UserID)
+		client.Blocked = false
+	}
+
 	// Error checking is not needed here since if the user is deleted the client is automatically deleted too
 	user, _ := handler.urService.FindUser(client.UserID)
 	handler.HandleClient(update, user)
 }
+
+// HandleMyChatMember is a method that updates the blocked state of a client when the user blocks or unblocks the bot
+func (handler *TelegramBotHandler) HandleMyChatMember(update *bot.Update) {
+
+	telegramID := strconv.FormatInt(update.MyChatMember.Chat.ID, 10)
+
+	switch update.MyChatMember.NewChatMember.Status {
+	case bot.ChatMemberKicked:
+		handler.clService.MarkClientBlocked(telegramID)
+	case bot.ChatMemberMember:
+		handler.clService.MarkClientActive(telegramID)
+	}
+}
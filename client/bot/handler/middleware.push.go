@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/gorilla/mux"
+)
+
+// AuthenticateInternalRequest is a middleware that only lets signed internal push requests through.
+// A request is accepted if it carries a push jwt for the job signed with the app secret key as a bearer token, or an
+// HMAC-SHA256 signature of "<job id>.<timestamp>". Either of them has to be fresh and can only be used once.
+func (handler *TelegramBotHandler) AuthenticateInternalRequest(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		signingKey := []byte(os.Getenv(entity.AppSecretKeyName))
+
+		// Without a secret key no request can be trusted
+		if len(signingKey) == 0 {
+			handler.WritePushResponse(w, &PushError{Code: PushCodeUnauthorized,
+				Message: "internal push requests are disabled"})
+			return
+		}
+
+		authorization := r.Header.Get("Authorization")
+		if strings.HasPrefix(authorization, "Bearer ") {
+			claim := new(bot.PushClaim)
+			err := tools.ParseToken(strings.TrimPrefix(authorization, "Bearer "), signingKey, claim)
+			if err != nil || claim.Purpose != bot.PushTokenPurpose || claim.JobID != mux.Vars(r)["id"] {
+				handler.rejectInternalRequest(w, r, "invalid token")
+				return
+			}
+
+			// Tokens without an expiry or with a long lifetime could be kept and used later
+			lifetime := claim.ExpiresAt - time.Now().Unix()
+			if claim.ExpiresAt == 0 || lifetime > bot.PushSignatureMaxAge || claim.Id == "" {
+				handler.rejectInternalRequest(w, r, "token must have an id and expire within 5 minutes")
+				return
+			}
+
+			handler.useInternalRequestCredential(w, r, fmt.Sprintf(bot.UsedPushTokenKey, claim.Id), next)
+			return
+		}
+
+		signature := r.Header.Get(bot.PushSignatureHeader)
+		timestamp, err := strconv.ParseInt(r.Header.Get(bot.PushTimestampHeader), 10, 64)
+		if signature == "" || err != nil {
+			handler.rejectInternalRequest(w, r, "missing signature")
+			return
+		}
+
+		age := time.Now().Unix() - timestamp
+		if age > bot.PushSignatureMaxAge || age < -bot.PushSignatureMaxAge {
+			handler.rejectInternalRequest(w, r, "expired signature")
+			return
+		}
+
+		message := fmt.Sprintf("%s.%d", mux.Vars(r)["id"], timestamp)
+		if !tools.VerifySignature(signingKey, message, signature) {
+			handler.rejectInternalRequest(w, r, "invalid signature")
+			return
+		}
+
+		// The signed message marks the request as used since the same signature can be hex encoded in different cases
+		handler.useInternalRequestCredential(w, r, fmt.Sprintf(bot.UsedPushSignatureKey, message), next)
+	}
+}
+
+// useInternalRequestCredential is a method that marks the signature or token of an internal push request as used
+// before passing the request on, so a captured request can't be replayed
+func (handler *TelegramBotHandler) useInternalRequestCredential(w http.ResponseWriter, r *http.Request, key string,
+	next http.HandlerFunc) {
+
+	added, err := handler.store.AddIfAbsent(key, "used")
+	if err != nil {
+		handler.logger.LogFileError(fmt.Sprintf("Unable to mark %s as used, %s", key, err.Error()),
+			entity.ServerLogFile)
+		handler.WritePushResponse(w, &PushError{Code: PushCodeError, Message: "unable to verify request"})
+		return
+	}
+
+	if !added {
+		handler.rejectInternalRequest(w, r, "request already used")
+		return
+	}
+
+	next(w, r)
+}
+
+// rejectInternalRequest is a method that logs and responds to an internal push request that failed authentication
+func (handler *TelegramBotHandler) rejectInternalRequest(w http.ResponseWriter, r *http.Request, reason string) {
+	handler.logger.LogFileError(fmt.Sprintf("Rejected internal push request %s from %s, %s",
+		r.URL.Path, r.RemoteAddr, reason), entity.ServerLogFile)
+	handler.WritePushResponse(w, &PushError{Code: PushCodeUnauthorized, Message: reason})
+}
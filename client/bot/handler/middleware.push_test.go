@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/log"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gorilla/mux"
+)
+
+func TestAuthenticateInternalRequest(t *testing.T) {
+
+	signingKey := []byte("push-secret")
+	os.Setenv(entity.AppSecretKeyName, string(signingKey))
+	defer os.Unsetenv(entity.AppSecretKeyName)
+
+	handler := &TelegramBotHandler{store: tools.NewMapStore(), logger: &log.Logger{}}
+	router := mux.NewRouter()
+	router.HandleFunc("/push/notification/channel/{id}", handler.AuthenticateInternalRequest(
+		func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }))
+
+	token := func(claim *bot.PushClaim) string {
+		signedToken, _ := tools.GenerateToken(signingKey, claim)
+		return "Bearer " + signedToken
+	}
+
+	now := time.Now().Unix()
+	validToken := token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-1",
+		StandardClaims: jwt.StandardClaims{Id: "token-1", ExpiresAt: now + 60}})
+	signature := tools.GenerateSignature(signingKey, fmt.Sprintf("JB-1.%d", now))
+
+	tests := []struct {
+		name          string
+		authorization string
+		signature     string
+		timestamp     int64
+		expected      int
+	}{
+		{"valid token", validToken, "", 0, http.StatusOK},
+		{"replayed token", validToken, "", 0, http.StatusUnauthorized},
+		{"token without expiry", token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-1",
+			StandardClaims: jwt.StandardClaims{Id: "token-2"}}), "", 0, http.StatusUnauthorized},
+		{"long lived token", token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-1",
+			StandardClaims: jwt.StandardClaims{Id: "token-3", ExpiresAt: now + 3600}}), "", 0, http.StatusUnauthorized},
+		{"expired token", token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-1",
+			StandardClaims: jwt.StandardClaims{Id: "token-4", ExpiresAt: now - 60}}), "", 0, http.StatusUnauthorized},
+		{"token without id", token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-1",
+			StandardClaims: jwt.StandardClaims{ExpiresAt: now + 60}}), "", 0, http.StatusUnauthorized},
+		{"token of another job", token(&bot.PushClaim{Purpose: bot.PushTokenPurpose, JobID: "JB-2",
+			StandardClaims: jwt.StandardClaims{Id: "token-5", ExpiresAt: now + 60}}), "", 0, http.StatusUnauthorized},
+		{"token without purpose", token(&bot.PushClaim{JobID: "JB-1",
+			StandardClaims: jwt.StandardClaims{Id: "token-6", ExpiresAt: now + 60}}), "", 0, http.StatusUnauthorized},
+		{"valid signature", "", signature, now, http.StatusOK},
+		{"replayed signature", "", signature, now, http.StatusUnauthorized},
+		{"replayed upper cased signature", "", strings.ToUpper(signature), now, http.StatusUnauthorized},
+		{"stale signature", "", tools.GenerateSignature(signingKey, fmt.Sprintf("JB-1.%d", now-600)), now - 600,
+			http.StatusUnauthorized},
+		{"missing credentials", "", "", 0, http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodPost, "/push/notification/channel/JB-1", nil)
+		if test.authorization != "" {
+			request.Header.Set("Authorization", test.authorization)
+		}
+		if test.signature != "" {
+			request.Header.Set(bot.PushSignatureHeader, test.signature)
+			request.Header.Set(bot.PushTimestampHeader, fmt.Sprint(test.timestamp))
+		}
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.expected {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.expected, recorder.Code)
+		}
+	}
+}
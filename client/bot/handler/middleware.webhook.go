@@ -2,9 +2,11 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/Benyam-S/asseri/client/bot"
 	"github.com/Benyam-S/asseri/entity"
@@ -14,6 +16,16 @@ import (
 func (handler *TelegramBotHandler) ParseRequest(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
+		// Only Telegram knows the secret token set with setWebhook
+		secretToken := os.Getenv("webhook_secret_token")
+		if secretToken != "" && subtle.ConstantTimeCompare(
+			[]byte(r.Header.Get(bot.SecretTokenHeader)), []byte(secretToken)) != 1 {
+			handler.logger.LogFileError(fmt.Sprintf("Rejected update with invalid secret token from %s",
+				r.RemoteAddr), entity.BotLogFile)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
 		update := new(bot.Update)
 		err := json.NewDecoder(r.Body).Decode(update)
 		if err != nil {
@@ -21,6 +33,23 @@ func (handler *TelegramBotHandler) ParseRequest(next http.HandlerFunc) http.Hand
 			return
 		}
 
+		// Telegram redelivers an update if the previous delivery took too long, so an update is handled only once
+		if update.UpdateID != 0 {
+			added, err := handler.store.AddIfAbsent(fmt.Sprintf(bot.HandledUpdateKey, update.UpdateID), "handled")
+			if err != nil {
+				// Telegram delivers the update again later since it hasn't been acknowledged
+				handler.logger.LogFileError(fmt.Sprintf("Unable to mark update %d as handled, %s",
+					update.UpdateID, err.Error()), entity.BotLogFile)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			if !added {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+
 		// Adding the update information to the context
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, entity.Key("update_info"), update)
@@ -0,0 +1,331 @@
+package locale
+
+// amharic is a value list that holds the amharic messages
+var amharic = Bundle{
+
+	// ----- Main menu -----
+	"menu.select_option":       "አማራጭ ይምረጡ",
+	"button.post_job":          "📋 ሥራ ይለጥፉ",
+	"button.manage_jobs":       "💼 ሥራዎችን ያስተዳድሩ",
+	"button.job_subscriptions": "🔔 የሥራ ምዝገባዎች",
+	"button.settings":          "⚙️ ማስተካከያዎች",
+	"button.my_applications":   "📄 ማመልከቻዎቼ",
+	"button.main_menu":         "🔙 ዋና ማውጫ",
+	"button.skip":              "↖️ ዝለል",
+
+	// ----- Settings -----
+	"settings.choose":       "ምርጫዎን ይምረጡ",
+	"button.profile":        "👥 መገለጫ",
+	"button.notifications":  "🔔 ማሳወቂያዎች",
+	"button.language":       "🌐 ቋንቋ",
+	"button.feedback":       "🗣️ አስተያየት",
+	"language.name":         "🇪🇹 አማርኛ",
+	"language.choose":       "ቦቱ እንዲያናግርዎት የሚፈልጉትን ቋንቋ ይምረጡ",
+	"language.changed":      "✅ ቋንቋው ተቀይሯል",
+	"language.error.update": "❌ ስህተት ቋንቋውን መቀየር አልተቻለም!",
+
+	// ----- Registration -----
+	"button.start":                 "🏁 ጀምር",
+	"registration.welcome":         "ወደ አሰሪ እንኳን በደህና መጡ 👋፣ እባክዎ መጀመሪያ ይመዝገቡ!",
+	"registration.enter_name":      "የድርጅት/የግለሰብ ስም ያስገቡ",
+	"registration.reenter_name":    "ስምዎን እንደገና ያስገቡ",
+	"registration.choose_category": "በየትኛው ምድብ መመደብ ይፈልጋሉ?",
+	"registration.completed":       "እንኳን ደስ አለዎት 🎉 በተሳካ ሁኔታ ተመዝግበዋል!",
+	"registration.error.init":      "❌ ስህተት ምዝገባውን መጀመር አልተቻለም!",
+	"registration.error.name":      "❌ ስህተት ስሙን መመዝገብ አልተቻለም!",
+	"registration.error.phone":     "❌ ስህተት ስልክ ቁጥሩን መመዝገብ አልተቻለም!",
+	"registration.error.user":      "❌ ስህተት አዲስ ተጠቃሚ መጨመር አልተቻለም!\nምድብ እንደገና ይምረጡ",
+	"button.category_asseri":       "አሰሪ",
+	"button.category_job_seeker":   "ሥራ ፈላጊ",
+	"button.category_agent":        "ወኪል",
+
+	// ----- Phonenumber verification -----
+	"button.share_phone":         "ያጋሩ 📱",
+	"button.change_phone":        "📱 ስልክ ቁጥር ይቀይሩ",
+	"phone.add":                  "ስልክ ቁጥርዎን ያስገቡ፣ 'ያጋሩ 📱' የሚለውን ቁልፍ በመጠቀም ስልክ ቁጥርዎን ያጋሩ ወይም የማረጋገጫ ኮድ ለመቀበል ቁጥሩን ይጻፉ",
+	"phone.enter_code":           "ወደ %s የተላከውን የማረጋገጫ ኮድ ያስገቡ",
+	"phone.sms_code":             "የአሰሪ የማረጋገጫ ኮድዎ %s ነው፣ በ %d ደቂቃ ውስጥ ጊዜው ያልፋል።",
+	"phone.error.own_contact":    "❌ እባክዎ የራስዎን ስልክ ቁጥር ያጋሩ",
+	"phone.error.invalid_code":   "❌ ልክ ያልሆነ ኮድ፣ %d ሙከራ ቀርቷል",
+	"phone.error.code_expired":   "❌ የማረጋገጫ ኮዱ ጊዜው አልፏል ወይም ብዙ ጊዜ ገብቷል",
+	"phone.error.resend_wait":    "ሌላ ኮድ ከመጠየቅዎ በፊት እባክዎ አንድ ደቂቃ ይጠብቁ",
+	"phone.error.send_code":      "የማረጋገጫ ኮዱን መላክ አልተቻለም",
+	"phone.error.too_many_codes": "በጣም ብዙ የማረጋገጫ ኮዶች ተጠይቀዋል፣ እባክዎ ነገ እንደገና ይሞክሩ",
+
+	// ----- Profile -----
+	"profile.view":              "<b>ስም</b>:   %s\n<b>ምድብ</b>:   %s\n<b>ስልክ ቁጥር</b>:   %s\n\n",
+	"button.update_profile":     "🔧 መገለጫ ያሻሽሉ",
+	"profile.enter_name":        "አዲስ ስም ያስገቡ",
+	"profile.reenter_name":      "አዲሱን ስም እንደገና ያስገቡ",
+	"profile.enter_phone":       "አዲስ ስልክ ቁጥር ያስገቡ",
+	"profile.reenter_phone":     "አዲሱን ስልክ ቁጥር እንደገና ያስገቡ",
+	"profile.choose_category":   "ምድብዎን መቀየር ይፈልጋሉ?",
+	"profile.reselect_category": "ምድብ እንደገና ይምረጡ",
+	"profile.updated":           "እንኳን ደስ አለዎት 🎉 መገለጫዎን በተሳካ ሁኔታ አሻሽለዋል!",
+	"profile.error.name":        "❌ ስህተት ስሙን ማሻሻል አልተቻለም!",
+	"profile.error.phone":       "❌ ስህተት ስልክ ቁጥሩን ማሻሻል አልተቻለም!",
+	"profile.error.update":      "❌ ስህተት መገለጫውን ማሻሻል አልተቻለም!",
+
+	// ----- Jobs -----
+	"common.oops":         "🙁 ውይ! %s",
+	"job.choose_status":   "ሁኔታ ይምረጡ",
+	"button.pending":      "⌛ በመጠባበቅ ላይ",
+	"button.opened":       "📖 ክፍት",
+	"button.closed":       "📕 የተዘጉ",
+	"button.declined":     "🚫 ውድቅ የተደረጉ",
+	"job.detail":          "<b>የሥራ መደብ</b>:  %s\n\n<b>የሥራ ዓይነት</b>:  %s\n<b>ጾታ</b>:  %s\n<b>የትምህርት ደረጃ</b>:  %s\n<b>የሥራ ልምድ</b>:  %s\n<b>የመገናኛ ዘዴ</b>:  %s\n\n<b>መግለጫ</b>:  %s\n\n#%s",
+	"job.banner.closed":   "------------- <b>ተዘግቷል</b> -------------\n\n",
+	"job.banner.declined": "------------- <b>ውድቅ ተደርጓል</b> -------------\n\n",
+	"job.close":           "❌ ዝጋ",
+	"job.empty.pending":   "ፈቃድ በመጠባበቅ ላይ ያለ ሥራ የለዎትም።",
+	"job.empty.opened":    "አመልካች በመጠባበቅ ላይ ያለ ክፍት ሥራ የለዎትም።",
+	"job.empty.closed":    "የተዘጋ ሥራ የለዎትም።",
+	"job.empty.declined":  "ውድቅ የተደረገ ሥራ የለዎትም።",
+	"job.extended":        "✅ የ<b>%s</b> የማብቂያ ቀን ወደ %s ተራዝሟል",
+	"job.error.view":      "😳 ውይ! የሥራውን ዝርዝር ማየት አልተቻለም።",
+	"job.error.find":      "🙁 ውይ! ሥራውን ማግኘት አልተቻለም",
+	"job.error.extend":    "🙁 ውይ! ሥራውን ማራዘም አልተቻለም",
+	"job.error.close":     "🙁 ውይ! ሥራውን መዝጋት አልተቻለም",
+	"job.status.pending":  "በመጠባበቅ ላይ",
+	"job.status.opened":   "ክፍት",
+	"job.status.closed":   "ተዘግቷል",
+	"job.status.declined": "ውድቅ ተደርጓል",
+	"gender.male":         "ወንድ",
+	"gender.female":       "ሴት",
+	"gender.both":         "ሁለቱም",
+
+	// ----- Job post -----
+	"job.field.title":           "<b>የሥራ መደብ</b>:  %s\n",
+	"job.field.employer":        "<b>አሰሪ</b>:  %s\n",
+	"job.field.type":            "<b>የሥራ ዓይነት</b>:  %s\n",
+	"job.field.gender":          "<b>ጾታ</b>:  %s\n",
+	"job.field.education_level": "<b>የትምህርት ደረጃ</b>:  %s\n",
+	"job.field.experience":      "<b>የሥራ ልምድ</b>:  %s\n",
+	"job.field.contact":         "<b>አድራሻ</b>: %s\n\n",
+	"job.field.description":     "<b>መግለጫ</b>:  %s\n",
+
+	// ----- Job application -----
+	"button.cancel_application":   "🔙 ማመልከቻውን ሰርዝ",
+	"apply.send_cv":               "የሥራ ልምድ ማስረጃ (CV) ይላኩ (*PDF ብቻ)",
+	"apply.caption":               "------------- <b>ማመልከቻ</b> -------------\n\n<b>የሥራ መደብ</b>:  %s\n\n<b>መግለጫ</b>:  %s\n\n",
+	"apply.sent":                  "🎉 ማመልከቻዎ ለአሰሪው ተልኳል። መልካም ዕድል!",
+	"apply.error.already_applied": "❌ ለዚህ ሥራ ቀድመው አመልክተዋል",
+	"apply.error.pdf_only":        "እባክዎ *.pdf ፋይል ብቻ ይላኩ",
+	"apply.error.apply":           "🙁 ለሥራው ማመልከት አልተቻለም",
+	"apply.error.send":            "😳 ውይ! የሆነ ችግር ተፈጥሯል፣ እንደገና ያመልክቱ።",
+	"apply.error.closed":          "🙁 ይቅርታ ሥራው ተዘግቷል",
+	"apply.error.own_job":         "❌ ለራስዎ ሥራ ማመልከት አይችሉም",
+
+	// ----- Job posting -----
+	"post.enter_title":            "የሥራ መደቡን ያስገቡ",
+	"post.enter_description":      "የሥራውን መግለጫ ያስገቡ",
+	"post.choose_sectors":         "የሥራ ዘርፎችን ይምረጡ",
+	"post.choose_sectors.hint":    "<b>አንድ ወይም ከዚያ በላይ ዘርፍ መርጠው ቀጣይ የሚለውን ይጫኑ</b>",
+	"post.choose_types":           "የሥራ ዓይነቶችን ይምረጡ",
+	"post.choose_types.hint":      "<b>አንድ ወይም ከዚያ በላይ ዓይነት መርጠው ቀጣይ የሚለውን ይጫኑ</b>",
+	"post.choose_education_level": "<b>የሚፈለገውን የትምህርት ደረጃ ይምረጡ</b>",
+	"post.choose_experience":      "<b>የሚፈለገውን የሥራ ልምድ ይምረጡ</b>",
+	"post.choose_gender":          "<b>ለሥራው የሚፈለገውን ጾታ ይምረጡ</b>",
+	"post.choose_contact_type":    "<b>አመልካቾች እንዴት ያግኙዎት?</b>",
+	"post.enter_due_date":         "የሥራውን የማብቂያ ቀን በ dd/mm/yyyy hh:mm ቅርጸት ያስገቡ፣ ለምሳሌ 25/12/2021 17:30\nሥራው የማብቂያ ቀን ከሌለው ይዝለሉ",
+	"post.next":                   "➡️ ቀጣይ",
+	"post.preview":                "የሥራዎ ቅድመ እይታ",
+	"post.preview.detail":         "<b>የሥራ መደብ</b>:  %s\n\n<b>የሥራ ዓይነት</b>:  %s\n<b>ጾታ</b>:  %s\n<b>የትምህርት ደረጃ</b>:  %s\n<b>የሥራ ልምድ</b>:  %s\n<b>የመገናኛ ዘዴ</b>:  %s\n<b>የማብቂያ ቀን</b>:  %s\n\n<b>መግለጫ</b>:  %s\n\n#%s",
+	"post.no_due_date":            "የለም",
+	"post.edit":                   "✏️ አስተካክል",
+	"post.submit":                 "✅ አስገባ",
+	"post.choose_edit":            "መቀየር የሚፈልጉትን መረጃ ይምረጡ",
+	"post.entry.title":            "የሥራ መደብ",
+	"post.entry.description":      "መግለጫ",
+	"post.entry.sectors":          "ዘርፎች",
+	"post.entry.types":            "ዓይነቶች",
+	"post.entry.education_level":  "የትምህርት ደረጃ",
+	"post.entry.experience":       "የሥራ ልምድ",
+	"post.entry.gender":           "ጾታ",
+	"post.entry.contact_type":     "የመገናኛ ዘዴ",
+	"post.entry.due_date":         "የማብቂያ ቀን",
+	"post.web_form":               "ወይም ሥራውን በድረ ገጽ ቅጹ ይለጥፉ",
+	"post.web_form.open":          "🌐 የድረ ገጽ ቅጽ",
+	"post.submitted":              "✅ ሥራዎ ገብቷል፣ ከተገመገመ በኋላ ይነገርዎታል",
+	"post.error.job_seeker":       "🙁 ውይ! ይህ ተግባር ለሥራ ፈላጊ አይፈቀድም።",
+	"post.error.start":            "🙁 ውይ! ሥራ መለጠፍ መጀመር አልተቻለም",
+	"post.error.expired":          "🙁 ውይ! የሥራ ረቂቅዎ ጊዜው አልፏል፣ እባክዎ እንደገና ይጀምሩ",
+	"post.error.due_date":         "🙁 ልክ ያልሆነ የማብቂያ ቀን፣ እባክዎ dd/mm/yyyy hh:mm ቅርጸት ይጠቀሙ። ለምሳሌ 25/12/2021 17:30",
+	"post.error.save":             "🙁 ውይ! የሥራ ረቂቁን ማስቀመጥ አልተቻለም",
+	"post.error.correct":          "🙁 ከማስገባትዎ በፊት እባክዎ የሚከተሉትን መረጃዎች ያስተካክሉ\n\n",
+	"post.error.submit":           "🙁 ውይ! ሥራውን ማስገባት አልተቻለም",
+
+	// ----- Job subscriptions -----
+	"subscription.choose_option":               "አማራጭ ይምረጡ",
+	"button.add_subscription":                  "➕ ምዝገባ ይጨምሩ",
+	"button.edit_subscriptions":                "📝 ምዝገባዎችን ያስተካክሉ",
+	"subscription.empty":                       "ለምንም ሥራ አልተመዘገቡም! እባክዎ ምዝገባ ይጨምሩ የሚለውን ቁልፍ በመጠቀም ይመዝገቡ።",
+	"subscription.detail":                      "<b>የሥራ ምዝገባ</b>\n\n<b>የሥራ ዓይነት</b>:  %s\n<b>ዘርፍ</b>:  %s\n<b>የትምህርት ደረጃ</b>:  %s\n<b>የሥራ ልምድ</b>:  %s\n\n",
+	"subscription.remove":                      "🗑️ አስወግድ",
+	"subscription.removed":                     "<b>የሥራ ምዝገባ</b>\n\n<b>የሥራ ዓይነት</b>:  %s\n<b>ዘርፍ</b>:  %s\n\n",
+	"subscription.banner.removed":              "------------- <b>ተወግዷል</b> -------------\n\n",
+	"subscription.choose_sector":               "የሥራ ዘርፍ ይምረጡ",
+	"subscription.choose_sector.hint":          "<b>የሚከተሉት ያሉት የሥራ ዘርፎች ናቸው</b>",
+	"subscription.choose_type":                 "የሥራ ዓይነት ይምረጡ",
+	"subscription.choose_type.hint":            "<b>የሚከተሉት ያሉት የሥራ ዓይነቶች ናቸው</b>",
+	"subscription.choose_education_level":      "የትምህርት ደረጃ ይምረጡ",
+	"subscription.choose_education_level.hint": "<b>የሚከተሉት ያሉት የትምህርት ደረጃዎች ናቸው</b>",
+	"subscription.choose_experience":           "የሥራ ልምድ ይምረጡ",
+	"subscription.choose_experience.hint":      "<b>የሚከተሉት ያሉት የሥራ ልምዶች ናቸው</b>",
+	"subscription.added":                       "እንኳን ደስ አለዎት 🎉 አዲስ የሥራ ምዝገባ በተሳካ ሁኔታ ጨምረዋል!",
+	"subscription.error.sector":                "❌ ስህተት የምዝገባውን የሥራ ዘርፍ መጨመር አልተቻለም!",
+	"subscription.error.type":                  "❌ ስህተት የምዝገባውን የሥራ ዓይነት መጨመር አልተቻለም!",
+	"subscription.error.education_level":       "❌ ስህተት የምዝገባውን የትምህርት ደረጃ መጨመር አልተቻለም!",
+	"subscription.error.add":                   "❌ ስህተት የሥራ ምዝገባውን መጨመር አልተቻለም!",
+	"subscription.error.remove":                "🙁 ውይ! ምዝገባውን ማስወገድ አልተቻለም",
+	"subscription.error.expired":               "ውይ 😳 የሆነ ከባድ ችግር ተፈጥሯል!",
+
+	// ----- Applications -----
+	"common.invalid_action":              "ልክ ያልሆነ ተግባር!",
+	"common.page":                        "ገጽ %d ከ %d",
+	"common.prev":                        "⬅️ ቀዳሚ",
+	"common.next":                        "ቀጣይ ➡️",
+	"job.error.not_found":                "ሥራው አልተገኘም!",
+	"application.status.submitted":       "ገብቷል",
+	"application.status.viewed":          "ታይቷል",
+	"application.status.shortlisted":     "ለመጨረሻ ምርጫ ተይዟል",
+	"application.status.rejected":        "ውድቅ ተደርጓል",
+	"application.status.hired":           "ተቀጥሯል",
+	"application.changed.shortlisted":    "ማመልከቻው ለመጨረሻ ምርጫ ተይዟል",
+	"application.changed.rejected":       "ማመልከቻው ውድቅ ተደርጓል",
+	"application.changed.hired":          "አመልካቹ ተቀጥሯል",
+	"application.job_details":            "👀 የሥራ ዝርዝር",
+	"application.shortlist":              "⭐️ ለመጨረሻ ምርጫ ያዝ",
+	"application.reject":                 "❌ ውድቅ አድርግ",
+	"application.hire":                   "🤝 ቅጠር",
+	"application.message":                "✉️ መልዕክት",
+	"application.write_message":          "ለአመልካቹ መልዕክትዎን ይጻፉ",
+	"application.employer_message":       "📩 <b>ከአሰሪው የተላከ መልዕክት</b>\n\n<b>የሥራ መደብ</b>:  %s\n\n%s",
+	"application.message_sent":           "መልዕክትዎ ለአመልካቹ ተልኳል።",
+	"application.update.subject":         "የማመልከቻ ሁኔታ: %s",
+	"application.update.message":         "------------- <b>የማመልከቻ ሁኔታ</b> -------------\n\n<b>የሥራ መደብ</b>:  %s\n\n<b>ሁኔታ</b>:  %s\n\n%s",
+	"application.update.summary":         "አሰሪ: ለ%s ያስገቡት ማመልከቻ %s። %s",
+	"application.update.viewed":          "👀 አሰሪው ማመልከቻዎን አይቷል።",
+	"application.update.shortlisted":     "🌟 እንኳን ደስ አለዎት! ለመጨረሻ ምርጫ ተይዘዋል፣ አሰሪው በቅርቡ ሊያገኝዎት ይችላል።",
+	"application.update.rejected":        "🙁 እንደ አለመታደል ሆኖ አሰሪው ማመልከቻዎን ላለመቀበል ወስኗል።",
+	"application.update.hired":           "🎉 እንኳን ደስ አለዎት! ተቀጥረዋል።",
+	"application.empty":                  "እስካሁን ለምንም ሥራ አላመለከቱም።",
+	"application.list.title":             "------------- <b>ማመልከቻዎቼ</b> -------------\n\n",
+	"application.list.entry":             "%d. <b>%s</b>\n<b>የሥራው ሁኔታ</b>:  %s\n<b>ማመልከቻ</b>:  %s\n\n",
+	"application.withdraw":               "🗑 %d ን አንሳ",
+	"application.withdrawn":              "ማመልከቻው ተነስቷል",
+	"application.error.withdrawn":        "ማመልከቻው ተነስቷል!",
+	"application.error.not_found":        "ማመልከቻው አልተገኘም!",
+	"application.error.not_withdrawable": "ማመልከቻውን ማንሳት አይቻልም!",
+	"application.error.withdraw":         "ማመልከቻውን ማንሳት አልተቻለም!",
+	"application.error.update":           "ማመልከቻውን ማሻሻል አልተቻለም!",
+	"application.error.message":          "🙁 መልዕክቱን መላክ አልተቻለም",
+	"application.error.unreachable":      "🙁 አመልካቹን በአሁኑ ሰዓት ማግኘት አይቻልም",
+
+	// ----- Applicants -----
+	"applicants.list":           "👥 አመልካቾች (%d)",
+	"applicants.empty":          "እስካሁን ምንም አመልካች የለም!",
+	"applicants.title":          "------------- <b>አመልካቾች</b> -------------\n\n<b>የሥራ መደብ</b>:  %s\n\n",
+	"applicants.entry":          "%d. <b>%s</b>\n<b>ስልክ</b>:  %s\n<b>ሁኔታ</b>:  %s\n\n",
+	"applicants.cv":             "📎 CV %d",
+	"applicants.cv.caption":     "------------- <b>ማመልከቻ</b> -------------\n\n<b>የሥራ መደብ</b>:  %s\n\n<b>አመልካች</b>:  %s\n\n",
+	"applicants.export":         "📊 CSV ላክ",
+	"applicants.export.caption": "የ%s <b>አመልካቾች</b>",
+	"applicants.error.export":   "🙁 አመልካቾቹን መላክ አልተቻለም",
+	"applicants.error.no_cv":    "የዚህ ማመልከቻ CV አይገኝም!",
+	"applicants.error.send_cv":  "CV ውን መላክ አልተቻለም!",
+
+	// ----- Feedback -----
+	"feedback.prompt":          "እባክዎ አስተያየትዎን በአጭሩ ይጻፉ",
+	"feedback.reenter":         "አስተያየትዎን እንደገና ያስገቡ",
+	"feedback.received":        "ስለ አስተያየትዎ እናመሰግናለን 😁! ጥያቄዎን ለማሟላት የተቻለንን እናደርጋለን።",
+	"feedback.reply":           "💬 <b>ለአስተያየትዎ የተሰጠ ምላሽ</b>\n\n<i>%s</i>\n\n%s",
+	"feedback.reply.answer":    "↩️ መልስ",
+	"feedback.reply.close":     "✅ ዝጋ",
+	"feedback.write_reply":     "እባክዎ መልስዎን ይጻፉ",
+	"feedback.reply_sent":      "መልስዎ ተልኳል፣ በቅርቡ ምላሽ እንሰጥዎታለን።",
+	"feedback.closed":          "ውይይቱ ተዘግቷል፣ ስለ አስተያየትዎ እናመሰግናለን 😁!",
+	"feedback.error.add":       "❌ ስህተት አስተያየትዎን መጨመር አልተቻለም!",
+	"feedback.error.reply":     "❌ ስህተት መልስዎን መጨመር አልተቻለም!",
+	"feedback.error.not_found": "አስተያየቱ አልተገኘም!",
+	"feedback.error.closed":    "ይህ ውይይት ቀድሞ ተዘግቷል!",
+	"feedback.error.close":     "ውይይቱን መዝጋት አልተቻለም!",
+
+	// ----- Notifications -----
+	"button.set_email":             "📧 ኢሜይል ያስገቡ",
+	"notification.choose_fallback": "ማሳወቂያዎች እዚሁ ቴሌግራም ላይ ይላካሉ፣ እዚህ ማግኘት በማይቻልበት ጊዜ የሚነገርዎትን መንገድ ይምረጡ።",
+	"notification.settings":        "<b>ኢሜይል</b>:   %s\n<b>አማራጭ</b>:   %s",
+	"notification.fallback.sms":    "SMS",
+	"notification.fallback.email":  "ኢሜይል",
+	"notification.fallback.none":   "የለም",
+	"notification.enter_email":     "የኢሜይል አድራሻዎን ያስገቡ",
+	"notification.email_saved":     "የኢሜይል አድራሻዎ ተቀምጧል።",
+	"notification.error.no_email":  "መጀመሪያ የኢሜይል አድራሻዎን ያስገቡ",
+	"notification.error.save":      "የማሳወቂያ ምርጫዎችን ማስቀመጥ አልተቻለም",
+
+	// ----- Moderation -----
+	"moderation.post.title":             "------------- <b>ግምገማ በመጠባበቅ ላይ</b> -------------\n\n",
+	"moderation.post.job_id":            "<b>የሥራ መለያ</b>:  %s\n",
+	"moderation.post.due_date":          "<b>የማብቂያ ቀን</b>:  %s\n",
+	"moderation.approve":                "✅ አጽድቅ",
+	"moderation.decline":                "❌ ውድቅ አድርግ",
+	"moderation.request_changes.button": "✏️ ማስተካከያ ጠይቅ",
+	"moderation.reason_prompt.decline":  "%s፣ <b>%s</b> ውድቅ የተደረገበትን ምክንያት ለዚህ መልዕክት መልስ አድርገው ይጻፉ",
+	"moderation.reason_prompt.changes":  "%s፣ በ<b>%s</b> ላይ የሚጠየቁትን ማስተካከያዎች ለዚህ መልዕክት መልስ አድርገው ይጻፉ",
+	"moderation.approved":               "✅ ሥራው ጸድቋል",
+	"moderation.declined":               "❌ ሥራው ውድቅ ተደርጓል",
+	"moderation.changes_requested":      "✏️ አሰሪው ሥራውን እንዲያስተካክል ተጠይቋል",
+	"moderation.request_changes":        "✏️ ሥራዎ <b>%s</b> ከመጽደቁ በፊት ማስተካከያ ያስፈልገዋል\n\n<b>ምክንያት</b>:  %s\n\nእባክዎ የተጠየቁትን ማስተካከያዎች አድርገው ሥራውን እንደገና ይለጥፉ",
+	"moderation.outcome.approved":       "✅ በ%s <b>ጸድቋል</b>",
+	"moderation.outcome.declined":       "❌ በ%s <b>ውድቅ ተደርጓል</b>\n<b>ምክንያት</b>:  %s",
+	"moderation.outcome.changes":        "✏️ በ%s <b>ማስተካከያ ተጠይቋል</b>\n<b>ምክንያት</b>:  %s",
+	"moderation.error.staff_only":       "🚫 ሥራዎችን መገምገም የሚችሉት የሠራተኞች አባላት ብቻ ናቸው",
+	"moderation.error.reviewed":         "🙁 ይህ ሥራ ቀድሞ ተገምግሟል",
+	"moderation.error.prompt":           "🙁 ውይ! ተግባሩን ማከናወን አልተቻለም",
+	"moderation.error.reason":           "🙁 እባክዎ ምክንያቱን በጽሑፍ መልዕክት ይመልሱ",
+	"moderation.error.moderate.approve": "🙁 ውይ! ሥራውን ማጽደቅ አልተቻለም፣ %s",
+	"moderation.error.moderate.decline": "🙁 ውይ! ሥራውን ውድቅ ማድረግ አልተቻለም፣ %s",
+	"moderation.error.moderate.changes": "🙁 ውይ! በሥራው ላይ ማስተካከያ መጠየቅ አልተቻለም፣ %s",
+	"moderation.error.notify":           "🙁 ውይ! ለአሰሪው ማሳወቅ አልተቻለም፣ %s",
+
+	// ----- Job notifications -----
+	"job.apply":                   "🔗 ያመልክቱ",
+	"job.view_details":            "ዝርዝሩን ለማየት ይሄንን ይጫኑ",
+	"job.banner.approved":         "------------- <b>ጸድቋል</b> -------------\n\n",
+	"job.banner.subscription":     "------------- <b>ምዝገባ</b> -------------\n\n",
+	"job.result.reason":           "<b>ምክንያት</b>:  %s\n\n",
+	"job.result.subject.approved": "ሥራው ጸድቋል: %s",
+	"job.result.subject.declined": "ሥራው ውድቅ ተደርጓል: %s",
+	"job.result.subject.closed":   "ሥራው ተዘግቷል: %s",
+	"job.result.summary.approved": "አሰሪ: የለጠፉት ሥራ %s ጸድቋል።",
+	"job.result.summary.declined": "አሰሪ: የለጠፉት ሥራ %s ውድቅ ተደርጓል።",
+	"job.result.summary.closed":   "አሰሪ: የለጠፉት ሥራ %s ተዘግቷል።",
+	"job.result.summary.reason":   " ምክንያት: %s",
+	"reminder.due_date":           "------------- <b>የማብቂያ ቀን ማስታወሻ</b> -------------\n\n<b>የሥራ መደብ</b>:  %s\n\n<b>የሥራ ዓይነት</b>:  %s\n<b>የማብቂያ ቀን</b>:  %s\n<b>ማመልከቻዎች</b>:  %d\n\nየማብቂያ ቀኑ ሲያልፍ ሥራው በራሱ ይዘጋል።\n\n",
+	"reminder.extend":             "⏳ በ7 ቀን አራዝም",
+	"reminder.close":              "🔒 አሁን ዝጋ",
+
+	// ----- Validation -----
+	"validation.invalid":               "ልክ ያልሆነ መረጃ፣ እባክዎ አረጋግጠው እንደገና ይሞክሩ",
+	"validation.user_name":             "ስሙ ቢያንስ አንድ ፊደል ሊኖረው፣ ፊደላትና ቁጥሮችን ብቻ ሊይዝ እና ከ255 ፊደላት ሊበልጥ አይገባም",
+	"validation.phone_number":          "ስልክ ቁጥሩ ልክ አይደለም ወይም ቀድሞ ተመዝግቧል",
+	"validation.category":              "ልክ ያልሆነ ምድብ ተመርጧል",
+	"validation.email":                 "ልክ ያልሆነ የኢሜይል አድራሻ",
+	"validation.notification_fallback": "ልክ ያልሆነ የማሳወቂያ መንገድ፣ የኢሜይል ማሳወቂያዎች የኢሜይል አድራሻ ያስፈልጋቸዋል",
+	"validation.language":              "ልክ ያልሆነ ቋንቋ ተመርጧል",
+	"validation.employer":              "ሥራውን ከዚህ መለያ መለጠፍ አይቻልም",
+	"validation.title":                 "የሥራ መደቡ ባዶ ሊሆን ወይም ከ300 ፊደላት ሊበልጥ አይችልም",
+	"validation.description":           "የሥራው መግለጫ ባዶ ሊሆን ወይም ከ2000 ፊደላት ሊበልጥ አይችልም",
+	"validation.link":                  "ሊንኩ ልክ አይደለም ወይም ከ1000 ፊደላት ይበልጣል",
+	"validation.contact_info":          "የመገናኛ መረጃ መገለጽ አለበት",
+	"validation.type":                  "ልክ ያልሆነ የሥራ ዓይነት",
+	"validation.sector":                "ልክ ያልሆነ የሥራ ዘርፍ",
+	"validation.education_level":       "ልክ ያልሆነ የትምህርት ደረጃ",
+	"validation.experience":            "ልክ ያልሆነ የሥራ ልምድ",
+	"validation.contact_type":          "ልክ ያልሆነ የመገናኛ ዘዴ ተመርጧል",
+	"validation.gender":                "ልክ ያልሆነ ጾታ ተመርጧል",
+	"validation.due_date":              "የማብቂያ ቀኑ ከአሁን ቢያንስ 3 ሰዓት በኋላ መሆን አለበት",
+	"validation.subscription":          "ለተመሳሳይ ሥራዎች ቀድመው ተመዝግበዋል",
+	"validation.comment":               "አስተያየቱ ባዶ ሊሆን ወይም ከ1000 ፊደላት ሊበልጥ አይችልም",
+	"validation.message":               "መልዕክቱ ባዶ ሊሆን ወይም ከ1000 ፊደላት ሊበልጥ አይችልም",
+}
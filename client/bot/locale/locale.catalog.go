@@ -0,0 +1,156 @@
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Benyam-S/asseri/entity"
+	emoji "github.com/tmdvs/Go-Emoji-Utils"
+)
+
+// Bundle is a type that defines the messages of a single language keyed by their message id
+type Bundle map[string]string
+
+// DefaultLanguage is a constant that holds the language used when a user hasn't got a supported language,
+// it is also used for the channel and the moderators' chat since they are shared by every language
+const DefaultLanguage = entity.LanguageEnglish
+
+// ButtonPrefix is a constant that holds the prefix of the message ids used as reply keyboard buttons,
+// a command sent by a client is only matched against these messages
+const ButtonPrefix = "button."
+
+// bundles is a value list that holds the message bundle of every supported language
+var bundles = map[string]Bundle{
+	entity.LanguageEnglish: english,
+	entity.LanguageAmharic: amharic,
+}
+
+// commands is a value list that holds the id of every button keyed by its normalized text in every language
+var commands = make(map[string]string)
+
+func init() {
+	for _, bundle := range bundles {
+		for id, text := range bundle {
+			if strings.HasPrefix(id, ButtonPrefix) {
+				commands[normalize(text)] = id
+			}
+		}
+	}
+}
+
+// Text is a function that returns the message of the given id in the given language, formatted with the given args.
+// A message missing from the language is taken from the default language and an unknown message id is returned as it is.
+func Text(language, id string, args ...interface{}) string {
+
+	text, ok := bundles[language][id]
+	if !ok {
+		text, ok = bundles[DefaultLanguage][id]
+	}
+
+	if !ok {
+		return id
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+
+	return text
+}
+
+// Join is a function that returns the message of the given id in every supported language joined by the separator,
+// it is used for the texts that are seen by users of every language
+func Join(separator, id string, args ...interface{}) string {
+
+	texts := make([]string, 0)
+	for _, language := range Languages() {
+		texts = append(texts, Text(language, id, args...))
+	}
+
+	return strings.Join(texts, separator)
+}
+
+// Command is a function that returns the message id of the button the given text belongs to, in any language.
+// A text that isn't a button is returned without its emojis, like a typed text or a /start command.
+func Command(text string) string {
+
+	if id, ok := commands[normalize(text)]; ok {
+		return id
+	}
+
+	return emoji.RemoveAll(text)
+}
+
+// Language is a function that returns the supported language of a Telegram language code,
+// like am for am-ET, the default language is returned for an unsupported language code
+func Language(languageCode string) string {
+
+	language := strings.ToLower(strings.SplitN(strings.Replace(languageCode, "_", "-", 1), "-", 2)[0])
+	if _, ok := bundles[language]; ok {
+		return language
+	}
+
+	return DefaultLanguage
+}
+
+// IsSupported is a function that checks whether the given language has a message bundle
+func IsSupported(language string) bool {
+	_, ok := bundles[language]
+	return ok
+}
+
+// Languages is a function that returns the supported languages, the default language comes first
+func Languages() []string {
+
+	languages := make([]string, 0)
+	for language := range bundles {
+		if language != DefaultLanguage {
+			languages = append(languages, language)
+		}
+	}
+
+	sort.Strings(languages)
+	return append([]string{DefaultLanguage}, languages...)
+}
+
+// Validate is a function that checks whether every bundle has the messages of the default language
+// and no two buttons have the same text, since a command couldn't be matched to a single button otherwise
+func Validate() error {
+
+	for language, bundle := range bundles {
+		for id := range bundles[DefaultLanguage] {
+			if _, ok := bundle[id]; !ok {
+				return fmt.Errorf("message %q is missing from the %q bundle", id, language)
+			}
+		}
+
+		for id := range bundle {
+			if _, ok := bundles[DefaultLanguage][id]; !ok {
+				return fmt.Errorf("message %q of the %q bundle is missing from the default bundle", id, language)
+			}
+		}
+	}
+
+	buttons := make(map[string]string)
+	for _, bundle := range bundles {
+		for id, text := range bundle {
+			if !strings.HasPrefix(id, ButtonPrefix) {
+				continue
+			}
+
+			if other, ok := buttons[normalize(text)]; ok && other != id {
+				return fmt.Errorf("buttons %q and %q have the same text %q", id, other, text)
+			}
+			buttons[normalize(text)] = id
+		}
+	}
+
+	return nil
+}
+
+// normalize is a function that returns a text without its emojis and case, so a button can be matched
+// however its emojis have been rendered or the text has been typed
+func normalize(text string) string {
+	return strings.ToLower(emoji.RemoveAll(text))
+}
@@ -0,0 +1,331 @@
+package locale
+
+// english is a value list that holds the english messages, it is the default language of the bot
+var english = Bundle{
+
+	// ----- Main menu -----
+	"menu.select_option":       "Select option",
+	"button.post_job":          "📋 Post Job",
+	"button.manage_jobs":       "💼 Manage Jobs",
+	"button.job_subscriptions": "🔔 Job Subscriptions",
+	"button.settings":          "⚙️ Settings",
+	"button.my_applications":   "📄 My Applications",
+	"button.main_menu":         "🔙 Main Menu",
+	"button.skip":              "↖️ Skip",
+
+	// ----- Settings -----
+	"settings.choose":       "Choose preference",
+	"button.profile":        "👥 Profile",
+	"button.notifications":  "🔔 Notifications",
+	"button.language":       "🌐 Language",
+	"button.feedback":       "🗣️ Feedback",
+	"language.name":         "🇬🇧 English",
+	"language.choose":       "Choose the language you want the bot to talk to you in",
+	"language.changed":      "✅ Language changed",
+	"language.error.update": "❌ Error unable to change the language!",
+
+	// ----- Registration -----
+	"button.start":                 "🏁 Start",
+	"registration.welcome":         "Welcome 👋 to Asseri, please register first!",
+	"registration.enter_name":      "Enter company/individual name",
+	"registration.reenter_name":    "Re-enter your name",
+	"registration.choose_category": "You wish to be categorized as ?",
+	"registration.completed":       "Congratulations 🎉 you have been successfully registered!",
+	"registration.error.init":      "❌ Error unable to initiate registration!",
+	"registration.error.name":      "❌ Error unable to register username!",
+	"registration.error.phone":     "❌ Error unable to register phonenumber!",
+	"registration.error.user":      "❌ Error unable to add new user!\nRe-select category",
+	"button.category_asseri":       "Employer",
+	"button.category_job_seeker":   "Job Seeker",
+	"button.category_agent":        "Agent",
+
+	// ----- Phonenumber verification -----
+	"button.share_phone":         "Add 📱",
+	"button.change_phone":        "📱 Change Phonenumber",
+	"phone.add":                  "Add your phonenumber, use 'Add 📱' button to share your phone number or type it to receive a verification code",
+	"phone.enter_code":           "Enter the verification code sent to %s",
+	"phone.sms_code":             "Your Asseri verification code is %s, it expires in %d minutes.",
+	"phone.error.own_contact":    "❌ Please share your own contact",
+	"phone.error.invalid_code":   "❌ Invalid code, %d attempts left",
+	"phone.error.code_expired":   "❌ The verification code has expired or has been entered too many times",
+	"phone.error.resend_wait":    "please wait a minute before requesting another code",
+	"phone.error.send_code":      "unable to send the verification code",
+	"phone.error.too_many_codes": "too many verification codes have been requested, please try again tomorrow",
+
+	// ----- Profile -----
+	"profile.view":              "<b>Name</b>:   %s\n<b>Category</b>:   %s\n<b>Phonenumber</b>:   %s\n\n",
+	"button.update_profile":     "🔧 Update Profile",
+	"profile.enter_name":        "Enter new name",
+	"profile.reenter_name":      "Re-enter new name",
+	"profile.enter_phone":       "Enter new phonenumber",
+	"profile.reenter_phone":     "Re-enter new phonenumber",
+	"profile.choose_category":   "Wish to change category?",
+	"profile.reselect_category": "Re-select category",
+	"profile.updated":           "Congratulations 🎉 you have successfully update your profile!",
+	"profile.error.name":        "❌ Error unable to update name!",
+	"profile.error.phone":       "❌ Error unable to update phonenumber!",
+	"profile.error.update":      "❌ Error unable to update profile!",
+
+	// ----- Jobs -----
+	"common.oops":         "🙁 Oops! %s",
+	"job.choose_status":   "Choose status",
+	"button.pending":      "⌛ Pending",
+	"button.opened":       "📖 Opened",
+	"button.closed":       "📕 Closed",
+	"button.declined":     "🚫 Declined",
+	"job.detail":          "<b>Job Title</b>:  %s\n\n<b>Job Type</b>:  %s\n<b>Gender</b>:  %s\n<b>Education level</b>:  %s\n<b>Experience</b>:  %s\n<b>Contact Type</b>:  %s\n\n<b>Description</b>:  %s\n\n#%s",
+	"job.banner.closed":   "------------- <b>Closed</b> -------------\n\n",
+	"job.banner.declined": "------------- <b>Declined</b> -------------\n\n",
+	"job.close":           "❌ Close",
+	"job.empty.pending":   "You don't have any pending job waiting for approval.",
+	"job.empty.opened":    "You don't have any opened job waiting for an applier.",
+	"job.empty.closed":    "You don't have any closed job.",
+	"job.empty.declined":  "You don't have any declined job.",
+	"job.extended":        "✅ The due date of <b>%s</b> has been extended to %s",
+	"job.error.view":      "😳 Oops! unable to view job detail.",
+	"job.error.find":      "🙁 Oops! unable to find the job",
+	"job.error.extend":    "🙁 Oops! unable to extend the job",
+	"job.error.close":     "🙁 Oops! unable to close the job",
+	"job.status.pending":  "Pending",
+	"job.status.opened":   "Opened",
+	"job.status.closed":   "Closed",
+	"job.status.declined": "Declined",
+	"gender.male":         "Male",
+	"gender.female":       "Female",
+	"gender.both":         "Both",
+
+	// ----- Job post -----
+	"job.field.title":           "<b>Job Title</b>:  %s\n",
+	"job.field.employer":        "<b>Employer</b>:  %s\n",
+	"job.field.type":            "<b>Job Type</b>:  %s\n",
+	"job.field.gender":          "<b>Gender</b>:  %s\n",
+	"job.field.education_level": "<b>Education level</b>:  %s\n",
+	"job.field.experience":      "<b>Experience</b>:  %s\n",
+	"job.field.contact":         "<b>Contact</b>: %s\n\n",
+	"job.field.description":     "<b>Description</b>:  %s\n",
+
+	// ----- Job application -----
+	"button.cancel_application":   "🔙 Cancel Application",
+	"apply.send_cv":               "Send CV (*PDF format only)",
+	"apply.caption":               "------------- <b>Application</b> -------------\n\n<b>Job Title</b>:  %s\n\n<b>Description</b>:  %s\n\n",
+	"apply.sent":                  "🎉 Your application has been sent to the employer. Good Luck!",
+	"apply.error.already_applied": "❌ You have already applied for the job",
+	"apply.error.pdf_only":        "Please send *.pdf file only",
+	"apply.error.apply":           "🙁 Unable to apply for the job",
+	"apply.error.send":            "😳 Oops! something went wrong, re-apply again.",
+	"apply.error.closed":          "🙁 Sorry the job has been closed",
+	"apply.error.own_job":         "❌ You can't apply for your own job",
+
+	// ----- Job posting -----
+	"post.enter_title":            "Enter the job title",
+	"post.enter_description":      "Enter the job description",
+	"post.choose_sectors":         "Select the job sectors",
+	"post.choose_sectors.hint":    "<b>Select one or more sectors and press Next</b>",
+	"post.choose_types":           "Select the job types",
+	"post.choose_types.hint":      "<b>Select one or more types and press Next</b>",
+	"post.choose_education_level": "<b>Select the required education level</b>",
+	"post.choose_experience":      "<b>Select the required work experience</b>",
+	"post.choose_gender":          "<b>Select the gender required for the job</b>",
+	"post.choose_contact_type":    "<b>How should applicants contact you?</b>",
+	"post.enter_due_date":         "Enter the due date of the job as dd/mm/yyyy hh:mm, e.g. 25/12/2021 17:30\nor skip if the job doesn't have a due date",
+	"post.next":                   "➡️ Next",
+	"post.preview":                "Preview of your job",
+	"post.preview.detail":         "<b>Job Title</b>:  %s\n\n<b>Job Type</b>:  %s\n<b>Gender</b>:  %s\n<b>Education level</b>:  %s\n<b>Experience</b>:  %s\n<b>Contact Type</b>:  %s\n<b>Due Date</b>:  %s\n\n<b>Description</b>:  %s\n\n#%s",
+	"post.no_due_date":            "None",
+	"post.edit":                   "✏️ Edit",
+	"post.submit":                 "✅ Submit",
+	"post.choose_edit":            "Select the entry you want to change",
+	"post.entry.title":            "Title",
+	"post.entry.description":      "Description",
+	"post.entry.sectors":          "Sectors",
+	"post.entry.types":            "Types",
+	"post.entry.education_level":  "Education Level",
+	"post.entry.experience":       "Experience",
+	"post.entry.gender":           "Gender",
+	"post.entry.contact_type":     "Contact Type",
+	"post.entry.due_date":         "Due Date",
+	"post.web_form":               "Or post the job using the web form",
+	"post.web_form.open":          "🌐 Web Form",
+	"post.submitted":              "✅ Your job has been submitted, you will be notified once it has been reviewed",
+	"post.error.job_seeker":       "🙁 Oops! Can't perform operation for job seeker.",
+	"post.error.start":            "🙁 Oops! unable to start posting a job",
+	"post.error.expired":          "🙁 Oops! your job draft has expired, please start again",
+	"post.error.due_date":         "🙁 Invalid due date, please use the dd/mm/yyyy hh:mm format. e.g. 25/12/2021 17:30",
+	"post.error.save":             "🙁 Oops! unable to save the job draft",
+	"post.error.correct":          "🙁 Please correct the following entries before submitting\n\n",
+	"post.error.submit":           "🙁 Oops! unable to submit the job",
+
+	// ----- Job subscriptions -----
+	"subscription.choose_option":               "Choose option",
+	"button.add_subscription":                  "➕ Add Subscription",
+	"button.edit_subscriptions":                "📝 Edit Subscriptions",
+	"subscription.empty":                       "You haven't subscribed for a job! please subscribe using the add subscription button.",
+	"subscription.detail":                      "<b>Job Subscription</b>\n\n<b>Job Type</b>:  %s\n<b>Sector</b>:  %s\n<b>Education Level</b>:  %s\n<b>Experience</b>:  %s\n\n",
+	"subscription.remove":                      "🗑️ Remove",
+	"subscription.removed":                     "<b>Job Subscription</b>\n\n<b>Job Type</b>:  %s\n<b>Sector</b>:  %s\n\n",
+	"subscription.banner.removed":              "------------- <b>Removed</b> -------------\n\n",
+	"subscription.choose_sector":               "Select job sector",
+	"subscription.choose_sector.hint":          "<b>The following are the valid job sectors avaliable</b>",
+	"subscription.choose_type":                 "Select job type",
+	"subscription.choose_type.hint":            "<b>The following are the valid job types avaliable</b>",
+	"subscription.choose_education_level":      "Select education level",
+	"subscription.choose_education_level.hint": "<b>The following are the valid education levels avaliable</b>",
+	"subscription.choose_experience":           "Select work experience",
+	"subscription.choose_experience.hint":      "<b>The following are the valid work experiences avaliable</b>",
+	"subscription.added":                       "Congratulations 🎉 you have successfully added new job subscription!",
+	"subscription.error.sector":                "❌ Error unable to add job subscription sector!",
+	"subscription.error.type":                  "❌ Error unable to add job subscription type!",
+	"subscription.error.education_level":       "❌ Error unable to add education level for the job subscription!",
+	"subscription.error.add":                   "❌ Error unable to add job subscription!",
+	"subscription.error.remove":                "🙁 Oops! unable to remove the subscription",
+	"subscription.error.expired":               "Oops 😳 something terribly went wrong!",
+
+	// ----- Applications -----
+	"common.invalid_action":              "Invalid action!",
+	"common.page":                        "Page %d of %d",
+	"common.prev":                        "⬅️ Prev",
+	"common.next":                        "Next ➡️",
+	"job.error.not_found":                "Job not found!",
+	"application.status.submitted":       "Submitted",
+	"application.status.viewed":          "Viewed",
+	"application.status.shortlisted":     "Shortlisted",
+	"application.status.rejected":        "Rejected",
+	"application.status.hired":           "Hired",
+	"application.changed.shortlisted":    "The application has been shortlisted",
+	"application.changed.rejected":       "The application has been rejected",
+	"application.changed.hired":          "The application has been hired",
+	"application.job_details":            "👀 Job Details",
+	"application.shortlist":              "⭐️ Shortlist",
+	"application.reject":                 "❌ Reject",
+	"application.hire":                   "🤝 Hire",
+	"application.message":                "✉️ Message",
+	"application.write_message":          "Write your message to the applicant",
+	"application.employer_message":       "📩 <b>Message from the employer</b>\n\n<b>Job Title</b>:  %s\n\n%s",
+	"application.message_sent":           "Your message has been sent to the applicant.",
+	"application.update.subject":         "Application Update: %s",
+	"application.update.message":         "------------- <b>Application Update</b> -------------\n\n<b>Job Title</b>:  %s\n\n<b>Status</b>:  %s\n\n%s",
+	"application.update.summary":         "Asseri: your application for %s is %s. %s",
+	"application.update.viewed":          "👀 The employer has viewed your application.",
+	"application.update.shortlisted":     "🌟 Congratulations! You have been shortlisted, the employer might contact you soon.",
+	"application.update.rejected":        "🙁 Unfortunately the employer has decided not to move forward with your application.",
+	"application.update.hired":           "🎉 Congratulations! You have been hired.",
+	"application.empty":                  "You haven't applied for any job yet.",
+	"application.list.title":             "------------- <b>My Applications</b> -------------\n\n",
+	"application.list.entry":             "%d. <b>%s</b>\n<b>Job Status</b>:  %s\n<b>Application</b>:  %s\n\n",
+	"application.withdraw":               "🗑 Withdraw %d",
+	"application.withdrawn":              "The application has been withdrawn",
+	"application.error.withdrawn":        "The application has been withdrawn!",
+	"application.error.not_found":        "Application not found!",
+	"application.error.not_withdrawable": "The application can't be withdrawn!",
+	"application.error.withdraw":         "Unable to withdraw the application!",
+	"application.error.update":           "Unable to update the application!",
+	"application.error.message":          "🙁 Unable to send the message",
+	"application.error.unreachable":      "🙁 The applicant can't be reached at the moment",
+
+	// ----- Applicants -----
+	"applicants.list":           "👥 Applicants (%d)",
+	"applicants.empty":          "There aren't any applicants yet!",
+	"applicants.title":          "------------- <b>Applicants</b> -------------\n\n<b>Job Title</b>:  %s\n\n",
+	"applicants.entry":          "%d. <b>%s</b>\n<b>Phone</b>:  %s\n<b>Status</b>:  %s\n\n",
+	"applicants.cv":             "📎 CV %d",
+	"applicants.cv.caption":     "------------- <b>Application</b> -------------\n\n<b>Job Title</b>:  %s\n\n<b>Applicant</b>:  %s\n\n",
+	"applicants.export":         "📊 Export CSV",
+	"applicants.export.caption": "<b>Applicants</b> of %s",
+	"applicants.error.export":   "🙁 Unable to export the applicants",
+	"applicants.error.no_cv":    "The cv of this application isn't available!",
+	"applicants.error.send_cv":  "Unable to send the cv!",
+
+	// ----- Feedback -----
+	"feedback.prompt":          "Please write your feedback in concise and short way",
+	"feedback.reenter":         "Re-enter your feedback",
+	"feedback.received":        "Thank you 😁 for your feedback! We will do our best to satisfy your requests.",
+	"feedback.reply":           "💬 <b>Reply to your feedback</b>\n\n<i>%s</i>\n\n%s",
+	"feedback.reply.answer":    "↩️ Reply",
+	"feedback.reply.close":     "✅ Close",
+	"feedback.write_reply":     "Please write your reply",
+	"feedback.reply_sent":      "Your reply has been sent, we will get back to you soon.",
+	"feedback.closed":          "The conversation has been closed, thank you 😁 for your feedback!",
+	"feedback.error.add":       "❌ Error unable to add your feedback!",
+	"feedback.error.reply":     "❌ Error unable to add your reply!",
+	"feedback.error.not_found": "Feedback not found!",
+	"feedback.error.closed":    "This conversation has already been closed!",
+	"feedback.error.close":     "Unable to close the conversation!",
+
+	// ----- Notifications -----
+	"button.set_email":             "📧 Set Email",
+	"notification.choose_fallback": "Notifications are sent here on telegram, choose where to be notified when you can't be reached here.",
+	"notification.settings":        "<b>Email</b>:   %s\n<b>Fallback</b>:   %s",
+	"notification.fallback.sms":    "SMS",
+	"notification.fallback.email":  "Email",
+	"notification.fallback.none":   "None",
+	"notification.enter_email":     "Enter your email address",
+	"notification.email_saved":     "Your email address has been saved.",
+	"notification.error.no_email":  "Set your email address first",
+	"notification.error.save":      "unable to save notification preferences",
+
+	// ----- Moderation -----
+	"moderation.post.title":             "------------- <b>Pending Review</b> -------------\n\n",
+	"moderation.post.job_id":            "<b>Job ID</b>:  %s\n",
+	"moderation.post.due_date":          "<b>Due Date</b>:  %s\n",
+	"moderation.approve":                "✅ Approve",
+	"moderation.decline":                "❌ Decline",
+	"moderation.request_changes.button": "✏️ Request Changes",
+	"moderation.reason_prompt.decline":  "%s, reply to this message with the reason for declining <b>%s</b>",
+	"moderation.reason_prompt.changes":  "%s, reply to this message with the changes requested for <b>%s</b>",
+	"moderation.approved":               "✅ The job has been approved",
+	"moderation.declined":               "❌ The job has been declined",
+	"moderation.changes_requested":      "✏️ The employer has been asked to change the job",
+	"moderation.request_changes":        "✏️ Your job <b>%s</b> needs some changes before it can be approved\n\n<b>Reason</b>:  %s\n\nPlease post the job again with the requested changes",
+	"moderation.outcome.approved":       "✅ <b>Approved</b> by %s",
+	"moderation.outcome.declined":       "❌ <b>Declined</b> by %s\n<b>Reason</b>:  %s",
+	"moderation.outcome.changes":        "✏️ <b>Changes requested</b> by %s\n<b>Reason</b>:  %s",
+	"moderation.error.staff_only":       "🚫 Only staff members can moderate jobs",
+	"moderation.error.reviewed":         "🙁 This job has already been reviewed",
+	"moderation.error.prompt":           "🙁 Oops! unable to perform operation",
+	"moderation.error.reason":           "🙁 Please reply with a text message as the reason",
+	"moderation.error.moderate.approve": "🙁 Oops! unable to approve the job, %s",
+	"moderation.error.moderate.decline": "🙁 Oops! unable to decline the job, %s",
+	"moderation.error.moderate.changes": "🙁 Oops! unable to request changes to the job, %s",
+	"moderation.error.notify":           "🙁 Oops! unable to notify the employer, %s",
+
+	// ----- Job notifications -----
+	"job.apply":                   "🔗 Apply",
+	"job.view_details":            "Tap to view details",
+	"job.banner.approved":         "------------- <b>Approved</b> -------------\n\n",
+	"job.banner.subscription":     "------------- <b>Subscription</b> -------------\n\n",
+	"job.result.reason":           "<b>Reason</b>:  %s\n\n",
+	"job.result.subject.approved": "Job Approved: %s",
+	"job.result.subject.declined": "Job Declined: %s",
+	"job.result.subject.closed":   "Job Closed: %s",
+	"job.result.summary.approved": "Asseri: your job post %s has been approved.",
+	"job.result.summary.declined": "Asseri: your job post %s has been declined.",
+	"job.result.summary.closed":   "Asseri: your job post %s has been closed.",
+	"job.result.summary.reason":   " Reason: %s",
+	"reminder.due_date":           "------------- <b>Due Date Reminder</b> -------------\n\n<b>Job Title</b>:  %s\n\n<b>Job Type</b>:  %s\n<b>Due Date</b>:  %s\n<b>Applications</b>:  %d\n\nThe job will be closed automatically once the due date has passed.\n\n",
+	"reminder.extend":             "⏳ Extend 7 days",
+	"reminder.close":              "🔒 Close now",
+
+	// ----- Validation -----
+	"validation.invalid":               "Invalid entry, please check it and try again",
+	"validation.user_name":             "The name should have at least one character, contain only letters and numbers and not be longer than 255 characters",
+	"validation.phone_number":          "The phone number is invalid or has already been registered",
+	"validation.category":              "Invalid category selected",
+	"validation.email":                 "Invalid email address used",
+	"validation.notification_fallback": "Invalid notification channel, email notifications require an email address",
+	"validation.language":              "Invalid language selected",
+	"validation.employer":              "The job can't be posted from this account",
+	"validation.title":                 "The job title can't be empty or longer than 300 characters",
+	"validation.description":           "The job description can't be empty or longer than 2000 characters",
+	"validation.link":                  "The link is invalid or longer than 1000 characters",
+	"validation.contact_info":          "Contact info must be specified",
+	"validation.type":                  "Invalid job type used",
+	"validation.sector":                "Invalid job sector used",
+	"validation.education_level":       "Invalid education level used",
+	"validation.experience":            "Invalid work experience used",
+	"validation.contact_type":          "Invalid contact type selected",
+	"validation.gender":                "Invalid gender selected",
+	"validation.due_date":              "The due date must be at least 3 hours from now",
+	"validation.subscription":          "You have already subscribed for the same jobs",
+	"validation.comment":               "The feedback can't be empty or longer than 1000 characters",
+	"validation.message":               "The message can't be empty or longer than 1000 characters",
+}
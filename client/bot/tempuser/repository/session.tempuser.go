@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/tempuser"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// TempUserRepository is a type that defines a temporary user repository type,
+// a temporary user is kept in the registering chat's session so an abandoned registration expires by itself
+type TempUserRepository struct {
+	sessions tools.ISessionStore
+}
+
+// NewTempUserRepository is a function that creates a new temporary user repository type
+func NewTempUserRepository(sessionStore tools.ISessionStore) tempuser.ITempUserRepository {
+	return &TempUserRepository{sessions: sessionStore}
+}
+
+// Create is a method that adds a new temporary user to the session of its chat
+func (repo *TempUserRepository) Create(newTempUser *bot.TempUser) error {
+
+	newTempUser.CreatedAt = time.Now()
+	newTempUser.UpdatedAt = newTempUser.CreatedAt
+
+	return repo.sessions.Save(newTempUser.TelegramID, bot.PayloadRegistration, newTempUser)
+}
+
+// Find is a method that finds a certain temporary user from the session store using its telegram_id
+func (repo *TempUserRepository) Find(identifier string) (*bot.TempUser, error) {
+
+	tempUser := new(bot.TempUser)
+	err := repo.sessions.Load(identifier, bot.PayloadRegistration, tempUser)
+	if err != nil {
+		return nil, err
+	}
+	return tempUser, nil
+}
+
+// Update is a method that updates a certain temporary user entries in the session store
+func (repo *TempUserRepository) Update(tempUser *bot.TempUser) error {
+
+	prevTempUser, err := repo.Find(tempUser.TelegramID)
+	if err != nil {
+		return err
+	}
+
+	/* --------------------------- can change layer if needed --------------------------- */
+	tempUser.CreatedAt = prevTempUser.CreatedAt
+	tempUser.UpdatedAt = time.Now()
+	/* -------------------------------------- end --------------------------------------- */
+
+	return repo.sessions.Save(tempUser.TelegramID, bot.PayloadRegistration, tempUser)
+}
+
+// UpdateValue is a method that updates a certain temporary user single column value in the session store
+func (repo *TempUserRepository) UpdateValue(tempUser *bot.TempUser, columnName string, columnValue interface{}) error {
+
+	prevTempUser, err := repo.Find(tempUser.TelegramID)
+	if err != nil {
+		return err
+	}
+
+	switch columnName {
+	case "user_name":
+		prevTempUser.UserName, _ = columnValue.(string)
+	case "phone_number":
+		prevTempUser.PhoneNumber, _ = columnValue.(string)
+	case "category":
+		prevTempUser.Category, _ = columnValue.(string)
+	case "language":
+		prevTempUser.Language, _ = columnValue.(string)
+	case "status":
+		prevTempUser.Status, _ = columnValue.(int64)
+	default:
+		return errors.New("unknown temporary user column")
+	}
+
+	return repo.Update(prevTempUser)
+}
+
+// Delete is a method that deletes a certain temporary user from the session store using its telegram_id
+func (repo *TempUserRepository) Delete(identifier string) (*bot.TempUser, error) {
+
+	tempUser, err := repo.Find(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	repo.sessions.Clear(identifier, bot.PayloadRegistration)
+	return tempUser, nil
+}
@@ -93,23 +93,9 @@ func (service *Service) ValidateTempUserProfile(tempUser *bot.TempUser) entity.E
 		errMap["category"] = errors.New("invalid category selected")
 	}
 
-	// Checking if the temporary user exists
-	prevProfile, err := service.tempUserRepo.Find(tempUser.TelegramID)
-
-	// Meaning a new temporary user is being added
-	if err != nil {
-		if validPhoneNumber && (!service.commonRepo.IsUnique("phone_number", tempUser.PhoneNumber, "users") ||
-			!service.commonRepo.IsUnique("phone_number", tempUser.PhoneNumber, "temp_users")) {
-			errMap["phone_number"] = errors.New("phone number already exists")
-		}
-	} else {
-
-		if validPhoneNumber && prevProfile.PhoneNumber != tempUser.PhoneNumber {
-			if !service.commonRepo.IsUnique("phone_number", tempUser.PhoneNumber, "users") ||
-				!service.commonRepo.IsUnique("phone_number", tempUser.PhoneNumber, "temp_users") {
-				errMap["phone_number"] = errors.New("phone number already exists")
-			}
-		}
+	// Temporary users are kept per chat so only the registered users can hold the phone number
+	if validPhoneNumber && !service.commonRepo.IsUnique("phone_number", tempUser.PhoneNumber, "users") {
+		errMap["phone_number"] = errors.New("phone number already exists")
 	}
 
 	if len(errMap) > 0 {
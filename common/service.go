@@ -1,6 +1,10 @@
 package common
 
-import "github.com/Benyam-S/asseri/entity"
+import (
+	"time"
+
+	"github.com/Benyam-S/asseri/entity"
+)
 
 // IService is an interface that defines all the common service methods
 type IService interface {
@@ -26,9 +30,14 @@ type IService interface {
 	GetValidContactTypes() []string
 }
 
-// IPushQueue is an interface all the method required from push queue struct
+// IPushQueue is an interface all the method required from push queue struct.
+// A claimed request stays invisible to other consumers until it is acknowledged, released or its visibility timeout passes.
 type IPushQueue interface {
-	GetQueue() []*entity.ChannelRequest
-	AddToQueue(request *entity.ChannelRequest)
-	RemoveFromQueueWithIndex(index int)
+	AddToQueue(request *entity.ChannelRequest) error
+	Claim() (*entity.QueuedRequest, error)
+	Ack(id string) error
+	Release(id string, delay time.Duration) error
+	Postpone(id string, delay time.Duration) error
+	DeadLetters() ([]*entity.QueuedRequest, error)
+	Len() int64
 }
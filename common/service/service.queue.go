@@ -1,33 +1,156 @@
 package service
 
 import (
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/Benyam-S/asseri/common"
 	"github.com/Benyam-S/asseri/entity"
 )
 
-// PushQueue is a struct that holds all the requests that needs to be pushed
+// PushQueue is a struct that holds all the requests that needs to be pushed in memory.
+// It behaves like the redis push queue but loses every pending request on restart.
 type PushQueue struct {
-	Queue []*entity.ChannelRequest
+	mutex             sync.Mutex
+	requests          map[string]*entity.QueuedRequest
+	pending           []string
+	processing        map[string]time.Time
+	dead              []string
+	nextID            int64
+	visibilityTimeout time.Duration
+	maxAttempts       int64
 }
 
-// NewPushQueue is a function that returns a new push queue
-func NewPushQueue() common.IPushQueue {
-	return &PushQueue{Queue: make([]*entity.ChannelRequest, 0)}
+// NewPushQueue is a function that returns a new in memory push queue
+func NewPushQueue(visibilityTimeout time.Duration, maxAttempts int64) common.IPushQueue {
+	return &PushQueue{requests: make(map[string]*entity.QueuedRequest), pending: make([]string, 0),
+		processing: make(map[string]time.Time), dead: make([]string, 0),
+		visibilityTimeout: visibilityTimeout, maxAttempts: maxAttempts}
 }
 
-// GetQueue is a method that returns all the elements inside the push queue
-func (pq *PushQueue) GetQueue() []*entity.ChannelRequest {
-	return pq.Queue
+// AddToQueue is a method that adds new request to the push queue
+func (pq *PushQueue) AddToQueue(request *entity.ChannelRequest) error {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	pq.nextID++
+	id := strconv.FormatInt(pq.nextID, 10)
+	pq.requests[id] = &entity.QueuedRequest{ID: id, Request: request}
+	pq.pending = append(pq.pending, id)
+	return nil
 }
 
-// AddToQueue is a method that adds new request to the push queue
-func (pq *PushQueue) AddToQueue(request *entity.ChannelRequest) {
-	pq.Queue = append(pq.Queue, request)
+// Claim is a method that claims the oldest pending request, it returns nil if there is no pending request
+func (pq *PushQueue) Claim() (*entity.QueuedRequest, error) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	pq.requeueExpired()
+
+	if len(pq.pending) == 0 {
+		return nil, nil
+	}
+
+	id := pq.pending[0]
+	pq.pending = pq.pending[1:]
+	pq.processing[id] = time.Now().Add(pq.visibilityTimeout)
+
+	queued := pq.requests[id]
+	queued.Attempts++
+	return &entity.QueuedRequest{ID: id, Attempts: queued.Attempts, Request: queued.Request}, nil
+}
+
+// Ack is a method that removes a claimed request from the push queue after it has been handled
+func (pq *PushQueue) Ack(id string) error {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	delete(pq.processing, id)
+	delete(pq.requests, id)
+
+	// The claim might have expired and been requeued
+	for i, pendingID := range pq.pending {
+		if pendingID == id {
+			pq.pending = append(pq.pending[:i], pq.pending[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// Release is a method that returns a claimed request to the push queue so it can be retried after the given delay.
+// A request that has reached the max attempt count is moved to the dead letter list instead.
+func (pq *PushQueue) Release(id string, delay time.Duration) error {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	if _, ok := pq.processing[id]; !ok {
+		return nil
+	}
+
+	if pq.requests[id].Attempts >= pq.maxAttempts {
+		delete(pq.processing, id)
+		pq.dead = append(pq.dead, id)
+		return nil
+	}
+
+	pq.processing[id] = time.Now().Add(delay)
+	return nil
+}
+
+// Postpone is a method that returns a claimed request to the push queue after the given delay without counting
+// the claim as a failed attempt, used when the request couldn't be tried at all
+func (pq *PushQueue) Postpone(id string, delay time.Duration) error {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	if _, ok := pq.processing[id]; !ok {
+		return nil
+	}
+
+	pq.requests[id].Attempts--
+	pq.processing[id] = time.Now().Add(delay)
+	return nil
 }
 
-// RemoveFromQueueWithIndex is a method that removes an request from the push queue using an index
-func (pq *PushQueue) RemoveFromQueueWithIndex(index int) {
-	if len(pq.Queue) > index {
-		pq.Queue = append(pq.Queue[:index], pq.Queue[index+1:]...)
+// DeadLetters is a method that returns all the requests that have failed the max attempt count
+func (pq *PushQueue) DeadLetters() ([]*entity.QueuedRequest, error) {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	deadLetters := make([]*entity.QueuedRequest, 0)
+	for _, id := range pq.dead {
+		queued := pq.requests[id]
+		deadLetters = append(deadLetters, &entity.QueuedRequest{ID: id, Attempts: queued.Attempts,
+			Request: queued.Request})
+	}
+
+	return deadLetters, nil
+}
+
+// Len is a method that returns the number of pending and claimed requests
+func (pq *PushQueue) Len() int64 {
+	pq.mutex.Lock()
+	defer pq.mutex.Unlock()
+
+	return int64(len(pq.pending) + len(pq.processing))
+}
+
+// requeueExpired is a method that moves every claim whose visibility timeout has passed back to the pending requests
+func (pq *PushQueue) requeueExpired() {
+	now := time.Now()
+	for id, deadline := range pq.processing {
+		if deadline.After(now) {
+			continue
+		}
+
+		delete(pq.processing, id)
+		if pq.requests[id].Attempts >= pq.maxAttempts {
+			pq.dead = append(pq.dead, id)
+		} else {
+			pq.pending = append([]string{id}, pq.pending...)
+		}
 	}
 }
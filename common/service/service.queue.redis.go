@@ -0,0 +1,216 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Benyam-S/asseri/common"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/go-redis/redis"
+)
+
+// enqueueScript stores the request and pushes its id to the pending list
+// KEYS: sequence, requests, pending | ARGV: request
+var enqueueScript = redis.NewScript(`
+local id = tostring(redis.call('INCR', KEYS[1]))
+redis.call('HSET', KEYS[2], id, ARGV[1])
+redis.call('LPUSH', KEYS[3], id)
+return id
+`)
+
+// claimScript requeues the expired claims and then claims the oldest pending request
+// KEYS: pending, processing, requests, attempts, dead | ARGV: now (ms), visibility timeout (ms), max attempts
+var claimScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1])
+for _, id in ipairs(expired) do
+	redis.call('ZREM', KEYS[2], id)
+	if tonumber(redis.call('HGET', KEYS[4], id) or '0') >= tonumber(ARGV[3]) then
+		redis.call('RPUSH', KEYS[5], id)
+	else
+		redis.call('RPUSH', KEYS[1], id)
+	end
+end
+
+local id = redis.call('RPOP', KEYS[1])
+if not id then
+	return false
+end
+
+local attempts = redis.call('HINCRBY', KEYS[4], id, 1)
+redis.call('ZADD', KEYS[2], tonumber(ARGV[1]) + tonumber(ARGV[2]), id)
+return {id, redis.call('HGET', KEYS[3], id), attempts}
+`)
+
+// ackScript removes a request from the queue wherever it is
+// KEYS: pending, processing, requests, attempts | ARGV: id
+var ackScript = redis.NewScript(`
+redis.call('ZREM', KEYS[2], ARGV[1])
+redis.call('LREM', KEYS[1], 0, ARGV[1])
+redis.call('HDEL', KEYS[3], ARGV[1])
+redis.call('HDEL', KEYS[4], ARGV[1])
+return 1
+`)
+
+// releaseScript makes a claimed request visible again after the delay or dead letters it
+// KEYS: processing, attempts, dead | ARGV: id, visible at (ms), max attempts
+var releaseScript = redis.NewScript(`
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+
+if tonumber(redis.call('HGET', KEYS[2], ARGV[1]) or '0') >= tonumber(ARGV[3]) then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+	redis.call('RPUSH', KEYS[3], ARGV[1])
+else
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+end
+return 1
+`)
+
+// postponeScript makes a claimed request visible again after the delay without counting the claim as an attempt
+// KEYS: processing, attempts | ARGV: id, visible at (ms)
+var postponeScript = redis.NewScript(`
+if not redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+	return 0
+end
+
+redis.call('HINCRBY', KEYS[2], ARGV[1], -1)
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+return 1
+`)
+
+// RedisPushQueue is a struct that holds all the requests that needs to be pushed in a redis database.
+// Every operation is a single lua script so producers and consumers can safely run concurrently.
+type RedisPushQueue struct {
+	client            *redis.Client
+	name              string
+	visibilityTimeout time.Duration
+	maxAttempts       int64
+}
+
+// NewRedisPushQueue is a function that returns a new push queue that keeps its requests under the given name in redis
+func NewRedisPushQueue(redisClient *redis.Client, name string, visibilityTimeout time.Duration,
+	maxAttempts int64) common.IPushQueue {
+	return &RedisPushQueue{client: redisClient, name: name, visibilityTimeout: visibilityTimeout,
+		maxAttempts: maxAttempts}
+}
+
+// AddToQueue is a method that adds new request to the push queue
+func (pq *RedisPushQueue) AddToQueue(request *entity.ChannelRequest) error {
+
+	requestS, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return enqueueScript.Run(pq.client, []string{pq.key("sequence"), pq.key("requests"), pq.key("pending")},
+		string(requestS)).Err()
+}
+
+// Claim is a method that claims the oldest pending request, it returns nil if there is no pending request
+func (pq *RedisPushQueue) Claim() (*entity.QueuedRequest, error) {
+
+	result, err := claimScript.Run(pq.client, []string{pq.key("pending"), pq.key("processing"),
+		pq.key("requests"), pq.key("attempts"), pq.key("dead")},
+		milliseconds(time.Now()), pq.visibilityTimeout.Milliseconds(), pq.maxAttempts).Result()
+
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 3 {
+		return nil, errors.New("unexpected push queue claim result")
+	}
+
+	id, _ := values[0].(string)
+	requestS, _ := values[1].(string)
+	attempts, _ := values[2].(int64)
+
+	request := new(entity.ChannelRequest)
+	err = json.Unmarshal([]byte(requestS), request)
+	if err != nil {
+		// A request that can't be decoded will never succeed so it shouldn't block the queue
+		pq.Ack(id)
+		return nil, errors.New("unable to parse queued push request " + id)
+	}
+
+	return &entity.QueuedRequest{ID: id, Attempts: attempts, Request: request}, nil
+}
+
+// Ack is a method that removes a claimed request from the push queue after it has been handled
+func (pq *RedisPushQueue) Ack(id string) error {
+	return ackScript.Run(pq.client, []string{pq.key("pending"), pq.key("processing"), pq.key("requests"),
+		pq.key("attempts")}, id).Err()
+}
+
+// Release is a method that returns a claimed request to the push queue so it can be retried after the given delay.
+// A request that has reached the max attempt count is moved to the dead letter list instead.
+func (pq *RedisPushQueue) Release(id string, delay time.Duration) error {
+	return releaseScript.Run(pq.client, []string{pq.key("processing"), pq.key("attempts"), pq.key("dead")},
+		id, milliseconds(time.Now().Add(delay)), pq.maxAttempts).Err()
+}
+
+// Postpone is a method that returns a claimed request to the push queue after the given delay without counting
+// the claim as a failed attempt, used when the request couldn't be tried at all
+func (pq *RedisPushQueue) Postpone(id string, delay time.Duration) error {
+	return postponeScript.Run(pq.client, []string{pq.key("processing"), pq.key("attempts")},
+		id, milliseconds(time.Now().Add(delay))).Err()
+}
+
+// DeadLetters is a method that returns all the requests that have failed the max attempt count
+func (pq *RedisPushQueue) DeadLetters() ([]*entity.QueuedRequest, error) {
+
+	deadLetters := make([]*entity.QueuedRequest, 0)
+
+	ids, err := pq.client.LRange(pq.key("dead"), 0, -1).Result()
+	if err != nil || len(ids) == 0 {
+		return deadLetters, err
+	}
+
+	requests, err := pq.client.HMGet(pq.key("requests"), ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := pq.client.HMGet(pq.key("attempts"), ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		requestS, _ := requests[i].(string)
+		attemptsS, _ := attempts[i].(string)
+
+		request := new(entity.ChannelRequest)
+		json.Unmarshal([]byte(requestS), request)
+		attemptCount, _ := strconv.ParseInt(attemptsS, 10, 64)
+
+		deadLetters = append(deadLetters, &entity.QueuedRequest{ID: id, Attempts: attemptCount, Request: request})
+	}
+
+	return deadLetters, nil
+}
+
+// Len is a method that returns the number of pending and claimed requests
+func (pq *RedisPushQueue) Len() int64 {
+	pending, _ := pq.client.LLen(pq.key("pending")).Result()
+	processing, _ := pq.client.ZCard(pq.key("processing")).Result()
+	return pending + processing
+}
+
+// key is a method that returns the redis key of one of the push queue structures
+func (pq *RedisPushQueue) key(structure string) string {
+	return pq.name + ":" + structure
+}
+
+// milliseconds is a function that returns the given time as unix milliseconds
+func milliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
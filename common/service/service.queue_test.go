@@ -0,0 +1,280 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Benyam-S/asseri/common"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/go-redis/redis"
+)
+
+// testVisibilityTimeout is the visibility timeout of the push queues used by the tests
+const testVisibilityTimeout = time.Millisecond * 100
+
+// testMaxAttempts is the max attempt count of the push queues used by the tests
+const testMaxAttempts = 3
+
+// newTestQueue is a type that defines a function that returns a new empty push queue
+type newTestQueue func(t *testing.T) common.IPushQueue
+
+// pushQueues is a function that returns the push queue implementations every test runs against.
+// The redis push queue is only tested when ASSERI_TEST_REDIS_ADDRESS points to a reachable redis server.
+func pushQueues() map[string]newTestQueue {
+	return map[string]newTestQueue{
+		"memory": func(t *testing.T) common.IPushQueue {
+			return NewPushQueue(testVisibilityTimeout, testMaxAttempts)
+		},
+		"redis": func(t *testing.T) common.IPushQueue {
+			address := os.Getenv("ASSERI_TEST_REDIS_ADDRESS")
+			if address == "" {
+				t.Skip("ASSERI_TEST_REDIS_ADDRESS is not set")
+			}
+
+			client := redis.NewClient(&redis.Options{Addr: address})
+			if err := client.Ping().Err(); err != nil {
+				t.Skipf("redis is not reachable at %s, %s", address, err)
+			}
+
+			name := fmt.Sprintf("test_push_queue_%d", time.Now().UnixNano())
+			t.Cleanup(func() {
+				keys, _ := client.Keys(name + ":*").Result()
+				if len(keys) > 0 {
+					client.Del(keys...)
+				}
+				client.Close()
+			})
+
+			return NewRedisPushQueue(client, name, testVisibilityTimeout, testMaxAttempts)
+		},
+	}
+}
+
+// addRequests is a function that adds requests with the given values to a push queue
+func addRequests(t *testing.T, pq common.IPushQueue, values ...string) {
+	t.Helper()
+
+	for _, value := range values {
+		if err := pq.AddToQueue(&entity.ChannelRequest{Type: "job", Value: value}); err != nil {
+			t.Fatalf("unable to add %s to the queue, %s", value, err)
+		}
+	}
+}
+
+// claim is a function that claims a request from a push queue and checks its value and attempt count,
+// an empty value expects no request to be claimed
+func claim(t *testing.T, pq common.IPushQueue, value string, attempts int64) *entity.QueuedRequest {
+	t.Helper()
+
+	queued, err := pq.Claim()
+	if err != nil {
+		t.Fatalf("unable to claim a request, %s", err)
+	}
+
+	if value == "" {
+		if queued != nil {
+			t.Fatalf("expected no request to be claimed, got %s", queued.Request.Value)
+		}
+		return nil
+	}
+
+	if queued == nil {
+		t.Fatalf("expected %s to be claimed, got nothing", value)
+	}
+
+	if queued.Request.Value != value || queued.Attempts != attempts {
+		t.Fatalf("expected %s to be claimed with %d attempts, got %s with %d attempts",
+			value, attempts, queued.Request.Value, queued.Attempts)
+	}
+
+	return queued
+}
+
+func TestPushQueueClaimAckRelease(t *testing.T) {
+	for name, newQueue := range pushQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue(t)
+			addRequests(t, pq, "JB-1", "JB-2", "JB-3")
+
+			// Requests are claimed from the oldest
+			first := claim(t, pq, "JB-1", 1)
+			if err := pq.Ack(first.ID); err != nil {
+				t.Fatalf("unable to ack, %s", err)
+			}
+
+			if pq.Len() != 2 {
+				t.Errorf("expected 2 requests to be left, got %d", pq.Len())
+			}
+
+			// A released request is claimed again once its delay passes
+			second := claim(t, pq, "JB-2", 1)
+			pq.Release(second.ID, 0)
+			second = claim(t, pq, "JB-2", 2)
+
+			pq.Release(second.ID, time.Hour)
+			third := claim(t, pq, "JB-3", 1)
+			claim(t, pq, "", 0)
+
+			if pq.Len() != 2 {
+				t.Errorf("expected the claimed requests to be counted, got %d", pq.Len())
+			}
+
+			// Acking or releasing a request that isn't claimed changes nothing
+			pq.Ack(third.ID)
+			pq.Ack(third.ID)
+			pq.Release(third.ID, 0)
+			claim(t, pq, "", 0)
+
+			if pq.Len() != 1 {
+				t.Errorf("expected only the released request to be left, got %d", pq.Len())
+			}
+		})
+	}
+}
+
+func TestPushQueueVisibilityTimeout(t *testing.T) {
+	for name, newQueue := range pushQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue(t)
+			addRequests(t, pq, "JB-1", "JB-2")
+
+			claim(t, pq, "JB-1", 1)
+			claim(t, pq, "JB-2", 1)
+			claim(t, pq, "", 0)
+
+			// A claim that is neither acked nor released is handed out again after the visibility timeout
+			time.Sleep(testVisibilityTimeout * 2)
+			reclaimed := map[string]int64{}
+			for i := 0; i < 2; i++ {
+				queued, err := pq.Claim()
+				if err != nil || queued == nil {
+					t.Fatalf("expected an expired claim to be reclaimed, got %v", err)
+				}
+				reclaimed[queued.Request.Value] = queued.Attempts
+			}
+
+			if reclaimed["JB-1"] != 2 || reclaimed["JB-2"] != 2 {
+				t.Errorf("expected both requests to be reclaimed with 2 attempts, got %v", reclaimed)
+			}
+
+			claim(t, pq, "", 0)
+		})
+	}
+}
+
+func TestPushQueueDeadLetters(t *testing.T) {
+	for name, newQueue := range pushQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue(t)
+			addRequests(t, pq, "JB-1", "JB-2")
+
+			// A released request is dead lettered once it reaches the max attempt count
+			for attempts := int64(1); attempts <= testMaxAttempts; attempts++ {
+				queued := claim(t, pq, "JB-1", attempts)
+				pq.Release(queued.ID, 0)
+			}
+
+			// An expired claim is dead lettered the same way
+			for attempts := int64(1); attempts <= testMaxAttempts; attempts++ {
+				claim(t, pq, "JB-2", attempts)
+				time.Sleep(testVisibilityTimeout * 2)
+			}
+
+			claim(t, pq, "", 0)
+			if pq.Len() != 0 {
+				t.Errorf("expected no request to be left, got %d", pq.Len())
+			}
+
+			deadLetters, err := pq.DeadLetters()
+			if err != nil || len(deadLetters) != 2 {
+				t.Fatalf("expected 2 dead letters, got %d, %v", len(deadLetters), err)
+			}
+
+			for i, value := range []string{"JB-1", "JB-2"} {
+				if deadLetters[i].Request.Value != value || deadLetters[i].Attempts != testMaxAttempts {
+					t.Errorf("expected %s to be dead lettered with %d attempts, got %s with %d attempts",
+						value, testMaxAttempts, deadLetters[i].Request.Value, deadLetters[i].Attempts)
+				}
+			}
+		})
+	}
+}
+
+func TestPushQueuePostpone(t *testing.T) {
+	for name, newQueue := range pushQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue(t)
+			addRequests(t, pq, "JB-1")
+
+			// A postponed claim isn't counted as an attempt, so it is never dead lettered
+			for i := 0; i < testMaxAttempts*2; i++ {
+				queued := claim(t, pq, "JB-1", 1)
+				pq.Postpone(queued.ID, 0)
+			}
+
+			queued := claim(t, pq, "JB-1", 1)
+			pq.Postpone(queued.ID, time.Hour)
+			claim(t, pq, "", 0)
+
+			if deadLetters, _ := pq.DeadLetters(); len(deadLetters) != 0 || pq.Len() != 1 {
+				t.Errorf("expected the postponed request to be kept, got %d dead letters", len(deadLetters))
+			}
+
+			// Postponing a request that isn't claimed changes nothing
+			pq.Ack(queued.ID)
+			pq.Postpone(queued.ID, 0)
+			claim(t, pq, "", 0)
+		})
+	}
+}
+
+func TestPushQueueClaimConcurrently(t *testing.T) {
+	for name, newQueue := range pushQueues() {
+		t.Run(name, func(t *testing.T) {
+			pq := newQueue(t)
+
+			values := make([]string, 50)
+			for i := range values {
+				values[i] = fmt.Sprintf("JB-%d", i)
+			}
+			addRequests(t, pq, values...)
+
+			// Every request is handed to exactly one of the workers
+			var mutex sync.Mutex
+			var wg sync.WaitGroup
+			claimed := make(map[string]int)
+
+			for worker := 0; worker < 10; worker++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						queued, err := pq.Claim()
+						if err != nil || queued == nil {
+							return
+						}
+
+						mutex.Lock()
+						claimed[queued.Request.Value]++
+						mutex.Unlock()
+						pq.Ack(queued.ID)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if len(claimed) != len(values) {
+				t.Errorf("expected %d requests to be claimed, got %d", len(values), len(claimed))
+			}
+
+			for value, count := range claimed {
+				if count != 1 {
+					t.Errorf("expected %s to be claimed once, got %d", value, count)
+				}
+			}
+		})
+	}
+}
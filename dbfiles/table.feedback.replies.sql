@@ -0,0 +1,9 @@
+CREATE TABLE feedback_replies (
+    id VARCHAR(255) PRIMARY KEY UNIQUE NOT NULL,
+    feedback_id VARCHAR(255),
+    sender_id VARCHAR(255),
+    sender_type VARCHAR(255),
+    message TEXT,
+    created_at DATETIME,
+    FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -3,5 +3,7 @@ CREATE TABLE feedbacks (
     user_id VARCHAR(255),
     comment VARCHAR(255),
     seen BOOLEAN,
-    created_at DATETIME
-);
\ No newline at end of file
+    status VARCHAR(255) DEFAULT 'Open',
+    created_at DATETIME,
+    updated_at DATETIME
+);
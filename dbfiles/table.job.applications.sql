@@ -1,4 +1,8 @@
 CREATE TABLE job_applications (
     job_id VARCHAR(255) PRIMARY KEY,
-    job_seeker_id VARCHAR(255) PRIMARY KEY
-);
\ No newline at end of file
+    job_seeker_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(255),
+    cv_file_id VARCHAR(255),
+    created_at DATETIME,
+    updated_at DATETIME
+);
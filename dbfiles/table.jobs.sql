@@ -14,7 +14,11 @@ CREATE TABLE jobs (
     contact_info VARCHAR(255), 
     link TEXT,
     due_date DATETIME,
+    reminded_at DATETIME,
     post_type VARCHAR(255),
+    channel_message_id BIGINT DEFAULT 0,
+    reviewed_by VARCHAR(255),
+    review_note TEXT,
     created_at DATETIME,
     updated_at DATETIME
-);
\ No newline at end of file
+);
@@ -0,0 +1,8 @@
+CREATE TABLE passwords (
+    id VARCHAR(255) PRIMARY KEY UNIQUE NOT NULL,
+    password VARCHAR(255),
+    salt VARCHAR(255),
+    created_at DATETIME,
+    updated_at DATETIME,
+    FOREIGN KEY (id) REFERENCES staffs(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
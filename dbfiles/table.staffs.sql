@@ -0,0 +1,11 @@
+CREATE TABLE staffs (
+    id VARCHAR(255) PRIMARY KEY UNIQUE NOT NULL,
+    first_name VARCHAR(255),
+    last_name VARCHAR(255),
+    phone_number VARCHAR(255) UNIQUE NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    profile_pic VARCHAR(255),
+    role VARCHAR(255),
+    created_at DATETIME,
+    updated_at DATETIME
+);
@@ -3,6 +3,9 @@ CREATE TABLE users (
     user_name VARCHAR(255),
     category VARCHAR(255),
     phone_number VARCHAR(255) UNIQUE NOT NULL,
+    email VARCHAR(255),
+    notification_fallback VARCHAR(255),
+    language VARCHAR(255),
     created_at DATETIME,
     updated_at DATETIME
 );
\ No newline at end of file
@@ -27,6 +27,21 @@ const UserCategoryAny = "Any"
 // UserCategoryJobSeeker is a constant that holds the job seeker user category
 const UserCategoryJobSeeker = "JobSeeker"
 
+// NotificationFallbackSMS is a constant that states a user is notified by sms when telegram delivery fails
+const NotificationFallbackSMS = "SMS"
+
+// NotificationFallbackEmail is a constant that states a user is notified by email when telegram delivery fails
+const NotificationFallbackEmail = "Email"
+
+// NotificationFallbackNone is a constant that states a user is only notified through telegram
+const NotificationFallbackNone = "None"
+
+// LanguageEnglish is a constant that holds the language code of English
+const LanguageEnglish = "en"
+
+// LanguageAmharic is a constant that holds the language code of Amharic
+const LanguageAmharic = "am"
+
 // PostCategoryInternal is a constant that states the job is posted by internal staff member
 const PostCategoryInternal = "Internal"
 
@@ -51,12 +66,42 @@ const JobStatusDecelined = "D"
 // JobStatusAny is a constant that defines a job status to be of any type
 const JobStatusAny = "Any"
 
+// JobApplicationSubmitted is a constant that states a job application has been sent to the employer
+const JobApplicationSubmitted = "Submitted"
+
+// JobApplicationViewed is a constant that states the employer has looked into a job application
+const JobApplicationViewed = "Viewed"
+
+// JobApplicationShortlisted is a constant that states a job application has been shortlisted by the employer
+const JobApplicationShortlisted = "Shortlisted"
+
+// JobApplicationRejected is a constant that states a job application has been rejected by the employer
+const JobApplicationRejected = "Rejected"
+
+// JobApplicationHired is a constant that states the job seeker of a job application has been hired
+const JobApplicationHired = "Hired"
+
 // FeedbackSeen is a constant that states a feedback has been seen
 const FeedbackSeen = "Seen"
 
 // FeedbackUnseen is a constant that states a feedback hasn't been seen
 const FeedbackUnseen = "Unseen"
 
+// FeedbackStatusOpen is a constant that states a feedback thread is waiting for a reply from a staff member
+const FeedbackStatusOpen = "Open"
+
+// FeedbackStatusAnswered is a constant that states a staff member has replied to the last message of a feedback thread
+const FeedbackStatusAnswered = "Answered"
+
+// FeedbackStatusClosed is a constant that states a feedback thread has been closed and can't be replied to
+const FeedbackStatusClosed = "Closed"
+
+// FeedbackSenderStaff is a constant that states a feedback reply has been sent by a staff member
+const FeedbackSenderStaff = "Staff"
+
+// FeedbackSenderUser is a constant that states a feedback reply has been sent by the user that has given the feedback
+const FeedbackSenderUser = "User"
+
 // StartPush is a constant that states start push
 const StartPush = "Start"
 
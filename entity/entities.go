@@ -7,15 +7,15 @@ import (
 
 // Staff is a type that defines a staff member
 type Staff struct {
-	ID          string `gorm:"primary_key; unique; not null"`
-	FirstName   string
-	LastName    string
-	PhoneNumber string `gorm:"unique; not null"`
-	Email       string `gorm:"unique; not null"`
-	ProfilePic  string
-	Role        string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID          string    `gorm:"primary_key; unique; not null" json:"id"`
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	PhoneNumber string    `gorm:"unique; not null" json:"phone_number"`
+	Email       string    `gorm:"unique; not null" json:"email"`
+	ProfilePic  string    `json:"profile_pic"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // Password is a type that defines a user password
@@ -29,41 +29,52 @@ type Password struct {
 
 // User is a type that defines the user group
 type User struct {
-	ID          string `gorm:"primary_key; unique; not null"`
-	UserName    string
-	PhoneNumber string `gorm:"unique; not null"`
-	Category    string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                   string    `gorm:"primary_key; unique; not null" json:"id"`
+	UserName             string    `json:"user_name"`
+	PhoneNumber          string    `gorm:"unique; not null" json:"phone_number"`
+	Category             string    `json:"category"`
+	Email                string    `json:"email"`                 // Optional, used for email notifications
+	NotificationFallback string    `json:"notification_fallback"` // Used when telegram delivery fails, empty means sms
+	Language             string    `json:"language"`              // The language the bot talks to the user in
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
 }
 
 // Job is a type that defines job to post
 type Job struct {
-	ID             string `gorm:"primary_key; unique; not null"`
-	Employer       string
-	Title          string
-	Description    string `gorm:"type:text;"`
-	Type           string
-	Sector         string
-	EducationLevel string
-	Experience     string
-	Gender         string
-	ContactType    string
-	ContactInfo    string // Only used if the PostType is 'Internal'
-	Status         string
-	PostType       string
-	Link           string
-	InitiatorID    string // To logging who created the job
-	DueDate        *time.Time
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID               string     `gorm:"primary_key; unique; not null" json:"id"`
+	Employer         string     `json:"employer"`
+	Title            string     `json:"title"`
+	Description      string     `gorm:"type:text;" json:"description"`
+	Type             string     `json:"type"`
+	Sector           string     `json:"sector"`
+	EducationLevel   string     `json:"education_level"`
+	Experience       string     `json:"experience"`
+	Gender           string     `json:"gender"`
+	ContactType      string     `json:"contact_type"`
+	ContactInfo      string     `json:"contact_info"` // Only used if the PostType is 'Internal'
+	Status           string     `json:"status"`
+	PostType         string     `json:"post_type"`
+	Link             string     `json:"link"`
+	InitiatorID      string     `json:"initiator_id"`       // To logging who created the job
+	ChannelMessageID int64      `json:"channel_message_id"` // The message id of the job's post on the telegram channel, 0 if not posted yet
+	DueDate          *time.Time `json:"due_date"`
+	RemindedAt       *time.Time `json:"reminded_at"`                   // The last time the employer has been reminded about the due date
+	ReviewedBy       string     `json:"reviewed_by"`                   // The staff id or the telegram id of the moderator that has reviewed the job
+	ReviewNote       string     `gorm:"type:text;" json:"review_note"` // The reason given for declining or requesting changes
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // JobApplication is type that defines the relationship between job and jobseeker
 // JobSeeker cannot apply for the same job twice so we use both JobID and JobSeekerID as primary key
 type JobApplication struct {
-	JobID       string `gorm:"primary_key"`
-	JobSeekerID string `gorm:"primary_key"`
+	JobID       string    `gorm:"primary_key" json:"job_id"`
+	JobSeekerID string    `gorm:"primary_key" json:"job_seeker_id"`
+	Status      string    `json:"status"`
+	CVFileID    string    `json:"cv_file_id"` // The telegram file id of the cv sent with the application
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // Subscription is a type that defines job subscription
@@ -79,17 +90,30 @@ type Subscription struct {
 
 // Feedback is a type that defines user feedback
 type Feedback struct {
-	ID        string `gorm:"primary_key; unique; not null"`
-	UserID    string
-	Comment   string `gorm:"type:text;"`
-	Seen      bool
-	CreatedAt time.Time
+	ID        string    `gorm:"primary_key; unique; not null" json:"id"`
+	UserID    string    `json:"user_id"`
+	Comment   string    `gorm:"type:text;" json:"comment"`
+	Seen      bool      `json:"seen"`
+	Status    string    `json:"status"` // The state of the thread started by the feedback
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FeedbackReply is a type that defines a message added to the thread of a feedback
+// either by a staff member or by the user that has given the feedback
+type FeedbackReply struct {
+	ID         string    `gorm:"primary_key; unique; not null" json:"id"`
+	FeedbackID string    `json:"feedback_id"`
+	SenderID   string    `json:"sender_id"`
+	SenderType string    `json:"sender_type"`
+	Message    string    `gorm:"type:text;" json:"message"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 // JobAttribute is a type that defines a job attribute like job type or job sector
 type JobAttribute struct {
-	ID   string `gorm:"primary_key; unique; not null"`
-	Name string
+	ID   string `gorm:"primary_key; unique; not null" json:"id"`
+	Name string `json:"name"`
 }
 
 // ChannelRequest is a type that defines a request that is set through a bot channel
@@ -100,6 +124,13 @@ type ChannelRequest struct {
 	Extra  string
 }
 
+// QueuedRequest is a type that defines a channel request that has been claimed from the push queue
+type QueuedRequest struct {
+	ID       string
+	Attempts int64
+	Request  *ChannelRequest
+}
+
 // Key is a type that defines a key type that can be used a key value in context
 type Key string
 
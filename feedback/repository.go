@@ -8,9 +8,17 @@ type IFeedbackRepository interface {
 	Find(identifier string) (*entity.Feedback, error)
 	FindMultiple(identifier string) []*entity.Feedback
 	FindAll(seenStatus, pageNum int64) ([]*entity.Feedback, int64)
-	SearchWRegx(key string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64)
-	Search(key string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64)
+	FindAllWStatus(status string, pageNum int64) ([]*entity.Feedback, int64)
+	SearchWRegx(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64)
+	Search(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64)
 	Update(feedback *entity.Feedback) error
 	Delete(identifier string) (*entity.Feedback, error)
 	DeleteMultiple(identifier string) []*entity.Feedback
 }
+
+// IFeedbackReplyRepository is an interface that defines all the repository methods of a feedback reply struct
+type IFeedbackReplyRepository interface {
+	Create(newReply *entity.FeedbackReply) error
+	FindMultiple(feedbackID string) []*entity.FeedbackReply
+	DeleteMultiple(feedbackID string) []*entity.FeedbackReply
+}
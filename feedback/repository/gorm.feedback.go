@@ -89,85 +89,75 @@ func (repo *FeedbackRepository) FindAll(seenStatus, pageNum int64) ([]*entity.Fe
 	return feedbacks, pageCount
 }
 
-// SearchWRegx is a method that searchs and returns set of feedbacks limited to the key identifier and page number using regular expersions
-func (repo *FeedbackRepository) SearchWRegx(key string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
+// FindAllWStatus is a method that returns set of feedbacks limited to the page number and thread status
+func (repo *FeedbackRepository) FindAllWStatus(status string, pageNum int64) ([]*entity.Feedback, int64) {
 	var feedbacks []*entity.Feedback
-	var whereStmt []string
-	var sqlValues []interface{}
 	var count float64
 
-	for _, column := range columns {
-		whereStmt = append(whereStmt, fmt.Sprintf(" %s regexp ? ", column))
-		sqlValues = append(sqlValues, "^"+regexp.QuoteMeta(key))
-	}
+	whereStmt := threadStatusStmt(status)
 
-	switch seenStatus {
-	case 0:
-
-		sqlValues = append(sqlValues, false)
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") && seen = ?", sqlValues...).Count(&count)
-
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" && seen = ? ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
-
-	case 1:
+	repo.conn.Raw("SELECT * FROM feedbacks WHERE "+whereStmt+" ORDER BY updated_at DESC LIMIT ?, 40", status, pageNum*40).Scan(&feedbacks)
+	repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE "+whereStmt, status).Count(&count)
 
-		sqlValues = append(sqlValues, true)
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") && seen = ?", sqlValues...).Count(&count)
-
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" && seen = ? ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
-
-	default:
-
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") ", sqlValues...).Count(&count)
+	var pageCount int64 = int64(math.Ceil(count / 40.0))
+	return feedbacks, pageCount
+}
 
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
+// SearchWRegx is a method that searchs and returns set of feedbacks limited to the key identifier and page number using regular expersions.
+// An empty thread status matches feedbacks of any thread status.
+func (repo *FeedbackRepository) SearchWRegx(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
+	var whereStmt []string
+	var sqlValues []interface{}
 
+	for _, column := range columns {
+		whereStmt = append(whereStmt, fmt.Sprintf(" %s regexp ? ", column))
+		sqlValues = append(sqlValues, "^"+regexp.QuoteMeta(key))
 	}
 
-	var pageCount int64 = int64(math.Ceil(count / 40.0))
-	return feedbacks, pageCount
+	return repo.search(whereStmt, sqlValues, status, seenStatus, pageNum)
 }
 
-// Search is a method that searchs and returns set of feedbacks limited to the key identifier and page number
-func (repo *FeedbackRepository) Search(key string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
-	var feedbacks []*entity.Feedback
+// Search is a method that searchs and returns set of feedbacks limited to the key identifier and page number.
+// An empty thread status matches feedbacks of any thread status.
+func (repo *FeedbackRepository) Search(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
 	var whereStmt []string
 	var sqlValues []interface{}
-	var count float64
 
 	for _, column := range columns {
 		whereStmt = append(whereStmt, fmt.Sprintf(" %s = ? ", column))
 		sqlValues = append(sqlValues, key)
 	}
 
-	switch seenStatus {
-	case 0:
+	return repo.search(whereStmt, sqlValues, status, seenStatus, pageNum)
+}
 
-		sqlValues = append(sqlValues, false)
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") && seen = ?", sqlValues...).Count(&count)
+// search is a method that returns set of feedbacks that match any of the column statements and
+// have the given thread status and seen status, limited to the page number
+func (repo *FeedbackRepository) search(whereStmt []string, sqlValues []interface{}, status string,
+	seenStatus, pageNum int64) ([]*entity.Feedback, int64) {
+	var feedbacks []*entity.Feedback
+	var count float64
 
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" && seen = ? ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
+	condition := "(" + strings.Join(whereStmt, "||") + ")"
 
+	switch seenStatus {
+	case 0:
+		condition += " && seen = ?"
+		sqlValues = append(sqlValues, false)
 	case 1:
-
+		condition += " && seen = ?"
 		sqlValues = append(sqlValues, true)
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") && seen = ?", sqlValues...).Count(&count)
-
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" && seen = ? ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
-
-	default:
+	}
 
-		repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE ("+strings.Join(whereStmt, "||")+") ", sqlValues...).Count(&count)
+	if status != "" {
+		condition += " && " + threadStatusStmt(status)
+		sqlValues = append(sqlValues, status)
+	}
 
-		sqlValues = append(sqlValues, pageNum*40)
-		repo.conn.Raw("SELECT * FROM feedbacks WHERE "+strings.Join(whereStmt, "||")+" ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
+	repo.conn.Raw("SELECT COUNT(*) FROM feedbacks WHERE "+condition, sqlValues...).Count(&count)
 
-	}
+	sqlValues = append(sqlValues, pageNum*40)
+	repo.conn.Raw("SELECT * FROM feedbacks WHERE "+condition+" ORDER BY created_at DESC LIMIT ?, 40", sqlValues...).Scan(&feedbacks)
 
 	var pageCount int64 = int64(math.Ceil(count / 40.0))
 	return feedbacks, pageCount
@@ -221,3 +211,12 @@ func (repo *FeedbackRepository) DeleteMultiple(identifier string) []*entity.Feed
 
 	return feedbacks
 }
+
+// threadStatusStmt is a function that returns the sql statement that matches feedbacks of the given thread status.
+// Feedbacks given before threads were added have an empty status, which is the same as an open thread.
+func threadStatusStmt(status string) string {
+	if status == entity.FeedbackStatusOpen {
+		return "(status = ? || status = '' || status IS NULL)"
+	}
+	return "status = ?"
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/feedback"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/jinzhu/gorm"
+)
+
+// FeedbackReplyRepository is a type that defines a feedback reply repository type
+type FeedbackReplyRepository struct {
+	conn *gorm.DB
+}
+
+// NewFeedbackReplyRepository is a function that creates a new feedback reply repository type
+func NewFeedbackReplyRepository(connection *gorm.DB) feedback.IFeedbackReplyRepository {
+	return &FeedbackReplyRepository{conn: connection}
+}
+
+// Create is a method that adds a new feedback reply to the database
+func (repo *FeedbackReplyRepository) Create(newReply *entity.FeedbackReply) error {
+	totalNumOfReplies := tools.CountMembers("feedback_replies", repo.conn)
+	newReply.ID = fmt.Sprintf("FR-%s%d", tools.RandomStringGN(7), totalNumOfReplies+1)
+
+	for !tools.IsUnique("id", newReply.ID, "feedback_replies", repo.conn) {
+		totalNumOfReplies++
+		newReply.ID = fmt.Sprintf("FR-%s%d", tools.RandomStringGN(7), totalNumOfReplies+1)
+	}
+
+	err := repo.conn.Create(newReply).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindMultiple is a method that finds all the replies of a feedback ordered from the oldest to the newest.
+// In FindMultiple() only feedback_id is used as a key
+func (repo *FeedbackReplyRepository) FindMultiple(identifier string) []*entity.FeedbackReply {
+
+	var replies []*entity.FeedbackReply
+	err := repo.conn.Model(entity.FeedbackReply{}).Where("feedback_id = ?", identifier).
+		Order("created_at ASC").Find(&replies).Error
+
+	if err != nil {
+		return []*entity.FeedbackReply{}
+	}
+	return replies
+}
+
+// DeleteMultiple is a method that deletes all the replies of a feedback from the database.
+// In DeleteMultiple() feedback_id is only used as an key
+func (repo *FeedbackReplyRepository) DeleteMultiple(identifier string) []*entity.FeedbackReply {
+	var replies []*entity.FeedbackReply
+	repo.conn.Model(replies).Where("feedback_id = ?", identifier).
+		Find(&replies)
+
+	for _, reply := range replies {
+		repo.conn.Delete(reply)
+	}
+
+	return replies
+}
@@ -11,6 +11,10 @@ type IService interface {
 	AllFeedbacks(status string, pageNum int64) ([]*entity.Feedback, int64)
 	SearchFeedbacks(key, status string, pageNum int64, extra ...string) ([]*entity.Feedback, int64)
 	MarkAsSeen(feedbackID string) error
+	ValidateFeedbackReply(reply *entity.FeedbackReply) entity.ErrMap
+	ReplyToFeedback(reply *entity.FeedbackReply) (*entity.Feedback, error)
+	FindFeedbackReplies(feedbackID string) []*entity.FeedbackReply
+	ChangeFeedbackStatus(feedbackID, status string) (*entity.Feedback, error)
 	DeleteFeedback(id string) (*entity.Feedback, error)
 	DeleteMultipleFeedbacks(userID string) []*entity.Feedback
 }
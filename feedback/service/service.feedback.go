@@ -12,18 +12,22 @@ import (
 // Service is a type that defines a feedback service
 type Service struct {
 	feedbackRepo feedback.IFeedbackRepository
+	replyRepo    feedback.IFeedbackReplyRepository
 	userRepo     user.IUserRepository
 }
 
 // NewFeedbackService is a function that returns a new feedback service
 func NewFeedbackService(feedbackRepository feedback.IFeedbackRepository,
-	userRepository user.IUserRepository) feedback.IService {
-	return &Service{feedbackRepo: feedbackRepository, userRepo: userRepository}
+	replyRepository feedback.IFeedbackReplyRepository, userRepository user.IUserRepository) feedback.IService {
+	return &Service{feedbackRepo: feedbackRepository, replyRepo: replyRepository, userRepo: userRepository}
 }
 
 // AddFeedback is a method that adds a new feedback to the system
 func (service *Service) AddFeedback(newFeedback *entity.Feedback) error {
 
+	// A new feedback always starts a thread that is waiting for a reply
+	newFeedback.Status = entity.FeedbackStatusOpen
+
 	err := service.feedbackRepo.Create(newFeedback)
 	if err != nil {
 		return errors.New("unable to add new feedback")
@@ -87,9 +91,14 @@ func (service *Service) FindMultipleFeedbacks(userID string) []*entity.Feedback
 	return service.feedbackRepo.FindMultiple(userID)
 }
 
-// AllFeedbacks is a method that returns all the feedbacks with pagination
+// AllFeedbacks is a method that returns all the feedbacks with pagination.
+// The status can either be a seen status or a thread status [Open, Answered and Closed]
 func (service *Service) AllFeedbacks(status string, pageNum int64) ([]*entity.Feedback, int64) {
 
+	if isThreadStatus(status) {
+		return service.feedbackRepo.FindAllWStatus(status, pageNum)
+	}
+
 	var seenStatus int64
 	if status == entity.FeedbackUnseen {
 		seenStatus = 0
@@ -105,13 +114,19 @@ func (service *Service) AllFeedbacks(status string, pageNum int64) ([]*entity.Fe
 // SearchFeedbacks is a method that searchs and returns a set of feedbacks related to the key identifier
 func (service *Service) SearchFeedbacks(key, status string, pageNum int64, extra ...string) ([]*entity.Feedback, int64) {
 
+	// The status can either be a seen status or a thread status, where the thread status is filtered by the repository
+	// so the pages and the page count only include the feedbacks of the status
 	var seenStatus int64
+	threadStatus := ""
 	if status == entity.FeedbackUnseen {
 		seenStatus = 0
 	} else if status == entity.FeedbackSeen {
 		seenStatus = 1
 	} else {
 		seenStatus = 2
+		if isThreadStatus(status) {
+			threadStatus = status
+		}
 	}
 
 	defaultSearchColumnsRegx := []string{"comment"}
@@ -131,9 +146,9 @@ func (service *Service) SearchFeedbacks(key, status string, pageNum int64, extra
 		return results, 0
 	}
 
-	result1, pageCount1 = service.feedbackRepo.Search(key, seenStatus, pageNum, defaultSearchColumns...)
+	result1, pageCount1 = service.feedbackRepo.Search(key, threadStatus, seenStatus, pageNum, defaultSearchColumns...)
 	if len(defaultSearchColumnsRegx) > 0 {
-		result2, pageCount2 = service.feedbackRepo.SearchWRegx(key, seenStatus, pageNum, defaultSearchColumnsRegx...)
+		result2, pageCount2 = service.feedbackRepo.SearchWRegx(key, threadStatus, seenStatus, pageNum, defaultSearchColumnsRegx...)
 	}
 
 	for _, feedback := range result1 {
@@ -177,7 +192,105 @@ func (service *Service) MarkAsSeen(feedbackID string) error {
 	return nil
 }
 
-// DeleteFeedback is a method that deletes a feedback from the system using an id
+// ValidateFeedbackReply is a method that validates a feedback reply entries.
+// It checks if the reply has a valid entries or not and return map of errors if any.
+func (service *Service) ValidateFeedbackReply(reply *entity.FeedbackReply) entity.ErrMap {
+
+	errMap := make(map[string]error)
+
+	emptyMessage, _ := regexp.MatchString(`^\s*$`, reply.Message)
+	if emptyMessage {
+		errMap["message"] = errors.New("message can not be empty")
+	} else if len(reply.Message) > 1000 {
+		errMap["message"] = errors.New("message can not exceed 1000 characters")
+	}
+
+	if reply.SenderType != entity.FeedbackSenderStaff && reply.SenderType != entity.FeedbackSenderUser {
+		errMap["sender_type"] = errors.New("invalid sender type")
+	}
+
+	if len(errMap) > 0 {
+		return errMap
+	}
+
+	return nil
+}
+
+// ReplyToFeedback is a method that adds a reply to the thread of a feedback and returns the updated feedback.
+// A reply from a staff member marks the thread as answered while a reply from the user reopens it.
+func (service *Service) ReplyToFeedback(reply *entity.FeedbackReply) (*entity.Feedback, error) {
+
+	feedback, err := service.feedbackRepo.Find(reply.FeedbackID)
+	if err != nil {
+		return nil, errors.New("feedback not found")
+	}
+
+	if feedback.Status == entity.FeedbackStatusClosed {
+		return nil, errors.New("feedback thread is closed")
+	}
+
+	if reply.SenderType == entity.FeedbackSenderUser && feedback.UserID != reply.SenderID {
+		return nil, errors.New("feedback not found")
+	}
+
+	err = service.replyRepo.Create(reply)
+	if err != nil {
+		return nil, errors.New("unable to add reply")
+	}
+
+	if reply.SenderType == entity.FeedbackSenderStaff {
+		feedback.Status = entity.FeedbackStatusAnswered
+		feedback.Seen = true
+	} else {
+		feedback.Status = entity.FeedbackStatusOpen
+		feedback.Seen = false
+	}
+
+	err = service.feedbackRepo.Update(feedback)
+	if err != nil {
+		return nil, errors.New("unable to update feedback")
+	}
+
+	return feedback, nil
+}
+
+// FindFeedbackReplies is a method that returns the replies of a feedback from the oldest to the newest
+func (service *Service) FindFeedbackReplies(feedbackID string) []*entity.FeedbackReply {
+
+	empty, _ := regexp.MatchString(`^\s*$`, feedbackID)
+	if empty {
+		return []*entity.FeedbackReply{}
+	}
+
+	return service.replyRepo.FindMultiple(feedbackID)
+}
+
+// ChangeFeedbackStatus is a method that changes the thread status of a feedback
+func (service *Service) ChangeFeedbackStatus(feedbackID, status string) (*entity.Feedback, error) {
+
+	if !isThreadStatus(status) {
+		return nil, errors.New("invalid feedback status")
+	}
+
+	feedback, err := service.feedbackRepo.Find(feedbackID)
+	if err != nil {
+		return nil, errors.New("feedback not found")
+	}
+
+	if feedback.Status == status {
+		return nil, errors.New("unable to perform operation")
+	}
+
+	feedback.Status = status
+	err = service.feedbackRepo.Update(feedback)
+	if err != nil {
+		return nil, errors.New("unable to update feedback")
+	}
+
+	return feedback, nil
+}
+
+// DeleteFeedback is a method that deletes a feedback along with its replies from the system using an id
 func (service *Service) DeleteFeedback(id string) (*entity.Feedback, error) {
 
 	feedback, err := service.feedbackRepo.Delete(id)
@@ -185,10 +298,24 @@ func (service *Service) DeleteFeedback(id string) (*entity.Feedback, error) {
 		return nil, errors.New("unable to delete feedback")
 	}
 
+	service.replyRepo.DeleteMultiple(id)
+
 	return feedback, nil
 }
 
 // DeleteMultipleFeedbacks is a method that deletes multiple feedbacks from the system that match the given userID
 func (service *Service) DeleteMultipleFeedbacks(userID string) []*entity.Feedback {
-	return service.feedbackRepo.DeleteMultiple(userID)
+
+	feedbacks := service.feedbackRepo.DeleteMultiple(userID)
+	for _, feedback := range feedbacks {
+		service.replyRepo.DeleteMultiple(feedback.ID)
+	}
+
+	return feedbacks
+}
+
+// isThreadStatus is a function that checks whether the given status is a valid feedback thread status
+func isThreadStatus(status string) bool {
+	return status == entity.FeedbackStatusOpen || status == entity.FeedbackStatusAnswered ||
+		status == entity.FeedbackStatusClosed
 }
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/feedback"
+	"github.com/Benyam-S/asseri/internal/testdb"
+)
+
+// newTestService is a function that returns a feedback service that keeps its data in the given in-memory database
+func newTestService(db *testdb.MemoryDB) feedback.IService {
+	return NewFeedbackService(testdb.NewMemoryFeedbackRepository(db),
+		testdb.NewMemoryFeedbackReplyRepository(db), testdb.NewMemoryUserRepository(db))
+}
+
+func TestReplyToFeedback(t *testing.T) {
+	service := newTestService(testdb.NewMemoryDB())
+
+	newFeedback := &entity.Feedback{UserID: "UR-1", Comment: "The salary filter doesn't work"}
+	if err := service.AddFeedback(newFeedback); err != nil {
+		t.Fatalf("unable to add the feedback, %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		senderID   string
+		senderType string
+		status     string // The thread status expected after the reply, empty when the reply is expected to fail
+		seen       bool
+	}{
+		{"staff answers", "ST-1", entity.FeedbackSenderStaff, entity.FeedbackStatusAnswered, true},
+		{"another user replies", "UR-2", entity.FeedbackSenderUser, "", false},
+		{"owner reopens", "UR-1", entity.FeedbackSenderUser, entity.FeedbackStatusOpen, false},
+		{"staff answers again", "ST-1", entity.FeedbackSenderStaff, entity.FeedbackStatusAnswered, true},
+	}
+
+	replies := 0
+	for _, test := range tests {
+		reply := &entity.FeedbackReply{FeedbackID: newFeedback.ID, SenderID: test.senderID,
+			SenderType: test.senderType, Message: test.name}
+		updated, err := service.ReplyToFeedback(reply)
+
+		if test.status == "" {
+			if err == nil {
+				t.Errorf("%s: expected the reply to be rejected", test.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%s: unable to reply, %s", test.name, err)
+		}
+		replies++
+
+		stored, _ := service.FindFeedback(newFeedback.ID)
+		if updated.Status != test.status || stored.Status != test.status || stored.Seen != test.seen {
+			t.Errorf("%s: expected the thread to be %s with seen %t, got %s with seen %t",
+				test.name, test.status, test.seen, stored.Status, stored.Seen)
+		}
+	}
+
+	if len(service.FindFeedbackReplies(newFeedback.ID)) != replies {
+		t.Errorf("expected only the accepted replies to be kept, got %d", len(service.FindFeedbackReplies(newFeedback.ID)))
+	}
+
+	// A closed thread can't be replied to by either side
+	if _, err := service.ChangeFeedbackStatus(newFeedback.ID, entity.FeedbackStatusClosed); err != nil {
+		t.Fatalf("unable to close the thread, %s", err)
+	}
+
+	for _, senderType := range []string{entity.FeedbackSenderUser, entity.FeedbackSenderStaff} {
+		reply := &entity.FeedbackReply{FeedbackID: newFeedback.ID, SenderID: "UR-1", SenderType: senderType,
+			Message: "Any update?"}
+		if _, err := service.ReplyToFeedback(reply); err == nil {
+			t.Errorf("expected a %s reply to a closed thread to be rejected", senderType)
+		}
+	}
+
+	if stored, _ := service.FindFeedback(newFeedback.ID); stored.Status != entity.FeedbackStatusClosed {
+		t.Errorf("expected the thread to stay closed, got %s", stored.Status)
+	}
+
+	if len(service.FindFeedbackReplies(newFeedback.ID)) != replies {
+		t.Errorf("expected no reply to be added to a closed thread")
+	}
+
+	if _, err := service.ReplyToFeedback(&entity.FeedbackReply{FeedbackID: "FD-0", SenderID: "ST-1",
+		SenderType: entity.FeedbackSenderStaff, Message: "Hello"}); err == nil {
+		t.Errorf("expected a reply to a feedback that doesn't exist to be rejected")
+	}
+}
+
+func TestSearchFeedbacksWStatus(t *testing.T) {
+	db := testdb.NewMemoryDB()
+	service := newTestService(db)
+
+	for i := 0; i < 41; i++ {
+		newFeedback := &entity.Feedback{UserID: "UR-1", Comment: fmt.Sprintf("salary %d", i)}
+		service.AddFeedback(newFeedback)
+		service.ChangeFeedbackStatus(newFeedback.ID, entity.FeedbackStatusAnswered)
+	}
+
+	for i := 0; i < 3; i++ {
+		service.AddFeedback(&entity.Feedback{UserID: "UR-1", Comment: fmt.Sprintf("salary open %d", i)})
+	}
+
+	// Feedbacks given before threads were added have an empty status
+	db.Insert("feedbacks", &entity.Feedback{ID: "FD-old", UserID: "UR-1", Comment: "salary without a status"})
+
+	tests := []struct {
+		status    string
+		pageNum   int64
+		expected  int
+		pageCount int64
+	}{
+		{entity.FeedbackStatusAnswered, 0, 40, 2},
+		{entity.FeedbackStatusAnswered, 1, 1, 2},
+		{entity.FeedbackStatusOpen, 0, 4, 1},
+		{entity.FeedbackStatusClosed, 0, 0, 0},
+		{"", 0, 40, 2},
+		{"", 1, 5, 2},
+	}
+
+	for _, test := range tests {
+		feedbacks, pageCount := service.SearchFeedbacks("salary", test.status, test.pageNum)
+		if len(feedbacks) != test.expected || pageCount != test.pageCount {
+			t.Errorf("expected page %d of %q to have %d feedbacks of %d pages, got %d feedbacks of %d pages",
+				test.pageNum, test.status, test.expected, test.pageCount, len(feedbacks), pageCount)
+		}
+
+		for _, feedback := range feedbacks {
+			if test.status == entity.FeedbackStatusAnswered && feedback.Status != test.status ||
+				test.status == entity.FeedbackStatusOpen && feedback.Status == entity.FeedbackStatusAnswered {
+				t.Errorf("expected only %s feedbacks to be found, got %s", test.status, feedback.Status)
+			}
+		}
+	}
+}
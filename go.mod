@@ -13,4 +13,5 @@ require (
 	github.com/onsi/ginkgo v1.14.2 // indirect
 	github.com/onsi/gomega v1.10.4 // indirect
 	github.com/tmdvs/Go-Emoji-Utils v1.1.0
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
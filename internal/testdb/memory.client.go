@@ -0,0 +1,78 @@
+package testdb
+
+import (
+	"errors"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/client"
+)
+
+// MemoryClientRepository is a type that defines a client repository that keeps the clients in an in-memory database
+type MemoryClientRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryClientRepository is a function that creates a new in-memory client repository type
+func NewMemoryClientRepository(db *MemoryDB) client.IClientRepository {
+	return &MemoryClientRepository{db: db}
+}
+
+// Create is a method that adds a new client to the in-memory database
+func (repo *MemoryClientRepository) Create(newClient *bot.Client) error {
+	if !repo.db.IsUnique("user_id", newClient.UserID, "bot_clients") ||
+		!repo.db.IsUnique("telegram_id", newClient.TelegramID, "bot_clients") {
+		return errors.New("duplicate client")
+	}
+
+	repo.db.Insert("bot_clients", newClient)
+	return nil
+}
+
+// Find is a method that returns a client that matches the provided identifier.
+// In Find() telegram_id or user_id can either be used as a key.
+func (repo *MemoryClientRepository) Find(identifier string) (*bot.Client, error) {
+	clients := repo.db.Select("bot_clients", repo.matchClient(identifier))
+	if len(clients) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return clients[0].(*bot.Client), nil
+}
+
+// Update is a method that updates a certain client entries in the in-memory database
+func (repo *MemoryClientRepository) Update(client *bot.Client) error {
+	replaced := repo.db.Replace("bot_clients", client,
+		func(row interface{}) bool { return row.(*bot.Client).UserID == client.UserID })
+	if !replaced {
+		return errors.New("record not found")
+	}
+	return nil
+}
+
+// Delete is a method that deletes a certain client from the in-memory database using telegram_id or user_id.
+func (repo *MemoryClientRepository) Delete(identifier string) (*bot.Client, error) {
+	deleted := repo.db.Delete("bot_clients", repo.matchClient(identifier))
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return deleted[0].(*bot.Client), nil
+}
+
+// SetBlocked is a method that sets the blocked state of a client using telegram_id or user_id
+func (repo *MemoryClientRepository) SetBlocked(identifier string, blocked bool) error {
+	_, err := repo.db.Update("bot_clients", map[string]interface{}{"blocked": blocked}, repo.matchClient(identifier))
+	return err
+}
+
+// CountBlocked is a method that returns the number of clients that have blocked the bot
+func (repo *MemoryClientRepository) CountBlocked() int64 {
+	return int64(len(repo.db.Select("bot_clients",
+		func(row interface{}) bool { return row.(*bot.Client).Blocked })))
+}
+
+// matchClient is a method that returns a filter that matches a client by its telegram id or user id
+func (repo *MemoryClientRepository) matchClient(identifier string) func(row interface{}) bool {
+	return func(row interface{}) bool {
+		client := row.(*bot.Client)
+		return client.TelegramID == identifier || client.UserID == identifier
+	}
+}
@@ -0,0 +1,103 @@
+package testdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Benyam-S/asseri/common"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// MemoryCommonRepository is a type that defines a repository for common use that works on an in-memory database
+type MemoryCommonRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryCommonRepository is a function that returns a new in-memory common repository type
+func NewMemoryCommonRepository(db *MemoryDB) common.ICommonRepository {
+	return &MemoryCommonRepository{db: db}
+}
+
+// IsUnique is a methods that checks if a given column value is unique in a certain table
+func (repo *MemoryCommonRepository) IsUnique(columnName string, columnValue interface{}, tableName string) bool {
+	return repo.db.IsUnique(columnName, columnValue, tableName)
+}
+
+// CreateJobAttribute is a method that adds a new job attribute to the in-memory database
+func (repo *MemoryCommonRepository) CreateJobAttribute(newAttribute *entity.JobAttribute, tableName string) error {
+
+	var prefix string
+
+	switch tableName {
+	case "job_types":
+		prefix = "TYPE"
+	case "job_sectors":
+		prefix = "SECTOR"
+	case "education_levels":
+		prefix = "LEVEL"
+	}
+
+	totalNumOfMembers := repo.db.Count(tableName)
+	newAttribute.ID = fmt.Sprintf(prefix+"-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+
+	for !repo.db.IsUnique("id", newAttribute.ID, tableName) {
+		totalNumOfMembers++
+		newAttribute.ID = fmt.Sprintf(prefix+"-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+	}
+
+	repo.db.Insert(tableName, newAttribute)
+	return nil
+}
+
+// FindJobAttribute is a method that finds a certain job attribute using an identifier and table name.
+// In FindJobAttribute() id and name are used as an key
+func (repo *MemoryCommonRepository) FindJobAttribute(identifier, tableName string) (*entity.JobAttribute, error) {
+
+	attributes := repo.db.Select(tableName, repo.matchAttribute(identifier))
+	if len(attributes) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return attributes[0].(*entity.JobAttribute), nil
+}
+
+// AllJobAttributes is a method that returns all the job attributes of a single job attribute table
+func (repo *MemoryCommonRepository) AllJobAttributes(tableName string) []*entity.JobAttribute {
+
+	attributes := make([]*entity.JobAttribute, 0)
+	for _, row := range repo.db.Select(tableName, nil) {
+		attributes = append(attributes, row.(*entity.JobAttribute))
+	}
+
+	return attributes
+}
+
+// UpdateJobAttribute is a method that updates a certain job attribute value in the in-memory database
+func (repo *MemoryCommonRepository) UpdateJobAttribute(attribute *entity.JobAttribute, tableName string) error {
+
+	replaced := repo.db.Replace(tableName, attribute,
+		func(row interface{}) bool { return row.(*entity.JobAttribute).ID == attribute.ID })
+	if !replaced {
+		return errors.New("record not found")
+	}
+	return nil
+}
+
+// DeleteJobAttribute is a method that deletes a certain job attribute from the in-memory database using an identifier.
+// In DeleteJobAttribute() id and name are used as an key
+func (repo *MemoryCommonRepository) DeleteJobAttribute(identifier, tableName string) (*entity.JobAttribute, error) {
+
+	deleted := repo.db.Delete(tableName, repo.matchAttribute(identifier))
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return deleted[0].(*entity.JobAttribute), nil
+}
+
+// matchAttribute is a method that returns a filter that matches a job attribute by its id or name
+func (repo *MemoryCommonRepository) matchAttribute(identifier string) func(row interface{}) bool {
+	return func(row interface{}) bool {
+		attribute := row.(*entity.JobAttribute)
+		return attribute.ID == identifier || attribute.Name == identifier
+	}
+}
@@ -0,0 +1,279 @@
+// Package testdb holds an in-memory database and the repositories built on it, which stand in for the
+// mysql repositories in tests. It is not meant to be used by the servers.
+package testdb
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MemoryDB is a type that defines an in-memory database that can be used in place of the mysql database,
+// for example when the bot is tested end to end. Rows are kept as copies of the stored structs and the columns
+// are named the same way gorm names them.
+type MemoryDB struct {
+	mutex  sync.Mutex
+	tables map[string][]interface{}
+}
+
+// NewMemoryDB is a function that returns a new empty in-memory database
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{tables: make(map[string][]interface{})}
+}
+
+// Insert is a method that adds a copy of the given row to a table, the row should be a pointer to a struct.
+// Like gorm, created_at and updated_at are set if the row has them and they are blank.
+func (db *MemoryDB) Insert(tableName string, row interface{}) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	now := time.Now()
+	for _, columnName := range []string{"created_at", "updated_at"} {
+		if field, ok := columnField(row, columnName); ok && field.IsZero() {
+			SetColumnValue(row, columnName, now)
+		}
+	}
+
+	db.tables[tableName] = append(db.tables[tableName], copyRow(row))
+}
+
+// Select is a method that returns copies of the rows of a table that satisfy the given filter in the order
+// they were inserted, a nil filter selects every row
+func (db *MemoryDB) Select(tableName string, filter func(row interface{}) bool) []interface{} {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	rows := make([]interface{}, 0)
+	for _, row := range db.tables[tableName] {
+		if filter == nil || filter(row) {
+			rows = append(rows, copyRow(row))
+		}
+	}
+
+	return rows
+}
+
+// Replace is a method that replaces the first row of a table that satisfies the given filter with a copy of the given row,
+// it returns false if no row has satisfied the filter
+func (db *MemoryDB) Replace(tableName string, row interface{}, filter func(row interface{}) bool) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for index, prevRow := range db.tables[tableName] {
+		if filter(prevRow) {
+			SetColumnValue(row, "updated_at", time.Now())
+			db.tables[tableName][index] = copyRow(row)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Update is a method that sets the given column values of every row of a table that satisfies the given filter,
+// it returns the number of rows that have been updated
+func (db *MemoryDB) Update(tableName string, values map[string]interface{},
+	filter func(row interface{}) bool) (int64, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var updated int64
+	for _, row := range db.tables[tableName] {
+		if !filter(row) {
+			continue
+		}
+
+		for columnName, columnValue := range values {
+			if err := SetColumnValue(row, columnName, columnValue); err != nil {
+				return updated, err
+			}
+		}
+
+		SetColumnValue(row, "updated_at", time.Now())
+		updated++
+	}
+
+	return updated, nil
+}
+
+// Delete is a method that removes every row of a table that satisfies the given filter and returns the removed rows
+func (db *MemoryDB) Delete(tableName string, filter func(row interface{}) bool) []interface{} {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	kept := make([]interface{}, 0)
+	deleted := make([]interface{}, 0)
+	for _, row := range db.tables[tableName] {
+		if filter(row) {
+			deleted = append(deleted, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+
+	db.tables[tableName] = kept
+	return deleted
+}
+
+// Count is a method that returns the number of rows in the given table
+func (db *MemoryDB) Count(tableName string) int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	return len(db.tables[tableName])
+}
+
+// IsUnique is a method that determines whether a certain column value is unique in the given table
+func (db *MemoryDB) IsUnique(columnName string, columnValue interface{}, tableName string) bool {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for _, row := range db.tables[tableName] {
+		if reflect.DeepEqual(ColumnValue(row, columnName), columnValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ColumnValue is a function that returns the value of the given column of a row, nil is returned if the row
+// doesn't have the column
+func ColumnValue(row interface{}, columnName string) interface{} {
+
+	field, ok := columnField(row, columnName)
+	if !ok {
+		return nil
+	}
+
+	return field.Interface()
+}
+
+// SetColumnValue is a function that sets the given column of a row, the value is converted to the column's type
+// if it is convertible like a database driver would do
+func SetColumnValue(row interface{}, columnName string, columnValue interface{}) error {
+
+	field, ok := columnField(row, columnName)
+	if !ok {
+		return errors.New("column " + columnName + " not found")
+	}
+
+	if columnValue == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	value := reflect.ValueOf(columnValue)
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	fieldType := field.Type()
+	if fieldType.Kind() == reflect.Ptr && value.Kind() != reflect.Ptr {
+		pointer := reflect.New(fieldType.Elem())
+		if err := setValue(pointer.Elem(), value); err != nil {
+			return errors.New("invalid value for column " + columnName)
+		}
+		field.Set(pointer)
+		return nil
+	}
+
+	if err := setValue(field, value); err != nil {
+		return errors.New("invalid value for column " + columnName)
+	}
+	return nil
+}
+
+// setValue is a function that assigns or converts a value to the given settable value
+func setValue(field, value reflect.Value) error {
+	if value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+
+	if value.Type().ConvertibleTo(field.Type()) {
+		field.Set(value.Convert(field.Type()))
+		return nil
+	}
+
+	return errors.New("value can't be assigned")
+}
+
+// columnField is a function that finds the struct field of a row that is stored in the given column
+func columnField(row interface{}, columnName string) (reflect.Value, bool) {
+
+	value := reflect.ValueOf(row)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	value = value.Elem()
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+
+		name := gorm.ToColumnName(structField.Name)
+		for _, setting := range strings.Split(structField.Tag.Get("gorm"), ";") {
+			setting = strings.TrimSpace(setting)
+			if strings.HasPrefix(strings.ToLower(setting), "column:") {
+				name = setting[len("column:"):]
+			}
+		}
+
+		if name == columnName {
+			return value.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// copyRow is a function that returns a shallow copy of the struct the given row points to
+func copyRow(row interface{}) interface{} {
+	value := reflect.ValueOf(row).Elem()
+	duplicate := reflect.New(value.Type())
+	duplicate.Elem().Set(value)
+	return duplicate.Interface()
+}
+
+// MatchColumns is a function that checks whether any of the given columns of a row matches the key,
+// if prefix is set a column only has to start with the key like the '^key' regular expressions of the search queries
+func MatchColumns(row interface{}, key string, prefix bool, columns ...string) bool {
+	for _, columnName := range columns {
+		value, ok := ColumnValue(row, columnName).(string)
+		if !ok {
+			continue
+		}
+
+		if prefix && strings.HasPrefix(strings.ToLower(value), strings.ToLower(key)) {
+			return true
+		} else if !prefix && value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Paginate is a function that returns the start and end index of the given page of a list with the provided length,
+// along with the number of pages the list has
+func Paginate(length int, pageNum, pageSize int64) (int, int, int64) {
+
+	pageCount := (int64(length) + pageSize - 1) / pageSize
+
+	start := pageNum * pageSize
+	if start > int64(length) || start < 0 {
+		start = int64(length)
+	}
+
+	end := start + pageSize
+	if end > int64(length) {
+		end = int64(length)
+	}
+
+	return int(start), int(end), pageCount
+}
@@ -0,0 +1,169 @@
+package testdb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/feedback"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// MemoryFeedbackRepository is a type that defines a feedback repository that keeps the feedbacks in an in-memory database
+type MemoryFeedbackRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryFeedbackRepository is a function that creates a new in-memory feedback repository type
+func NewMemoryFeedbackRepository(db *MemoryDB) feedback.IFeedbackRepository {
+	return &MemoryFeedbackRepository{db: db}
+}
+
+// Create is a method that adds a new feedback to the in-memory database
+func (repo *MemoryFeedbackRepository) Create(newFeedback *entity.Feedback) error {
+	totalNumOfFeedbacks := repo.db.Count("feedbacks")
+	newFeedback.ID = fmt.Sprintf("FD-%s%d", tools.RandomStringGN(7), totalNumOfFeedbacks+1)
+
+	for !repo.db.IsUnique("id", newFeedback.ID, "feedbacks") {
+		totalNumOfFeedbacks++
+		newFeedback.ID = fmt.Sprintf("FD-%s%d", tools.RandomStringGN(7), totalNumOfFeedbacks+1)
+	}
+
+	repo.db.Insert("feedbacks", newFeedback)
+	return nil
+}
+
+// Find is a method that finds a certain feedback using an identifier,
+// also Find() uses only id as a key for selection
+func (repo *MemoryFeedbackRepository) Find(identifier string) (*entity.Feedback, error) {
+
+	feedbacks := repo.selectFeedbacks(func(feedback *entity.Feedback) bool { return feedback.ID == identifier })
+	if len(feedbacks) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return feedbacks[0], nil
+}
+
+// FindMultiple is a method that finds multiple feedbacks that matches the given identifier
+// In FindMultiple() only user_id is used as a key
+func (repo *MemoryFeedbackRepository) FindMultiple(identifier string) []*entity.Feedback {
+	return repo.selectFeedbacks(func(feedback *entity.Feedback) bool { return feedback.UserID == identifier })
+}
+
+// FindAll is a method that returns set of feedbacks limited to the page number and status
+// We used int64 for seenStatus because we have 3 options for seen value [true, false and both]
+func (repo *MemoryFeedbackRepository) FindAll(seenStatus, pageNum int64) ([]*entity.Feedback, int64) {
+	return repo.page(repo.selectFeedbacks(func(feedback *entity.Feedback) bool {
+		return repo.hasSeenStatus(feedback, seenStatus)
+	}), pageNum)
+}
+
+// FindAllWStatus is a method that returns set of feedbacks limited to the page number and thread status
+func (repo *MemoryFeedbackRepository) FindAllWStatus(status string, pageNum int64) ([]*entity.Feedback, int64) {
+
+	feedbacks := repo.selectFeedbacks(func(feedback *entity.Feedback) bool { return repo.hasThreadStatus(feedback, status) })
+	sort.SliceStable(feedbacks, func(i, j int) bool { return feedbacks[i].UpdatedAt.After(feedbacks[j].UpdatedAt) })
+
+	start, end, pageCount := Paginate(len(feedbacks), pageNum, 40)
+	return feedbacks[start:end], pageCount
+}
+
+// SearchWRegx is a method that searchs and returns set of feedbacks whose columns start with the key identifier,
+// limited to the page number. An empty thread status matches feedbacks of any thread status.
+func (repo *MemoryFeedbackRepository) SearchWRegx(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
+	return repo.page(repo.selectFeedbacks(func(feedback *entity.Feedback) bool {
+		return repo.hasSeenStatus(feedback, seenStatus) && (status == "" || repo.hasThreadStatus(feedback, status)) &&
+			MatchColumns(feedback, key, true, columns...)
+	}), pageNum)
+}
+
+// Search is a method that searchs and returns set of feedbacks limited to the key identifier and page number.
+// An empty thread status matches feedbacks of any thread status.
+func (repo *MemoryFeedbackRepository) Search(key, status string, seenStatus, pageNum int64, columns ...string) ([]*entity.Feedback, int64) {
+	return repo.page(repo.selectFeedbacks(func(feedback *entity.Feedback) bool {
+		return repo.hasSeenStatus(feedback, seenStatus) && (status == "" || repo.hasThreadStatus(feedback, status)) &&
+			MatchColumns(feedback, key, false, columns...)
+	}), pageNum)
+}
+
+// Update is a method that updates a certain feedback entries in the in-memory database
+func (repo *MemoryFeedbackRepository) Update(feedback *entity.Feedback) error {
+
+	prevFeedback, err := repo.Find(feedback.ID)
+	if err != nil {
+		return err
+	}
+
+	feedback.CreatedAt = prevFeedback.CreatedAt
+	repo.db.Replace("feedbacks", feedback,
+		func(row interface{}) bool { return row.(*entity.Feedback).ID == feedback.ID })
+	return nil
+}
+
+// Delete is a method that deletes a certain feedback from the in-memory database using an identifier.
+// In Delete() id is only used as an key
+func (repo *MemoryFeedbackRepository) Delete(identifier string) (*entity.Feedback, error) {
+	deleted := repo.db.Delete("feedbacks",
+		func(row interface{}) bool { return row.(*entity.Feedback).ID == identifier })
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+
+	return deleted[0].(*entity.Feedback), nil
+}
+
+// DeleteMultiple is a method that deletes a set of feedbacks from the in-memory database using an identifier.
+// In Delete() user_id is only used as an key
+func (repo *MemoryFeedbackRepository) DeleteMultiple(identifier string) []*entity.Feedback {
+
+	feedbacks := make([]*entity.Feedback, 0)
+	for _, row := range repo.db.Delete("feedbacks",
+		func(row interface{}) bool { return row.(*entity.Feedback).UserID == identifier }) {
+		feedbacks = append(feedbacks, row.(*entity.Feedback))
+	}
+
+	return feedbacks
+}
+
+// hasSeenStatus is a method that checks whether a feedback has the given seen status,
+// 0 matches unseen feedbacks, 1 matches seen feedbacks and any other value matches every feedback
+func (repo *MemoryFeedbackRepository) hasSeenStatus(feedback *entity.Feedback, seenStatus int64) bool {
+	switch seenStatus {
+	case 0:
+		return !feedback.Seen
+	case 1:
+		return feedback.Seen
+	}
+
+	return true
+}
+
+// hasThreadStatus is a method that checks whether a feedback has the given thread status.
+// Feedbacks given before threads were added have an empty status, which is the same as an open thread.
+func (repo *MemoryFeedbackRepository) hasThreadStatus(feedback *entity.Feedback, status string) bool {
+	return feedback.Status == status || (status == entity.FeedbackStatusOpen && feedback.Status == "")
+}
+
+// selectFeedbacks is a method that returns the feedbacks that satisfy the given filter
+func (repo *MemoryFeedbackRepository) selectFeedbacks(filter func(feedback *entity.Feedback) bool) []*entity.Feedback {
+
+	feedbacks := make([]*entity.Feedback, 0)
+	for _, row := range repo.db.Select("feedbacks", nil) {
+		if filter(row.(*entity.Feedback)) {
+			feedbacks = append(feedbacks, row.(*entity.Feedback))
+		}
+	}
+
+	return feedbacks
+}
+
+// page is a method that orders the given feedbacks from the newest and returns the feedbacks of the given page
+// with the page count
+func (repo *MemoryFeedbackRepository) page(feedbacks []*entity.Feedback, pageNum int64) ([]*entity.Feedback, int64) {
+
+	sort.SliceStable(feedbacks, func(i, j int) bool { return feedbacks[i].CreatedAt.After(feedbacks[j].CreatedAt) })
+
+	start, end, pageCount := Paginate(len(feedbacks), pageNum, 40)
+	return feedbacks[start:end], pageCount
+}
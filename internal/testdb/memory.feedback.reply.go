@@ -0,0 +1,60 @@
+package testdb
+
+import (
+	"fmt"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/feedback"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// MemoryFeedbackReplyRepository is a type that defines a feedback reply repository that keeps the replies
+// in an in-memory database
+type MemoryFeedbackReplyRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryFeedbackReplyRepository is a function that creates a new in-memory feedback reply repository type
+func NewMemoryFeedbackReplyRepository(db *MemoryDB) feedback.IFeedbackReplyRepository {
+	return &MemoryFeedbackReplyRepository{db: db}
+}
+
+// Create is a method that adds a new feedback reply to the in-memory database
+func (repo *MemoryFeedbackReplyRepository) Create(newReply *entity.FeedbackReply) error {
+	totalNumOfReplies := repo.db.Count("feedback_replies")
+	newReply.ID = fmt.Sprintf("FR-%s%d", tools.RandomStringGN(7), totalNumOfReplies+1)
+
+	for !repo.db.IsUnique("id", newReply.ID, "feedback_replies") {
+		totalNumOfReplies++
+		newReply.ID = fmt.Sprintf("FR-%s%d", tools.RandomStringGN(7), totalNumOfReplies+1)
+	}
+
+	repo.db.Insert("feedback_replies", newReply)
+	return nil
+}
+
+// FindMultiple is a method that finds all the replies of a feedback ordered from the oldest to the newest.
+// In FindMultiple() only feedback_id is used as a key
+func (repo *MemoryFeedbackReplyRepository) FindMultiple(identifier string) []*entity.FeedbackReply {
+
+	replies := make([]*entity.FeedbackReply, 0)
+	for _, row := range repo.db.Select("feedback_replies",
+		func(row interface{}) bool { return row.(*entity.FeedbackReply).FeedbackID == identifier }) {
+		replies = append(replies, row.(*entity.FeedbackReply))
+	}
+
+	return replies
+}
+
+// DeleteMultiple is a method that deletes all the replies of a feedback from the in-memory database.
+// In DeleteMultiple() feedback_id is only used as an key
+func (repo *MemoryFeedbackReplyRepository) DeleteMultiple(identifier string) []*entity.FeedbackReply {
+
+	replies := make([]*entity.FeedbackReply, 0)
+	for _, row := range repo.db.Delete("feedback_replies",
+		func(row interface{}) bool { return row.(*entity.FeedbackReply).FeedbackID == identifier }) {
+		replies = append(replies, row.(*entity.FeedbackReply))
+	}
+
+	return replies
+}
@@ -0,0 +1,197 @@
+package testdb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/job"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// MemoryJobRepository is a type that defines a job repository that keeps the jobs in an in-memory database
+type MemoryJobRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryJobRepository is a function that creates a new in-memory job repository type
+func NewMemoryJobRepository(db *MemoryDB) job.IJobRepository {
+	return &MemoryJobRepository{db: db}
+}
+
+// Create is a method that adds a new job to the in-memory database
+func (repo *MemoryJobRepository) Create(newJob *entity.Job) error {
+	totalNumOfMembers := repo.db.Count("jobs")
+	newJob.ID = fmt.Sprintf("JB-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+
+	for !repo.db.IsUnique("id", newJob.ID, "jobs") {
+		totalNumOfMembers++
+		newJob.ID = fmt.Sprintf("JB-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+	}
+
+	repo.db.Insert("jobs", newJob)
+	return nil
+}
+
+// Find is a method that finds a certain job using an identifier,
+// also Find() uses only id as a key for selection
+func (repo *MemoryJobRepository) Find(identifier string) (*entity.Job, error) {
+
+	jobs := repo.selectJobs(func(job *entity.Job) bool { return job.ID == identifier })
+	if len(jobs) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return jobs[0], nil
+}
+
+// FindMultiple is a method that find multiple jobs that matches the given identifier
+// In FindMultiple() only employer is used as a key
+func (repo *MemoryJobRepository) FindMultiple(identifier string) []*entity.Job {
+	return repo.selectJobs(func(job *entity.Job) bool { return job.Employer == identifier })
+}
+
+// FindAll is a method that returns set of jobs limited to the page number and status
+func (repo *MemoryJobRepository) FindAll(status string, pageNum int64) ([]*entity.Job, int64) {
+	return repo.page(repo.selectJobs(func(job *entity.Job) bool {
+		return repo.hasStatus(job, status)
+	}), pageNum)
+}
+
+// SearchWRegx is a method that searchs and returns set of jobs whose columns start with the key identifier,
+// limited to the page number and status
+func (repo *MemoryJobRepository) SearchWRegx(key, status string, pageNum int64, columns ...string) ([]*entity.Job, int64) {
+	return repo.page(repo.selectJobs(func(job *entity.Job) bool {
+		return repo.hasStatus(job, status) && MatchColumns(job, key, true, columns...)
+	}), pageNum)
+}
+
+// Search is a method that searchs and returns set of jobs limited to the key identifier and page number
+func (repo *MemoryJobRepository) Search(key, status string, pageNum int64, columns ...string) ([]*entity.Job, int64) {
+	return repo.page(repo.selectJobs(func(job *entity.Job) bool {
+		return repo.hasStatus(job, status) && MatchColumns(job, key, false, columns...)
+	}), pageNum)
+}
+
+// All is a method that returns all the jobs found in the in-memory database
+func (repo *MemoryJobRepository) All() []*entity.Job {
+	return repo.selectJobs(nil)
+}
+
+// Total is a method that retruns the total number of jobs for the given status type
+func (repo *MemoryJobRepository) Total(status string) int64 {
+	return int64(len(repo.selectJobs(func(job *entity.Job) bool {
+		return status == entity.JobStatusAny || job.Status == status
+	})))
+}
+
+// Update is a method that updates a certain job entries in the in-memory database
+func (repo *MemoryJobRepository) Update(job *entity.Job) error {
+
+	prevJob, err := repo.Find(job.ID)
+	if err != nil {
+		return err
+	}
+
+	job.CreatedAt = prevJob.CreatedAt
+	repo.db.Replace("jobs", job, func(row interface{}) bool { return row.(*entity.Job).ID == job.ID })
+	return nil
+}
+
+// UpdateValue is a method that updates a certain job single column value in the in-memory database
+func (repo *MemoryJobRepository) UpdateValue(job *entity.Job, columnName string, columnValue interface{}) error {
+
+	updated, err := repo.db.Update("jobs", map[string]interface{}{columnName: columnValue},
+		func(row interface{}) bool { return row.(*entity.Job).ID == job.ID })
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return errors.New("record not found")
+	}
+	return nil
+}
+
+// ChangeStatus is a method that changes the status of a job, along with the given column values, only if the job
+// still has the previous status. It returns whether the job has been changed.
+func (repo *MemoryJobRepository) ChangeStatus(jobID, prevStatus, status string, values map[string]interface{}) (bool, error) {
+
+	columns := map[string]interface{}{"status": status}
+	for columnName, columnValue := range values {
+		columns[columnName] = columnValue
+	}
+
+	updated, err := repo.db.Update("jobs", columns, func(row interface{}) bool {
+		return row.(*entity.Job).ID == jobID && row.(*entity.Job).Status == prevStatus
+	})
+
+	return updated > 0, err
+}
+
+// FindDueJobs is a method that returns all the jobs that will reach their due date by the given time
+// depending on the provided job status
+func (repo *MemoryJobRepository) FindDueJobs(dueDate time.Time, status string) []*entity.Job {
+	return repo.selectJobs(func(job *entity.Job) bool { return repo.isDue(job, dueDate, status) })
+}
+
+// CloseDueJobs is a method that updates multiple jobs' status to closed which have reached the given due date
+// depending on the provided job status
+func (repo *MemoryJobRepository) CloseDueJobs(dueDate time.Time, status string) []*entity.Job {
+
+	closeableJobs := repo.FindDueJobs(dueDate, status)
+
+	repo.db.Update("jobs", map[string]interface{}{"status": entity.JobStatusClosed},
+		func(row interface{}) bool { return repo.isDue(row.(*entity.Job), dueDate, status) })
+
+	return closeableJobs
+}
+
+// Delete is a method that deletes a certain job from the in-memory database using an identifier.
+// In Delete() id is only used as an key
+func (repo *MemoryJobRepository) Delete(identifier string) (*entity.Job, error) {
+	deleted := repo.db.Delete("jobs", func(row interface{}) bool { return row.(*entity.Job).ID == identifier })
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+
+	return deleted[0].(*entity.Job), nil
+}
+
+// isDue is a method that checks whether a job has the given status and a due date that isn't after the given time
+func (repo *MemoryJobRepository) isDue(job *entity.Job, dueDate time.Time, status string) bool {
+	return job.Status == status && job.DueDate != nil && !job.DueDate.After(dueDate)
+}
+
+// hasStatus is a method that checks whether a job has the given status, any other status value matches every job
+func (repo *MemoryJobRepository) hasStatus(job *entity.Job, status string) bool {
+	switch status {
+	case entity.JobStatusPending, entity.JobStatusOpened, entity.JobStatusClosed, entity.JobStatusDecelined:
+		return job.Status == status
+	}
+
+	return true
+}
+
+// selectJobs is a method that returns the jobs that satisfy the given filter, a nil filter selects every job
+func (repo *MemoryJobRepository) selectJobs(filter func(job *entity.Job) bool) []*entity.Job {
+
+	jobs := make([]*entity.Job, 0)
+	for _, row := range repo.db.Select("jobs", nil) {
+		if filter == nil || filter(row.(*entity.Job)) {
+			jobs = append(jobs, row.(*entity.Job))
+		}
+	}
+
+	return jobs
+}
+
+// page is a method that orders the given jobs from the newest and returns the jobs of the given page with the page count
+func (repo *MemoryJobRepository) page(jobs []*entity.Job, pageNum int64) ([]*entity.Job, int64) {
+
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+
+	start, end, pageCount := Paginate(len(jobs), pageNum, 40)
+	return jobs[start:end], pageCount
+}
@@ -0,0 +1,130 @@
+package testdb
+
+import (
+	"errors"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/jobapplication"
+)
+
+// MemoryJobApplicationRepository is a type that defines a job application repository that keeps the job applications
+// in an in-memory database
+type MemoryJobApplicationRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryJobApplicationRepository is a function that creates a new in-memory job application repository type
+func NewMemoryJobApplicationRepository(db *MemoryDB) jobapplication.IJobApplicationRepository {
+	return &MemoryJobApplicationRepository{db: db}
+}
+
+// Create is a method that adds a new job application to the in-memory database
+func (repo *MemoryJobApplicationRepository) Create(newJobApplication *entity.JobApplication) error {
+	if repo.HasApplied(newJobApplication.JobID, newJobApplication.JobSeekerID) {
+		return errors.New("duplicate job application")
+	}
+
+	repo.db.Insert("job_applications", newJobApplication)
+	return nil
+}
+
+// Find is a method that searches and returns a set of job applications that are limited to the provided identifier.
+// In Find() job_id or job_seeker_id can either be used as a key.
+func (repo *MemoryJobApplicationRepository) Find(identifier string) []*entity.JobApplication {
+	return repo.selectJobApplications(func(jobApplication *entity.JobApplication) bool {
+		return jobApplication.JobID == identifier || jobApplication.JobSeekerID == identifier
+	})
+}
+
+// FindApplication is a method that finds the job application of a job seeker for a certain job
+func (repo *MemoryJobApplicationRepository) FindApplication(jobID, jobSeekerID string) (*entity.JobApplication, error) {
+
+	jobApplications := repo.selectJobApplications(repo.matchApplication(jobID, jobSeekerID))
+	if len(jobApplications) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return jobApplications[0], nil
+}
+
+// HasApplied is a method that checks whether a job seeker has applied to a certain job
+func (repo *MemoryJobApplicationRepository) HasApplied(jobID, jobSeekerID string) bool {
+	return len(repo.selectJobApplications(repo.matchApplication(jobID, jobSeekerID))) > 0
+}
+
+// Update is a method that updates a certain job application entries in the in-memory database
+func (repo *MemoryJobApplicationRepository) Update(jobApplication *entity.JobApplication) error {
+
+	prevJobApplication, err := repo.FindApplication(jobApplication.JobID, jobApplication.JobSeekerID)
+	if err != nil {
+		return err
+	}
+
+	jobApplication.CreatedAt = prevJobApplication.CreatedAt
+	match := repo.matchApplication(jobApplication.JobID, jobApplication.JobSeekerID)
+	repo.db.Replace("job_applications", jobApplication,
+		func(row interface{}) bool { return match(row.(*entity.JobApplication)) })
+	return nil
+}
+
+// ChangeStatus is a method that changes the status of a job application only if it still has the previous status.
+// It returns whether the job application has been changed.
+func (repo *MemoryJobApplicationRepository) ChangeStatus(jobID, jobSeekerID, prevStatus, status string) (bool, error) {
+
+	match := repo.matchApplication(jobID, jobSeekerID)
+	updated, err := repo.db.Update("job_applications", map[string]interface{}{"status": status},
+		func(row interface{}) bool {
+			return match(row.(*entity.JobApplication)) && row.(*entity.JobApplication).Status == prevStatus
+		})
+
+	return updated > 0, err
+}
+
+// Delete is a method that deletes a certain job application from the in-memory database using job_id and job_seeker_id.
+func (repo *MemoryJobApplicationRepository) Delete(jobID, jobSeekerID string) (*entity.JobApplication, error) {
+
+	match := repo.matchApplication(jobID, jobSeekerID)
+	deleted := repo.db.Delete("job_applications",
+		func(row interface{}) bool { return match(row.(*entity.JobApplication)) })
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+
+	return deleted[0].(*entity.JobApplication), nil
+}
+
+// DeleteMultiple is a method that deletes a set of job applications from the in-memory database using an identifier.
+// In DeleteMultiple() job_id or job_seeker_id can either be used as a key.
+func (repo *MemoryJobApplicationRepository) DeleteMultiple(identifier string) []*entity.JobApplication {
+
+	jobApplications := make([]*entity.JobApplication, 0)
+	for _, row := range repo.db.Delete("job_applications", func(row interface{}) bool {
+		jobApplication := row.(*entity.JobApplication)
+		return jobApplication.JobID == identifier || jobApplication.JobSeekerID == identifier
+	}) {
+		jobApplications = append(jobApplications, row.(*entity.JobApplication))
+	}
+
+	return jobApplications
+}
+
+// matchApplication is a method that returns a filter that matches the job application of a job seeker for a certain job
+func (repo *MemoryJobApplicationRepository) matchApplication(jobID,
+	jobSeekerID string) func(jobApplication *entity.JobApplication) bool {
+	return func(jobApplication *entity.JobApplication) bool {
+		return jobApplication.JobID == jobID && jobApplication.JobSeekerID == jobSeekerID
+	}
+}
+
+// selectJobApplications is a method that returns the job applications that satisfy the given filter
+func (repo *MemoryJobApplicationRepository) selectJobApplications(
+	filter func(jobApplication *entity.JobApplication) bool) []*entity.JobApplication {
+
+	jobApplications := make([]*entity.JobApplication, 0)
+	for _, row := range repo.db.Select("job_applications", nil) {
+		if filter(row.(*entity.JobApplication)) {
+			jobApplications = append(jobApplications, row.(*entity.JobApplication))
+		}
+	}
+
+	return jobApplications
+}
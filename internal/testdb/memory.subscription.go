@@ -0,0 +1,141 @@
+package testdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/subscription"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// MemorySubscriptionRepository is a type that defines a job subscription repository that keeps the subscriptions
+// in an in-memory database
+type MemorySubscriptionRepository struct {
+	db *MemoryDB
+}
+
+// NewMemorySubscriptionRepository is a function that creates a new in-memory job subscription repository type
+func NewMemorySubscriptionRepository(db *MemoryDB) subscription.ISubscriptionRepository {
+	return &MemorySubscriptionRepository{db: db}
+}
+
+// Create is a method that adds a new job subscription to the in-memory database
+func (repo *MemorySubscriptionRepository) Create(newSubscription *entity.Subscription) error {
+	totalNumOfSubscriptions := repo.db.Count("subscriptions")
+	newSubscription.ID = fmt.Sprintf("SB-%s%d", tools.RandomStringGN(7), totalNumOfSubscriptions+1)
+
+	for !repo.db.IsUnique("id", newSubscription.ID, "subscriptions") {
+		totalNumOfSubscriptions++
+		newSubscription.ID = fmt.Sprintf("SB-%s%d", tools.RandomStringGN(7), totalNumOfSubscriptions+1)
+	}
+
+	repo.db.Insert("subscriptions", newSubscription)
+	return nil
+}
+
+// Find is a method that finds a certain job subscription using an identifier,
+// also Find() uses only id as a key for selection
+func (repo *MemorySubscriptionRepository) Find(identifier string) (*entity.Subscription, error) {
+
+	subscriptions := repo.selectSubscriptions(func(subscription *entity.Subscription) bool {
+		return subscription.ID == identifier
+	})
+
+	if len(subscriptions) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return subscriptions[0], nil
+}
+
+// FindMultiple is a method that finds multiple job subscriptions that matches the given identifier
+// In FindMultiple() only user_id is used as a key
+func (repo *MemorySubscriptionRepository) FindMultiple(identifier string) []*entity.Subscription {
+	return repo.selectSubscriptions(func(subscription *entity.Subscription) bool {
+		return subscription.UserID == identifier
+	})
+}
+
+// Total is a method that retruns the total number of subscribers for job push notifications
+func (repo *MemorySubscriptionRepository) Total() int64 {
+
+	subscribers := make(map[string]bool)
+	for _, subscription := range repo.selectSubscriptions(nil) {
+		subscribers[subscription.UserID] = true
+	}
+
+	return int64(len(subscribers))
+}
+
+// Match is a method that finds a multiple subscriptions that match the given job type and sector
+func (repo *MemorySubscriptionRepository) Match(jobSectors, jobTypes, educationLevels, experiences []string) []*entity.Subscription {
+	return repo.selectSubscriptions(func(subscription *entity.Subscription) bool {
+		return contains(jobSectors, subscription.Sector) && contains(jobTypes, subscription.Type) &&
+			contains(educationLevels, subscription.EducationLevel) && contains(experiences, subscription.Experience)
+	})
+}
+
+// Update is a method that updates a certain job subscription entries in the in-memory database
+func (repo *MemorySubscriptionRepository) Update(subscription *entity.Subscription) error {
+
+	prevSubscription, err := repo.Find(subscription.ID)
+	if err != nil {
+		return err
+	}
+
+	subscription.CreatedAt = prevSubscription.CreatedAt
+	repo.db.Replace("subscriptions", subscription,
+		func(row interface{}) bool { return row.(*entity.Subscription).ID == subscription.ID })
+	return nil
+}
+
+// Delete is a method that deletes a certain job subscription from the in-memory database using an identifier.
+// In Delete() id is only used as an key
+func (repo *MemorySubscriptionRepository) Delete(identifier string) (*entity.Subscription, error) {
+	deleted := repo.db.Delete("subscriptions",
+		func(row interface{}) bool { return row.(*entity.Subscription).ID == identifier })
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+
+	return deleted[0].(*entity.Subscription), nil
+}
+
+// DeleteMultiple is a method that deletes a set of job subscriptions from the in-memory database using an identifier.
+// In Delete() user_id is only used as an key
+func (repo *MemorySubscriptionRepository) DeleteMultiple(identifier string) []*entity.Subscription {
+
+	subscriptions := make([]*entity.Subscription, 0)
+	for _, row := range repo.db.Delete("subscriptions",
+		func(row interface{}) bool { return row.(*entity.Subscription).UserID == identifier }) {
+		subscriptions = append(subscriptions, row.(*entity.Subscription))
+	}
+
+	return subscriptions
+}
+
+// selectSubscriptions is a method that returns the job subscriptions that satisfy the given filter,
+// a nil filter selects every subscription
+func (repo *MemorySubscriptionRepository) selectSubscriptions(
+	filter func(subscription *entity.Subscription) bool) []*entity.Subscription {
+
+	subscriptions := make([]*entity.Subscription, 0)
+	for _, row := range repo.db.Select("subscriptions", nil) {
+		if filter == nil || filter(row.(*entity.Subscription)) {
+			subscriptions = append(subscriptions, row.(*entity.Subscription))
+		}
+	}
+
+	return subscriptions
+}
+
+// contains is a function that checks whether a value is found in the given list
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,181 @@
+package testdb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/Benyam-S/asseri/user"
+)
+
+// MemoryUserRepository is a type that defines a user repository that keeps the users in an in-memory database
+type MemoryUserRepository struct {
+	db *MemoryDB
+}
+
+// NewMemoryUserRepository is a function that creates a new in-memory user repository type
+func NewMemoryUserRepository(db *MemoryDB) user.IUserRepository {
+	return &MemoryUserRepository{db: db}
+}
+
+// Create is a method that adds a new user to the in-memory database
+func (repo *MemoryUserRepository) Create(newUser *entity.User) error {
+	totalNumOfMembers := repo.db.Count("users")
+	newUser.ID = fmt.Sprintf("UR-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+
+	for !repo.db.IsUnique("id", newUser.ID, "users") {
+		totalNumOfMembers++
+		newUser.ID = fmt.Sprintf("UR-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+	}
+
+	if !repo.db.IsUnique("phone_number", newUser.PhoneNumber, "users") {
+		return errors.New("duplicate phone number")
+	}
+
+	repo.db.Insert("users", newUser)
+	return nil
+}
+
+// Find is a method that finds a certain user using an identifier,
+// also Find() uses id and phone_number as a key for selection
+func (repo *MemoryUserRepository) Find(identifier string) (*entity.User, error) {
+
+	modifiedIdentifier := identifier
+	if strings.HasPrefix(identifier, "0") {
+		modifiedIdentifier = "+251" + identifier[1:]
+	}
+
+	users := repo.selectUsers(func(user *entity.User) bool {
+		return user.ID == identifier || user.PhoneNumber == modifiedIdentifier
+	})
+
+	if len(users) == 0 {
+		return nil, errors.New("record not found")
+	}
+	return users[0], nil
+}
+
+// FindAll is a method that returns set of users limited to the page number and category
+func (repo *MemoryUserRepository) FindAll(category string, pageNum int64) ([]*entity.User, int64) {
+	return repo.page(repo.selectUsers(func(user *entity.User) bool {
+		return category == entity.UserCategoryAny || user.Category == category
+	}), pageNum)
+}
+
+// SearchWRegx is a method that searchs and returns set of users whose columns start with the key identifier,
+// limited to the page number and category
+func (repo *MemoryUserRepository) SearchWRegx(key, category string, pageNum int64, columns ...string) ([]*entity.User, int64) {
+	return repo.page(repo.selectUsers(func(user *entity.User) bool {
+		return (category == entity.UserCategoryAny || user.Category == category) &&
+			MatchColumns(user, key, true, columns...)
+	}), pageNum)
+}
+
+// Search is a method that searchs and returns set of users limited to the key identifier, page number and category
+func (repo *MemoryUserRepository) Search(key, category string, pageNum int64, columns ...string) ([]*entity.User, int64) {
+
+	modifiedKey := key
+	if strings.HasPrefix(key, "0") {
+		modifiedKey = "+251" + key[1:]
+	}
+
+	return repo.page(repo.selectUsers(func(user *entity.User) bool {
+		if category != entity.UserCategoryAny && user.Category != category {
+			return false
+		}
+
+		for _, column := range columns {
+			// modifying the key so that it can match the stored phone number values
+			if column == "phone_number" && MatchColumns(user, modifiedKey, false, column) {
+				return true
+			} else if column != "phone_number" && MatchColumns(user, key, false, column) {
+				return true
+			}
+		}
+		return false
+	}), pageNum)
+}
+
+// All is a method that returns all the users found in the in-memory database
+func (repo *MemoryUserRepository) All() []*entity.User {
+	return repo.selectUsers(nil)
+}
+
+// Total is a method that retruns the total number of users for the given user category
+func (repo *MemoryUserRepository) Total(category string) int64 {
+	return int64(len(repo.selectUsers(func(user *entity.User) bool {
+		return category == entity.UserCategoryAny || user.Category == category
+	})))
+}
+
+// FromTo is a method that returns total number of users between start and end time
+func (repo *MemoryUserRepository) FromTo(start, end time.Time) int64 {
+	return int64(len(repo.selectUsers(func(user *entity.User) bool {
+		return !user.CreatedAt.Before(start) && !user.CreatedAt.After(end)
+	})))
+}
+
+// Update is a method that updates a certain user entries in the in-memory database
+func (repo *MemoryUserRepository) Update(user *entity.User) error {
+
+	prevUser, err := repo.Find(user.ID)
+	if err != nil || prevUser.ID != user.ID {
+		return errors.New("record not found")
+	}
+
+	user.CreatedAt = prevUser.CreatedAt
+	repo.db.Replace("users", user, func(row interface{}) bool { return row.(*entity.User).ID == user.ID })
+	return nil
+}
+
+// UpdateValue is a method that updates a certain user single column value in the in-memory database
+func (repo *MemoryUserRepository) UpdateValue(user *entity.User, columnName string, columnValue interface{}) error {
+
+	updated, err := repo.db.Update("users", map[string]interface{}{columnName: columnValue},
+		func(row interface{}) bool { return row.(*entity.User).ID == user.ID })
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return errors.New("record not found")
+	}
+	return nil
+}
+
+// Delete is a method that deletes a certain user from the in-memory database using an identifier.
+// In Delete() id is only used as an key
+func (repo *MemoryUserRepository) Delete(identifier string) (*entity.User, error) {
+	deleted := repo.db.Delete("users", func(row interface{}) bool { return row.(*entity.User).ID == identifier })
+	if len(deleted) == 0 {
+		return nil, errors.New("record not found")
+	}
+
+	return deleted[0].(*entity.User), nil
+}
+
+// selectUsers is a method that returns the users that satisfy the given filter, a nil filter selects every user
+func (repo *MemoryUserRepository) selectUsers(filter func(user *entity.User) bool) []*entity.User {
+
+	users := make([]*entity.User, 0)
+	for _, row := range repo.db.Select("users", nil) {
+		if filter == nil || filter(row.(*entity.User)) {
+			users = append(users, row.(*entity.User))
+		}
+	}
+
+	return users
+}
+
+// page is a method that orders the given users by name and returns the users of the given page with the page count
+func (repo *MemoryUserRepository) page(users []*entity.User, pageNum int64) ([]*entity.User, int64) {
+
+	sort.SliceStable(users, func(i, j int) bool { return users[i].UserName < users[j].UserName })
+
+	start, end, pageCount := Paginate(len(users), pageNum, 20)
+	return users[start:end], pageCount
+}
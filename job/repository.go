@@ -18,6 +18,8 @@ type IJobRepository interface {
 	Total(status string) int64
 	Update(job *entity.Job) error
 	UpdateValue(job *entity.Job, columnName string, columnValue interface{}) error
+	ChangeStatus(jobID, prevStatus, status string, values map[string]interface{}) (bool, error)
+	FindDueJobs(time.Time, string) []*entity.Job
 	CloseDueJobs(time.Time, string) []*entity.Job
 	Delete(identifier string) (*entity.Job, error)
 }
@@ -239,6 +239,37 @@ func (repo *JobRepository) UpdateValue(job *entity.Job, columnName string, colum
 	return nil
 }
 
+// ChangeStatus is a method that changes the status of a job, along with the given column values, only if the job
+// still has the previous status. It returns whether the job has been changed so concurrent status changes can't both succeed.
+func (repo *JobRepository) ChangeStatus(jobID, prevStatus, status string, values map[string]interface{}) (bool, error) {
+
+	columns := map[string]interface{}{"status": status}
+	for columnName, columnValue := range values {
+		columns[columnName] = columnValue
+	}
+
+	result := repo.conn.Model(entity.Job{}).Where("id = ? && status = ?", jobID, prevStatus).Update(columns)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// FindDueJobs is a method that returns all the jobs that will reach their due date by the given time
+// depending on the provided job status
+func (repo *JobRepository) FindDueJobs(dueDate time.Time, status string) []*entity.Job {
+
+	var dueJobs []*entity.Job
+	err := repo.conn.Model(entity.Job{}).Where("status = ? && due_date <= ?", status, dueDate).Find(&dueJobs).Error
+
+	if err != nil {
+		return []*entity.Job{}
+	}
+
+	return dueJobs
+}
+
 // CloseDueJobs is a method that updates multiple jobs' status to closed which have reached the given due date
 // depending on the provided job status
 func (repo *JobRepository) CloseDueJobs(dueDate time.Time, status string) []*entity.Job {
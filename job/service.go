@@ -19,6 +19,8 @@ type IService interface {
 	UpdateJob(job *entity.Job) error
 	UpdateJobSingleValue(jobID, columnName string, columnValue interface{}) error
 	ChangeJobStatus(jobID, status string) (*entity.Job, error)
+	ReviewJob(jobID, status, reviewedBy, reviewNote string) (*entity.Job, error)
+	FindDueJobs(time.Time, string) []*entity.Job
 	CloseDueJobs(time.Time, string) []*entity.Job
 	DeleteJob(jobID string) (*entity.Job, error)
 }
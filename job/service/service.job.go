@@ -18,12 +18,13 @@ type Service struct {
 	jobRepo   job.IJobRepository
 	userRepo  user.IUserRepository
 	cmService common.IService
+	clock     tools.IClock
 }
 
-// NewJobService is a function that returns a new job service
+// NewJobService is a function that returns a new job service, the clock is used for validating due dates
 func NewJobService(jobRepository job.IJobRepository, userRepository user.IUserRepository,
-	commonService common.IService) job.IService {
-	return &Service{jobRepo: jobRepository, userRepo: userRepository, cmService: commonService}
+	commonService common.IService, clock tools.IClock) job.IService {
+	return &Service{jobRepo: jobRepository, userRepo: userRepository, cmService: commonService, clock: clock}
 }
 
 // AddJob is a method that adds a new job to the system
@@ -177,7 +178,7 @@ func (service *Service) ValidateJob(job *entity.Job) entity.ErrMap {
 		errMap["gender"] = errors.New("invalid gender selection")
 	}
 
-	dateBase := time.Now().Add(time.Hour * 3)
+	dateBase := service.clock.Now().Add(time.Hour * 3)
 	if job.DueDate != nil && job.DueDate.Unix() < dateBase.Unix() {
 		errMap["due_date"] = errors.New("due date must exceed the current time at least by 3 hours")
 	}
@@ -275,6 +276,11 @@ func (service *Service) AllJobs() []*entity.Job {
 	return service.jobRepo.All()
 }
 
+// FindDueJobs is a method that returns all the jobs with the given status that will reach their due date by the given time
+func (service *Service) FindDueJobs(dueDate time.Time, status string) []*entity.Job {
+	return service.jobRepo.FindDueJobs(dueDate, status)
+}
+
 // CloseDueJobs is a method that closes all the jobs that have reached their due date for given job status
 func (service *Service) CloseDueJobs(dueDate time.Time, status string) []*entity.Job {
 	return service.jobRepo.CloseDueJobs(dueDate, status)
@@ -375,6 +381,22 @@ func (service *Service) UpdateJobSingleValue(jobID, columnName string, columnVal
 
 // ChangeJobStatus is a method that changes the given job status
 func (service *Service) ChangeJobStatus(jobID, status string) (*entity.Job, error) {
+	return service.changeJobStatus(jobID, status, nil)
+}
+
+// ReviewJob is a method that approves or declines a pending job and records the reviewer along with the note
+// in the same update, so the review can't overwrite changes made to the job in the meantime
+func (service *Service) ReviewJob(jobID, status, reviewedBy, reviewNote string) (*entity.Job, error) {
+	if status != entity.JobStatusOpened && status != entity.JobStatusDecelined {
+		return nil, errors.New("unable to perform operation")
+	}
+
+	return service.changeJobStatus(jobID, status, map[string]interface{}{"reviewed_by": reviewedBy,
+		"review_note": reviewNote})
+}
+
+// changeJobStatus is a method that changes the given job status along with the given column values
+func (service *Service) changeJobStatus(jobID, status string, values map[string]interface{}) (*entity.Job, error) {
 
 	job, err := service.jobRepo.Find(jobID)
 	if err != nil {
@@ -394,13 +416,17 @@ func (service *Service) ChangeJobStatus(jobID, status string) (*entity.Job, erro
 		return nil, errors.New("unable to perform operation")
 	}
 
-	job.Status = status
-	err = service.jobRepo.Update(job)
+	// The status is only changed if no one else has changed it since it has been checked
+	changed, err := service.jobRepo.ChangeStatus(job.ID, job.Status, status, values)
 	if err != nil {
 		return nil, errors.New("unable to update job")
 	}
 
-	return job, nil
+	if !changed {
+		return nil, errors.New("job status has already been changed")
+	}
+
+	return service.jobRepo.Find(jobID)
 }
 
 // DeleteJob is a method that deletes a job from the system
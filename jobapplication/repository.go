@@ -6,7 +6,10 @@ import "github.com/Benyam-S/asseri/entity"
 type IJobApplicationRepository interface {
 	Create(newJobApplication *entity.JobApplication) error
 	Find(identifier string) []*entity.JobApplication
+	FindApplication(jobID, jobSeekerID string) (*entity.JobApplication, error)
 	HasApplied(jobID, jobSeekerID string) bool
+	Update(jobApplication *entity.JobApplication) error
+	ChangeStatus(jobID, jobSeekerID, prevStatus, status string) (bool, error)
 	Delete(jobID, jobSeekerID string) (*entity.JobApplication, error)
 	DeleteMultiple(identifier string) []*entity.JobApplication
 }
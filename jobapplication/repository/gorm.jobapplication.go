@@ -39,6 +39,20 @@ func (repo *JobApplicationRepository) Find(identifier string) []*entity.JobAppli
 	return jobApplications
 }
 
+// FindApplication is a method that finds the job application of a job seeker for a certain job
+func (repo *JobApplicationRepository) FindApplication(jobID, jobSeekerID string) (*entity.JobApplication, error) {
+
+	jobApplication := new(entity.JobApplication)
+	err := repo.conn.Model(jobApplication).
+		Where("job_id = ? && job_seeker_id = ?", jobID, jobSeekerID).
+		First(jobApplication).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return jobApplication, nil
+}
+
 // HasApplied is a method that checks whether a job seeker has applied to a certain job by checking
 // the job application relation table
 func (repo *JobApplicationRepository) HasApplied(jobID, jobSeekerID string) bool {
@@ -55,6 +69,44 @@ func (repo *JobApplicationRepository) HasApplied(jobID, jobSeekerID string) bool
 	return true
 }
 
+// Update is a method that updates a certain job application entries in the database
+func (repo *JobApplicationRepository) Update(jobApplication *entity.JobApplication) error {
+
+	prevJobApplication, err := repo.FindApplication(jobApplication.JobID, jobApplication.JobSeekerID)
+	if err != nil {
+		return err
+	}
+
+	/* --------------------------- can change layer if needed --------------------------- */
+	jobApplication.CreatedAt = prevJobApplication.CreatedAt
+	/* -------------------------------------- end --------------------------------------- */
+
+	err = repo.conn.Save(jobApplication).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ChangeStatus is a method that changes the status of a job application only if it still has the previous status.
+// It returns whether the job application has been changed so concurrent status changes can't both succeed.
+func (repo *JobApplicationRepository) ChangeStatus(jobID, jobSeekerID, prevStatus, status string) (bool, error) {
+
+	// Applications made before statuses were introduced have an empty status
+	whereStmt := "job_id = ? && job_seeker_id = ? && status = ?"
+	if prevStatus == "" {
+		whereStmt = "job_id = ? && job_seeker_id = ? && (status = ? || status IS NULL)"
+	}
+
+	result := repo.conn.Model(entity.JobApplication{}).Where(whereStmt, jobID, jobSeekerID, prevStatus).
+		Update("status", status)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
 // Delete is a method that deletes a certain job application from the database using job_id and job_seeker_id.
 func (repo *JobApplicationRepository) Delete(jobID, jobSeekerID string) (*entity.JobApplication, error) {
 	jobApplication := new(entity.JobApplication)
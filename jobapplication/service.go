@@ -6,7 +6,9 @@ import "github.com/Benyam-S/asseri/entity"
 type IService interface {
 	AddJobApplication(newJobApplication *entity.JobApplication) error
 	FindJobApplications(identifier string) []*entity.JobApplication
+	FindJobApplication(jobID, jobSeekerID string) (*entity.JobApplication, error)
 	JobApplicationExists(jobID, jobSeekerID string) bool
+	ChangeJobApplicationStatus(jobID, jobSeekerID, status string) (*entity.JobApplication, error)
 	DeleteJobApplication(jobID, jobSeekerID string) (*entity.JobApplication, error)
 	DeleteMultipleJobApplications(identifier string) []*entity.JobApplication
 }
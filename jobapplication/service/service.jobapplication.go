@@ -23,6 +23,10 @@ func NewJobApplicationService(jobApplicationRepository jobapplication.IJobApplic
 
 // AddJobApplication is a method that adds a new job application to the system
 func (service *Service) AddJobApplication(newJobApplication *entity.JobApplication) error {
+
+	// Every application starts as submitted regardless of the given status
+	newJobApplication.Status = entity.JobApplicationSubmitted
+
 	err := service.jobApplicationRepo.Create(newJobApplication)
 	if err != nil {
 		return errors.New("unable to add new job application")
@@ -42,11 +46,49 @@ func (service *Service) FindJobApplications(identifier string) []*entity.JobAppl
 	return service.jobApplicationRepo.Find(identifier)
 }
 
+// FindJobApplication is a method that find and return the job application of a job seeker for a certain job
+func (service *Service) FindJobApplication(jobID, jobSeekerID string) (*entity.JobApplication, error) {
+
+	jobApplication, err := service.jobApplicationRepo.FindApplication(jobID, jobSeekerID)
+	if err != nil {
+		return nil, errors.New("job application not found")
+	}
+
+	return jobApplication, nil
+}
+
 // JobApplicationExists is a method that checks whether the job application exists or not
 func (service *Service) JobApplicationExists(jobID, jobSeekerID string) bool {
 	return service.jobApplicationRepo.HasApplied(jobID, jobSeekerID)
 }
 
+// ChangeJobApplicationStatus is a method that moves a job application to the given status.
+// An application can only move forward, submitted -> viewed -> shortlisted -> hired, and can be rejected
+// at any point before the job seeker is hired.
+func (service *Service) ChangeJobApplicationStatus(jobID, jobSeekerID, status string) (*entity.JobApplication, error) {
+
+	jobApplication, err := service.jobApplicationRepo.FindApplication(jobID, jobSeekerID)
+	if err != nil {
+		return nil, errors.New("job application not found")
+	}
+
+	if !isValidTransition(jobApplication.Status, status) {
+		return nil, errors.New("invalid job application status")
+	}
+
+	// The status is only changed if it hasn't been changed since it was read, so concurrent changes can't skip the transitions
+	changed, err := service.jobApplicationRepo.ChangeStatus(jobID, jobSeekerID, jobApplication.Status, status)
+	if err != nil {
+		return nil, errors.New("unable to update job application")
+	}
+
+	if !changed {
+		return nil, errors.New("job application status has already been changed")
+	}
+
+	return service.FindJobApplication(jobID, jobSeekerID)
+}
+
 // DeleteJobApplication is a method that deletes a certain job application from the system
 func (service *Service) DeleteJobApplication(jobID, jobSeekerID string) (*entity.JobApplication, error) {
 
@@ -62,3 +104,24 @@ func (service *Service) DeleteJobApplication(jobID, jobSeekerID string) (*entity
 func (service *Service) DeleteMultipleJobApplications(identifier string) []*entity.JobApplication {
 	return service.jobApplicationRepo.DeleteMultiple(identifier)
 }
+
+// isValidTransition is a function that checks whether a job application can move from one status to the other
+func isValidTransition(from, to string) bool {
+
+	// Applications made before statuses were introduced don't have a status
+	if from == "" {
+		from = entity.JobApplicationSubmitted
+	}
+
+	switch from {
+	case entity.JobApplicationSubmitted:
+		return to == entity.JobApplicationViewed || to == entity.JobApplicationShortlisted ||
+			to == entity.JobApplicationRejected
+	case entity.JobApplicationViewed:
+		return to == entity.JobApplicationShortlisted || to == entity.JobApplicationRejected
+	case entity.JobApplicationShortlisted:
+		return to == entity.JobApplicationHired || to == entity.JobApplicationRejected
+	}
+
+	return false
+}
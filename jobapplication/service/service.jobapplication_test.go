@@ -0,0 +1,89 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/internal/testdb"
+)
+
+func TestIsValidTransition(t *testing.T) {
+
+	statuses := []string{entity.JobApplicationSubmitted, entity.JobApplicationViewed, entity.JobApplicationShortlisted,
+		entity.JobApplicationRejected, entity.JobApplicationHired}
+
+	// The statuses each status can move to, any other status is invalid
+	valid := map[string][]string{
+		"": {entity.JobApplicationViewed, entity.JobApplicationShortlisted, entity.JobApplicationRejected},
+		entity.JobApplicationSubmitted: {entity.JobApplicationViewed, entity.JobApplicationShortlisted,
+			entity.JobApplicationRejected},
+		entity.JobApplicationViewed:      {entity.JobApplicationShortlisted, entity.JobApplicationRejected},
+		entity.JobApplicationShortlisted: {entity.JobApplicationHired, entity.JobApplicationRejected},
+		entity.JobApplicationRejected:    {},
+		entity.JobApplicationHired:       {},
+	}
+
+	for from, tos := range valid {
+		for _, to := range append(statuses, "", "Unknown") {
+			expected := false
+			for _, validTo := range tos {
+				expected = expected || validTo == to
+			}
+
+			if isValidTransition(from, to) != expected {
+				t.Errorf("expected the transition from %q to %q to be valid %t", from, to, expected)
+			}
+		}
+	}
+
+	if isValidTransition("Unknown", entity.JobApplicationViewed) {
+		t.Errorf("expected no transition from an unknown status to be valid")
+	}
+}
+
+func TestChangeJobApplicationStatusConcurrently(t *testing.T) {
+	service := NewJobApplicationService(testdb.NewMemoryJobApplicationRepository(testdb.NewMemoryDB()), nil)
+
+	if err := service.AddJobApplication(&entity.JobApplication{JobID: "JB-1", JobSeekerID: "UR-1"}); err != nil {
+		t.Fatalf("unable to add the job application, %s", err)
+	}
+
+	// Only one of the changes made from the same status can succeed, so an application can't be
+	// both shortlisted and rejected
+	statuses := []string{entity.JobApplicationShortlisted, entity.JobApplicationRejected}
+	changed := make([]bool, 10)
+
+	var wg sync.WaitGroup
+	for i := range changed {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.ChangeJobApplicationStatus("JB-1", "UR-1", statuses[i%2])
+			changed[i] = err == nil
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, ok := range changed {
+		if ok {
+			succeeded++
+		}
+	}
+
+	jobApplication, _ := service.FindJobApplication("JB-1", "UR-1")
+	if succeeded != 1 || jobApplication.Status == entity.JobApplicationSubmitted {
+		t.Errorf("expected exactly one change to succeed, got %d with status %s", succeeded, jobApplication.Status)
+	}
+
+	// The changed status is returned and later transitions start from it
+	if jobApplication.Status == entity.JobApplicationShortlisted {
+		hired, err := service.ChangeJobApplicationStatus("JB-1", "UR-1", entity.JobApplicationHired)
+		if err != nil || hired.Status != entity.JobApplicationHired {
+			t.Errorf("expected a shortlisted application to be hired, got %v", err)
+		}
+	} else if _, err := service.ChangeJobApplicationStatus("JB-1", "UR-1", entity.JobApplicationHired); err == nil {
+		t.Errorf("expected a rejected application not to be hired")
+	}
+}
@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"errors"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// FallbackNotifier is a type that delivers notifications through a primary channel and falls back to the
+// channel preferred by the user when the primary channel fails
+type FallbackNotifier struct {
+	primary INotifier
+	sms     INotifier
+	email   INotifier
+}
+
+// NewFallbackNotifier is a function that returns a new fallback notifier
+func NewFallbackNotifier(primary, sms, email INotifier) INotifier {
+	return &FallbackNotifier{primary: primary, sms: sms, email: email}
+}
+
+// Notify is a method that delivers the notification through the primary channel, or through the user's
+// fallback channel if that fails. ErrUnreachable is returned if neither of the channels can reach the user.
+func (notifier *FallbackNotifier) Notify(user *entity.User, notification *Notification) error {
+
+	err := notifier.primary.Notify(user, notification)
+	if err == nil {
+		return nil
+	}
+
+	fallback := notifier.fallback(user)
+	if fallback == nil {
+		return err
+	}
+
+	fallbackErr := fallback.Notify(user, notification)
+	if fallbackErr == nil {
+		return nil
+	}
+
+	// Only the failure of a channel that could have reached the user is worth reporting
+	if fallbackErr == ErrUnreachable {
+		return err
+	} else if err == ErrUnreachable {
+		return fallbackErr
+	}
+
+	return errors.New(err.Error() + ", " + fallbackErr.Error())
+}
+
+// fallback is a method that returns the channel the user prefers to be notified through when the primary
+// channel fails, a user who prefers email but hasn't provided an email address is notified by sms
+func (notifier *FallbackNotifier) fallback(user *entity.User) INotifier {
+
+	switch user.NotificationFallback {
+	case entity.NotificationFallbackNone:
+		return nil
+	case entity.NotificationFallbackEmail:
+		if user.Email != "" {
+			return notifier.email
+		}
+	}
+
+	return notifier.sms
+}
@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+func TestFallbackNotifier(t *testing.T) {
+
+	failure := errors.New("delivery failed")
+	notification := &Notification{Subject: "Job approved", Message: "<b>Junior Accountant</b> has been approved"}
+
+	smsUser := &entity.User{ID: "UR-1"}
+	emailUser := &entity.User{ID: "UR-2", NotificationFallback: entity.NotificationFallbackEmail, Email: "abebe@example.com"}
+	noAddressUser := &entity.User{ID: "UR-3", NotificationFallback: entity.NotificationFallbackEmail}
+	noneUser := &entity.User{ID: "UR-4", NotificationFallback: entity.NotificationFallbackNone}
+
+	tests := []struct {
+		name       string
+		user       *entity.User
+		primaryErr error
+		smsErr     error
+		emailErr   error
+		tried      []string // The channels expected to receive the notification in order
+		expected   error
+	}{
+		{"primary delivers", smsUser, nil, nil, nil, []string{"Telegram"}, nil},
+		{"primary fails then sms", smsUser, failure, nil, nil, []string{"Telegram", "SMS"}, nil},
+		{"primary unreachable then sms", smsUser, ErrUnreachable, nil, nil, []string{"Telegram", "SMS"}, nil},
+		{"email preferred", emailUser, failure, nil, nil, []string{"Telegram", "Email"}, nil},
+		{"email preferred without an address", noAddressUser, failure, nil, nil, []string{"Telegram", "SMS"}, nil},
+		{"none preferred", noneUser, failure, nil, nil, []string{"Telegram"}, failure},
+		{"none preferred and unreachable", noneUser, ErrUnreachable, nil, nil, []string{"Telegram"}, ErrUnreachable},
+		{"unreachable everywhere", smsUser, ErrUnreachable, ErrUnreachable, nil, []string{"Telegram", "SMS"},
+			ErrUnreachable},
+		{"primary fails and sms unreachable", smsUser, failure, ErrUnreachable, nil, []string{"Telegram", "SMS"},
+			failure},
+		{"primary unreachable and email fails", emailUser, ErrUnreachable, nil, failure, []string{"Telegram", "Email"},
+			failure},
+		{"both fail", smsUser, failure, errors.New("sms failed"), nil, []string{"Telegram", "SMS"},
+			errors.New("delivery failed, sms failed")},
+	}
+
+	for _, test := range tests {
+		primary := NewLocalNotifier("Telegram", nil)
+		sms := NewLocalNotifier("SMS", nil)
+		email := NewLocalNotifier("Email", nil)
+		primary.Err, sms.Err, email.Err = test.primaryErr, test.smsErr, test.emailErr
+
+		err := NewFallbackNotifier(primary, sms, email).Notify(test.user, notification)
+		if (err == nil) != (test.expected == nil) || (err != nil && err.Error() != test.expected.Error()) {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.expected, err)
+		}
+
+		if test.expected == ErrUnreachable && err != ErrUnreachable {
+			t.Errorf("%s: expected ErrUnreachable to be returned as it is, got %v", test.name, err)
+		}
+
+		tried := make([]string, 0)
+		for _, channel := range []*LocalNotifier{primary, sms, email} {
+			for _, delivery := range channel.Deliveries() {
+				if delivery.UserID != test.user.ID || delivery.Notification != notification {
+					t.Errorf("%s: unexpected %s delivery %+v", test.name, channel.Name, delivery)
+				}
+				tried = append(tried, channel.Name)
+			}
+		}
+
+		if len(tried) != len(test.tried) {
+			t.Errorf("%s: expected %v to be tried, got %v", test.name, test.tried, tried)
+			continue
+		}
+
+		for i := range tried {
+			if tried[i] != test.tried[i] {
+				t.Errorf("%s: expected %v to be tried, got %v", test.name, test.tried, tried)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// ErrUnreachable is an error returned by a notifier that doesn't have any means of reaching the user,
+// like a user without a bot client or without an email address
+var ErrUnreachable = errors.New("the user can't be reached through this channel")
+
+// INotifier is an interface that defines a channel a notification can be delivered to a user through
+type INotifier interface {
+	Notify(user *entity.User, notification *Notification) error
+}
+
+// Notification is a type that defines a message sent to a user along with the entries needed by every channel
+type Notification struct {
+	Subject string // Used as the subject of an email
+	Message string // Formatted with the html subset supported by Telegram
+	Summary string // Optional short plain text used for sms, the plain text of the message is used if empty
+}
+
+// PlainText is a method that returns the message of the notification without any html formatting
+func (notification *Notification) PlainText() string {
+	tags := regexp.MustCompile(`<[^>]*>`)
+	return strings.TrimSpace(html.UnescapeString(tags.ReplaceAllString(notification.Message, "")))
+}
+
+// ShortText is a method that returns the summary of the notification, or its plain text if it doesn't have any
+func (notification *Notification) ShortText() string {
+	if notification.Summary != "" {
+		return notification.Summary
+	}
+	return notification.PlainText()
+}
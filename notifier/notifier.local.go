@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// Delivery is a type that defines a notification received by a local notifier along with its user
+type Delivery struct {
+	UserID       string
+	Notification *Notification
+}
+
+// LocalNotifier is a type that records notifications instead of delivering them, it is used for local
+// development and tests. Setting Err makes every delivery fail with it after being recorded.
+type LocalNotifier struct {
+	Name       string
+	Err        error
+	out        io.Writer
+	deliveries []*Delivery
+	mutex      sync.Mutex
+}
+
+// NewLocalNotifier is a function that returns a new local notifier, each notification is also
+// written to the given writer if it isn't nil
+func NewLocalNotifier(name string, out io.Writer) *LocalNotifier {
+	return &LocalNotifier{Name: name, out: out}
+}
+
+// Notify is a method that records the notification of the given user
+func (notifier *LocalNotifier) Notify(user *entity.User, notification *Notification) error {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	notifier.deliveries = append(notifier.deliveries, &Delivery{UserID: user.ID, Notification: notification})
+	if notifier.out != nil {
+		fmt.Fprintf(notifier.out, "%s notification to %s: %s\n%s\n", notifier.Name, user.ID,
+			notification.Subject, notification.PlainText())
+	}
+
+	return notifier.Err
+}
+
+// Deliveries is a method that returns the notifications that have been recorded so far
+func (notifier *LocalNotifier) Deliveries() []*Delivery {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	return append([]*Delivery{}, notifier.deliveries...)
+}
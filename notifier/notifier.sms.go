@@ -0,0 +1,26 @@
+package notifier
+
+import (
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// SMSNotifier is a type that delivers notifications as sms messages to the phonenumber of a user
+type SMSNotifier struct {
+	sender tools.ISMSSender
+}
+
+// NewSMSNotifier is a function that returns a new sms notifier that sends messages using the given sender
+func NewSMSNotifier(sender tools.ISMSSender) INotifier {
+	return &SMSNotifier{sender: sender}
+}
+
+// Notify is a method that sends the short text of the notification to the phonenumber of the user
+func (notifier *SMSNotifier) Notify(user *entity.User, notification *Notification) error {
+
+	if user.PhoneNumber == "" {
+		return ErrUnreachable
+	}
+
+	return notifier.sender.Send(user.PhoneNumber, notification.ShortText())
+}
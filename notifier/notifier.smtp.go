@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// SMTPNotifier is a type that delivers notifications as emails using an smtp account
+type SMTPNotifier struct {
+	container *tools.SMTPContainer
+}
+
+// NewSMTPNotifier is a function that returns a new smtp notifier for the given smtp account
+func NewSMTPNotifier(container *tools.SMTPContainer) INotifier {
+	return &SMTPNotifier{container: container}
+}
+
+// Notify is a method that emails the plain text of the notification to the user,
+// a user without an email address can't be reached
+func (notifier *SMTPNotifier) Notify(user *entity.User, notification *Notification) error {
+
+	if user.Email == "" {
+		return ErrUnreachable
+	}
+
+	return notifier.container.SendEmail(user.Email, notification.Subject, notification.PlainText())
+}
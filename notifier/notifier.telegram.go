@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"strconv"
+
+	"github.com/Benyam-S/asseri/client/bot"
+	"github.com/Benyam-S/asseri/client/bot/client"
+	"github.com/Benyam-S/asseri/entity"
+)
+
+// ITelegramSender is an interface that defines the method used to send a message to a Telegram chat
+type ITelegramSender interface {
+	SendReplyToTelegramChat(chatID int64, reply ...string) (*bot.Message, error)
+}
+
+// TelegramNotifier is a type that delivers notifications to the bot client of a user
+type TelegramNotifier struct {
+	clService client.IService
+	sender    ITelegramSender
+}
+
+// NewTelegramNotifier is a function that returns a new telegram notifier
+func NewTelegramNotifier(clientService client.IService, sender ITelegramSender) INotifier {
+	return &TelegramNotifier{clService: clientService, sender: sender}
+}
+
+// Notify is a method that sends the notification to the bot client of the user,
+// a user without a bot client or who has blocked the bot can't be reached
+func (notifier *TelegramNotifier) Notify(user *entity.User, notification *Notification) error {
+
+	client, err := notifier.clService.FindClient(user.ID)
+	if err != nil || client.Blocked {
+		return ErrUnreachable
+	}
+
+	chatID, _ := strconv.ParseInt(client.TelegramID, 10, 64)
+	_, err = notifier.sender.SendReplyToTelegramChat(chatID, notification.Message)
+	return err
+}
@@ -10,11 +10,15 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
+	adminHandler "github.com/Benyam-S/asseri/client/admin/handler"
 	"github.com/Benyam-S/asseri/client/bot"
 	"github.com/Benyam-S/asseri/client/bot/handler"
 	"github.com/Benyam-S/asseri/entity"
 	"github.com/Benyam-S/asseri/log"
+	"github.com/Benyam-S/asseri/notifier"
+	"github.com/Benyam-S/asseri/staff"
 	"github.com/Benyam-S/asseri/tools"
 	"github.com/go-redis/redis"
 
@@ -33,6 +37,9 @@ import (
 	fdRepository "github.com/Benyam-S/asseri/feedback/repository"
 	fdService "github.com/Benyam-S/asseri/feedback/service"
 
+	stRepository "github.com/Benyam-S/asseri/staff/repository"
+	stService "github.com/Benyam-S/asseri/staff/service"
+
 	sbRepository "github.com/Benyam-S/asseri/subscription/repository"
 	sbService "github.com/Benyam-S/asseri/subscription/service"
 
@@ -53,16 +60,21 @@ var (
 	sysConfig      SystemConfig
 	err            error
 	botHandler     *handler.TelegramBotHandler
+	adHandler      *adminHandler.AdminHandler
 )
 
 // SystemConfig is a type that defines a server system configuration file
 type SystemConfig struct {
-	RedisClient         map[string]string `json:"redis_client"`
-	MysqlClient         map[string]string `json:"mysql_client"`
-	BotDomainAddres     string            `json:"bot_domain_address"`
-	BotClientServerPort string            `json:"bot_client_server_port"`
-	ServerLogFile       string            `json:"server_log_file"`
-	BotLogFile          string            `json:"bot_log_file"`
+	RedisClient          map[string]string `json:"redis_client"`
+	MysqlClient          map[string]string `json:"mysql_client"`
+	BotDomainAddres      string            `json:"bot_domain_address"`
+	BotClientServerPort  string            `json:"bot_client_server_port"`
+	BotUpdateMode        string            `json:"bot_update_mode"`
+	SMSSender            string            `json:"sms_sender"`
+	EmailSender          string            `json:"email_sender"`
+	DueDateReminderHours string            `json:"due_date_reminder_hours"`
+	ServerLogFile        string            `json:"server_log_file"`
+	BotLogFile           string            `json:"bot_log_file"`
 }
 
 // initServer initialize the web server for takeoff
@@ -96,17 +108,36 @@ func initServer() {
 		panic(errors.New("unable to parse asseri config data"))
 	}
 
+	// Optional, if empty updates posted to the webhook aren't verified
+	webhookSecretToken, _ := asseriConfig["webhook_secret_token"].(string)
+
+	// Optional, if empty the web job posting form isn't offered
+	jobPostURL, _ := asseriConfig["job_post_url"].(string)
+
+	// Optional, if empty the internal push endpoints reject every request
+	appSecretKey, _ := asseriConfig["app_secret_key"].(string)
+
+	// Optional, if empty pending jobs aren't forwarded for moderation
+	moderatorsChatID, _ := asseriConfig["moderators_chat_id"].(string)
+	staffTelegramIDs, _ := asseriConfig["staff_telegram_ids"].(string)
+
 	// Setting environmental variables so they can be used any where on the application
 	os.Setenv("config_files_dir", configFilesDir)
 	os.Setenv("bot_domain_address", sysConfig.BotDomainAddres)
 	os.Setenv("bot_client_server_port", sysConfig.BotClientServerPort)
 	os.Setenv("server_log_file", sysConfig.ServerLogFile)
 	os.Setenv("bot_log_file", sysConfig.BotLogFile)
+	os.Setenv("due_date_reminder_hours", sysConfig.DueDateReminderHours)
 
 	os.Setenv("api_access_point", apiAccessPoint)
 	os.Setenv("bot_api_token", botAPIToken)
 	os.Setenv("channel_name", channelName)
 	os.Setenv("bot_url", botURL)
+	os.Setenv("webhook_secret_token", webhookSecretToken)
+	os.Setenv(entity.AppSecretKeyName, appSecretKey)
+	os.Setenv("job_post_url", jobPostURL)
+	os.Setenv("moderators_chat_id", moderatorsChatID)
+	os.Setenv("staff_telegram_ids", staffTelegramIDs)
 
 	// Initializing the database with the needed tables and values
 	initDB()
@@ -118,34 +149,85 @@ func initServer() {
 	userRepo := urRepository.NewUserRepository(mysqlDB)
 	subscriptionRepo := sbRepository.NewSubscriptionRepository(mysqlDB)
 	feedbackRepo := fdRepository.NewFeedbackRepository(mysqlDB)
+	feedbackReplyRepo := fdRepository.NewFeedbackReplyRepository(mysqlDB)
+	staffRepo := stRepository.NewStaffRepository(mysqlDB)
+	passwordRepo := stRepository.NewPasswordRepository(mysqlDB)
 	commonRepo := cmRepository.NewCommonRepository(mysqlDB)
 
+	clock := tools.NewSystemClock()
 	commonService := cmService.NewCommonService(commonRepo)
 	userService := urService.NewUserService(userRepo, jobRepo, jobApplicationRepo, commonRepo)
-	jobService := jbService.NewJobService(jobRepo, userRepo, commonService)
+	jobService := jbService.NewJobService(jobRepo, userRepo, commonService, clock)
 	jobApplicationService := jaService.NewJobApplicationService(jobApplicationRepo, commonRepo)
 	subscriptionService := sbService.NewSubscriptionService(subscriptionRepo, commonService)
-	feedbackService := fdService.NewFeedbackService(feedbackRepo, userRepo)
+	feedbackService := fdService.NewFeedbackService(feedbackRepo, feedbackReplyRepo, userRepo)
+	staffService := stService.NewStaffService(staffRepo, passwordRepo, commonRepo)
 
 	// Creating push channel and queue
 	pushChannel := make(chan string, 1000)
-	pushQueue := cmService.NewPushQueue()
+	pushQueue := cmService.NewRedisPushQueue(redisClient, "push_queue", time.Minute*5, 5)
 	logger := &log.Logger{ServerLogFile: filepath.Join(path, "../../log", os.Getenv("server_log_file")),
 		BotLogFile: filepath.Join(path, "../../log", os.Getenv("bot_log_file"))}
 
+	// ----- Creating store -----
+	store := tools.NewRedisStore(redisClient)
+	sessionStore := tools.NewSessionStore(store, bot.SessionExpiration)
+
 	// ----- Bot level init -----
-	tempUserRepo := tuRepository.NewTempUserRepository(mysqlDB)
+	tempUserRepo := tuRepository.NewTempUserRepository(sessionStore)
 	clientRepo := clRepository.NewClientRepository(mysqlDB)
 
 	tempUserService := tuService.NewTempUserService(tempUserRepo, userRepo, commonRepo)
 	clientService := clService.NewClientService(clientRepo)
 
-	// ----- Creating store -----
-	store := tools.NewRedisStore(redisClient)
+	// ----- Creating sms sender -----
+	// The senders have to be set explicitly so a missing or misspelled value can't silently stop deliveries
+	var smsSender tools.ISMSSender
+	switch sysConfig.SMSSender {
+	case bot.SMSSenderTwilio:
+		smsAccount, err := tools.LoadSMSAccount()
+		if err != nil {
+			panic(err)
+		}
+		smsSender = tools.NewTwilioSMSSender(smsAccount)
+	case bot.SMSSenderLocal:
+		smsSender = tools.NewLocalSMSSender(os.Stdout)
+	default:
+		panic(errors.New("invalid sms sender: " + strconv.Quote(sysConfig.SMSSender)))
+	}
+
+	// ----- Creating notifiers -----
+	smsNotifier := notifier.NewSMSNotifier(smsSender)
+	var emailNotifier notifier.INotifier
+	switch sysConfig.EmailSender {
+	case bot.EmailSenderSMTP:
+		smtpContainer, err := tools.LoadSMTPContainer()
+		if err != nil {
+			panic(err)
+		}
+		emailNotifier = notifier.NewSMTPNotifier(smtpContainer)
+	case bot.EmailSenderLocal:
+		emailNotifier = notifier.NewLocalNotifier("Email", os.Stdout)
+	default:
+		panic(errors.New("invalid email sender: " + strconv.Quote(sysConfig.EmailSender)))
+	}
+
+	// ----- Creating telegram api client -----
+	telegramClient := bot.NewTelegramClient(apiAccessPoint, botAPIToken, time.Second*30)
 
 	botHandler = handler.NewTelegramBotHandler(tempUserService, clientService, userService,
 		jobService, jobApplicationService, subscriptionService, feedbackService,
-		commonService, store, pushChannel, pushQueue, logger)
+		commonService, telegramClient, clock, store, sessionStore, smsSender,
+		smsNotifier, emailNotifier, pushChannel, pushQueue, logger)
+
+	// ----- Admin level init -----
+	adHandler = adminHandler.NewAdminHandler(staffService, userService, jobService, subscriptionService,
+		feedbackService, commonService, clientService, botHandler, store, logger)
+
+	// The first admin can only be added from the config since adding staff members requires an admin
+	if initialAdmin, ok := asseriConfig["initial_admin"].(map[string]interface{}); ok {
+		initAdmin(staffService, initialAdmin)
+	}
 }
 
 // initDB initialize the database for takeoff
@@ -177,25 +259,61 @@ func initDB() {
 
 	// Creating and Migrating tables from the structures
 	mysqlDB.AutoMigrate(&entity.Feedback{})
+	mysqlDB.AutoMigrate(&entity.FeedbackReply{})
 	mysqlDB.AutoMigrate(&entity.Subscription{})
 	mysqlDB.AutoMigrate(&entity.JobApplication{})
 	mysqlDB.AutoMigrate(&entity.Job{})
 	mysqlDB.AutoMigrate(&entity.User{})
+	mysqlDB.AutoMigrate(&entity.Staff{})
+	mysqlDB.AutoMigrate(&entity.Password{})
 
 	// ----- Bot level database -----
-	mysqlDB.AutoMigrate(&bot.TempUser{})
 	mysqlDB.AutoMigrate(&bot.Client{})
 
 	// Setting foreign key constraint
 	mysqlDB.Model(&entity.JobApplication{}).AddForeignKey("job_seeker_id", "users(id)", "CASCADE", "CASCADE")
 	mysqlDB.Model(&entity.JobApplication{}).AddForeignKey("job_id", "jobs(id)", "CASCADE", "CASCADE")
 	mysqlDB.Model(&entity.Feedback{}).AddForeignKey("user_id", "users(id)", "SET NULL", "CASCADE")
+	mysqlDB.Model(&entity.FeedbackReply{}).AddForeignKey("feedback_id", "feedbacks(id)", "CASCADE", "CASCADE")
 	mysqlDB.Model(&entity.Subscription{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
+	mysqlDB.Model(&entity.Password{}).AddForeignKey("id", "staffs(id)", "CASCADE", "CASCADE")
 
 	// ----- Bot level constraint -----
 	mysqlDB.Model(&bot.Client{}).AddForeignKey("user_id", "users(id)", "CASCADE", "CASCADE")
 }
 
+// initAdmin adds the admin given in the config if there isn't any admin yet
+func initAdmin(staffService staff.IService, initialAdmin map[string]interface{}) {
+
+	if staffService.TotalStaffs(entity.RoleAdmin) > 0 {
+		return
+	}
+
+	firstName, _ := initialAdmin["first_name"].(string)
+	lastName, _ := initialAdmin["last_name"].(string)
+	phoneNumber, _ := initialAdmin["phone_number"].(string)
+	email, _ := initialAdmin["email"].(string)
+	password, _ := initialAdmin["password"].(string)
+
+	newAdmin := &entity.Staff{FirstName: firstName, LastName: lastName, PhoneNumber: phoneNumber,
+		Email: email, Role: entity.RoleAdmin}
+
+	errMap := staffService.ValidateStaffProfile(newAdmin)
+	if len(errMap) > 0 {
+		panic(errors.New("invalid initial admin: " + fmt.Sprint(errMap.StringMap())))
+	}
+
+	err := staffService.ValidatePassword(password)
+	if err != nil {
+		panic(errors.New("invalid initial admin: " + err.Error()))
+	}
+
+	err = staffService.AddStaff(newAdmin, password)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func main() {
 	configFilesDir = "/asseri_bot/config"
 
@@ -205,16 +323,93 @@ func main() {
 
 	router := mux.NewRouter()
 
-	router.HandleFunc("/approval/result/{id}", botHandler.HandleApprovalResult).Methods("GET")
-	router.HandleFunc("/push/notification/channel/{id}", botHandler.HandlePushNotificationToChannel).Methods("GET")
-	router.HandleFunc("/push/notification/subscriber/{id}", botHandler.HandlePushNotificationToSubscribers).Methods("GET")
-
-	router.HandleFunc("/", tools.MiddlewareFactory(botHandler.HandleWebHook, botHandler.ParseRequest))
+	router.HandleFunc("/approval/result/{id}", tools.MiddlewareFactory(botHandler.HandleApprovalResult,
+		botHandler.AuthenticateInternalRequest)).Methods("POST")
+	router.HandleFunc("/push/notification/channel/{id}", tools.MiddlewareFactory(
+		botHandler.HandlePushNotificationToChannel, botHandler.AuthenticateInternalRequest)).Methods("POST")
+	router.HandleFunc("/push/notification/subscriber/{id}", tools.MiddlewareFactory(
+		botHandler.HandlePushNotificationToSubscribers, botHandler.AuthenticateInternalRequest)).Methods("POST")
+
+	router.HandleFunc("/api/jobs", botHandler.HandleSubmitJob).Methods("POST")
+
+	adminRouter := router.PathPrefix("/admin/api/v1").Subrouter()
+	adminRouter.HandleFunc("/login", adHandler.HandleLogin).Methods("POST")
+	adminRouter.HandleFunc("/logout", tools.MiddlewareFactory(adHandler.HandleLogout,
+		adHandler.Authorize(entity.RoleAny))).Methods("POST")
+	adminRouter.HandleFunc("/profile", tools.MiddlewareFactory(adHandler.HandleGetProfile,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/profile/password", tools.MiddlewareFactory(adHandler.HandleChangePassword,
+		adHandler.Authorize(entity.RoleAny))).Methods("PUT")
+	adminRouter.HandleFunc("/staffs", tools.MiddlewareFactory(adHandler.HandleAllStaffs,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("GET")
+	adminRouter.HandleFunc("/staffs", tools.MiddlewareFactory(adHandler.HandleAddStaff,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("POST")
+	adminRouter.HandleFunc("/staffs/{id}", tools.MiddlewareFactory(adHandler.HandleDeleteStaff,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("DELETE")
+
+	adminRouter.HandleFunc("/stats", tools.MiddlewareFactory(adHandler.HandleGetStats,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+
+	adminRouter.HandleFunc("/jobs", tools.MiddlewareFactory(adHandler.HandleAllJobs,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/jobs/{id}", tools.MiddlewareFactory(adHandler.HandleGetJob,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/jobs/{id}/status", tools.MiddlewareFactory(adHandler.HandleChangeJobStatus,
+		adHandler.Authorize(entity.RoleAny))).Methods("PUT")
+	adminRouter.HandleFunc("/jobs/{id}", tools.MiddlewareFactory(adHandler.HandleDeleteJob,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("DELETE")
+
+	adminRouter.HandleFunc("/users", tools.MiddlewareFactory(adHandler.HandleAllUsers,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/users/{id}", tools.MiddlewareFactory(adHandler.HandleGetUser,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/users/{id}", tools.MiddlewareFactory(adHandler.HandleDeleteUser,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("DELETE")
+
+	adminRouter.HandleFunc("/feedbacks", tools.MiddlewareFactory(adHandler.HandleAllFeedbacks,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/feedbacks/{id}", tools.MiddlewareFactory(adHandler.HandleGetFeedback,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/feedbacks/{id}/seen", tools.MiddlewareFactory(adHandler.HandleMarkFeedbackAsSeen,
+		adHandler.Authorize(entity.RoleAny))).Methods("PUT")
+	adminRouter.HandleFunc("/feedbacks/{id}/replies", tools.MiddlewareFactory(adHandler.HandleGetFeedbackReplies,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/feedbacks/{id}/replies", tools.MiddlewareFactory(adHandler.HandleReplyToFeedback,
+		adHandler.Authorize(entity.RoleAny))).Methods("POST")
+	adminRouter.HandleFunc("/feedbacks/{id}/status", tools.MiddlewareFactory(adHandler.HandleChangeFeedbackStatus,
+		adHandler.Authorize(entity.RoleAny))).Methods("PUT")
+	adminRouter.HandleFunc("/feedbacks/{id}", tools.MiddlewareFactory(adHandler.HandleDeleteFeedback,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("DELETE")
+
+	adminRouter.HandleFunc("/attributes/{table}", tools.MiddlewareFactory(adHandler.HandleAllJobAttributes,
+		adHandler.Authorize(entity.RoleAny))).Methods("GET")
+	adminRouter.HandleFunc("/attributes/{table}", tools.MiddlewareFactory(adHandler.HandleAddJobAttribute,
+		adHandler.Authorize(entity.RoleAdmin))).Methods("POST")
+	adminRouter.HandleFunc("/attributes/{table}/{id}", tools.MiddlewareFactory(
+		adHandler.HandleUpdateJobAttribute, adHandler.Authorize(entity.RoleAdmin))).Methods("PUT")
+	adminRouter.HandleFunc("/attributes/{table}/{id}", tools.MiddlewareFactory(
+		adHandler.HandleDeleteJobAttribute, adHandler.Authorize(entity.RoleAdmin))).Methods("DELETE")
 
 	go func() {
 		botHandler.HandlePushRequest()
 	}()
 
+	go func() {
+		botHandler.HandleDueJobs()
+	}()
+
+	// Polling doesn't need a public address so the internal routes are served without TLS
+	if sysConfig.BotUpdateMode == bot.UpdateModePolling {
+		go func() {
+			botHandler.HandleUpdatePolling()
+		}()
+
+		http.ListenAndServe(":"+os.Getenv("bot_client_server_port"), router)
+		return
+	}
+
+	router.HandleFunc("/", tools.MiddlewareFactory(botHandler.HandleWebHook, botHandler.ParseRequest))
+
 	http.ListenAndServeTLS(":"+os.Getenv("bot_client_server_port"),
 		filepath.Join(configFilesDir, "/server.pem"),
 		filepath.Join(configFilesDir, "/server.key"), router)
@@ -0,0 +1,22 @@
+package staff
+
+import "github.com/Benyam-S/asseri/entity"
+
+// IStaffRepository is an interface that defines all the repository methods of a staff struct
+type IStaffRepository interface {
+	Create(newStaff *entity.Staff) error
+	Find(identifier string) (*entity.Staff, error)
+	FindAll(role string, pageNum int64) ([]*entity.Staff, int64)
+	Total(role string) int64
+	Update(staff *entity.Staff) error
+	UpdateValue(staff *entity.Staff, columnName string, columnValue interface{}) error
+	Delete(identifier string) (*entity.Staff, error)
+}
+
+// IPasswordRepository is an interface that defines all the repository methods of a password struct
+type IPasswordRepository interface {
+	Create(newPassword *entity.Password) error
+	Find(identifier string) (*entity.Password, error)
+	Update(password *entity.Password) error
+	Delete(identifier string) (*entity.Password, error)
+}
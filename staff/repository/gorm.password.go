@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/staff"
+	"github.com/jinzhu/gorm"
+)
+
+// PasswordRepository is a type that defines a password repository type
+type PasswordRepository struct {
+	conn *gorm.DB
+}
+
+// NewPasswordRepository is a function that creates a new password repository type
+func NewPasswordRepository(connection *gorm.DB) staff.IPasswordRepository {
+	return &PasswordRepository{conn: connection}
+}
+
+// Create is a method that adds a new password to the database, the password id is the id of its owner
+func (repo *PasswordRepository) Create(newPassword *entity.Password) error {
+	err := repo.conn.Create(newPassword).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Find is a method that finds a certain password from the database using an identifier.
+// In Find() id is only used as an key
+func (repo *PasswordRepository) Find(identifier string) (*entity.Password, error) {
+
+	password := new(entity.Password)
+	err := repo.conn.Model(password).Where("id = ?", identifier).First(password).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return password, nil
+}
+
+// Update is a method that updates a certain password entries in the database
+func (repo *PasswordRepository) Update(password *entity.Password) error {
+
+	prevPassword := new(entity.Password)
+	err := repo.conn.Model(prevPassword).Where("id = ?", password.ID).First(prevPassword).Error
+
+	if err != nil {
+		return err
+	}
+
+	/* --------------------------- can change layer if needed --------------------------- */
+	password.CreatedAt = prevPassword.CreatedAt
+	/* -------------------------------------- end --------------------------------------- */
+
+	err = repo.conn.Save(password).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete is a method that deletes a certain password from the database using an identifier.
+// In Delete() id is only used as an key
+func (repo *PasswordRepository) Delete(identifier string) (*entity.Password, error) {
+	password := new(entity.Password)
+	err := repo.conn.Model(password).Where("id = ?", identifier).First(password).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.conn.Delete(password)
+	return password, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/staff"
+	"github.com/Benyam-S/asseri/tools"
+	"github.com/jinzhu/gorm"
+)
+
+// StaffRepository is a type that defines a staff repository type
+type StaffRepository struct {
+	conn *gorm.DB
+}
+
+// NewStaffRepository is a function that creates a new staff repository type
+func NewStaffRepository(connection *gorm.DB) staff.IStaffRepository {
+	return &StaffRepository{conn: connection}
+}
+
+// Create is a method that adds a new staff member to the database
+func (repo *StaffRepository) Create(newStaff *entity.Staff) error {
+	totalNumOfMembers := tools.CountMembers("staffs", repo.conn)
+	newStaff.ID = fmt.Sprintf("ST-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+
+	for !tools.IsUnique("id", newStaff.ID, "staffs", repo.conn) {
+		totalNumOfMembers++
+		newStaff.ID = fmt.Sprintf("ST-%s%d", tools.RandomStringGN(7), totalNumOfMembers+1)
+	}
+
+	err := repo.conn.Create(newStaff).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Find is a method that finds a certain staff member from the database using an identifier,
+// also Find() uses id, email and phone_number as a key for selection
+func (repo *StaffRepository) Find(identifier string) (*entity.Staff, error) {
+
+	modifiedIdentifier := identifier
+	if strings.HasPrefix(identifier, "0") {
+		modifiedIdentifier = "+251" + identifier[1:]
+	}
+
+	staff := new(entity.Staff)
+	err := repo.conn.Model(staff).
+		Where("id = ? || email = ? || phone_number = ?", identifier, identifier, modifiedIdentifier).
+		First(staff).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return staff, nil
+}
+
+// FindAll is a method that returns set of staff members limited to the page number and role
+func (repo *StaffRepository) FindAll(role string, pageNum int64) ([]*entity.Staff, int64) {
+
+	var staffs []*entity.Staff
+	var count float64
+
+	if role == entity.RoleAny {
+		repo.conn.Raw("SELECT * FROM staffs ORDER BY first_name ASC LIMIT ?, 20", pageNum*20).Scan(&staffs)
+		repo.conn.Raw("SELECT COUNT(*) FROM staffs").Count(&count)
+
+	} else {
+		repo.conn.Raw("SELECT * FROM staffs WHERE role = ? ORDER BY first_name ASC LIMIT ?, 20", role, pageNum*20).Scan(&staffs)
+		repo.conn.Raw("SELECT COUNT(*) FROM staffs WHERE role = ?", role).Count(&count)
+	}
+
+	var pageCount int64 = int64(math.Ceil(count / 20.0))
+	return staffs, pageCount
+}
+
+// Total is a method that retruns the total number of staff members for the given role
+func (repo *StaffRepository) Total(role string) int64 {
+
+	var count int64
+	if role == entity.RoleAny {
+		repo.conn.Raw("SELECT COUNT(*) FROM staffs").Count(&count)
+		return count
+	}
+
+	repo.conn.Raw("SELECT COUNT(*) FROM staffs WHERE role = ?", role).Count(&count)
+	return count
+}
+
+// Update is a method that updates a certain staff member entries in the database
+func (repo *StaffRepository) Update(staff *entity.Staff) error {
+
+	prevStaff := new(entity.Staff)
+	err := repo.conn.Model(prevStaff).Where("id = ?", staff.ID).First(prevStaff).Error
+
+	if err != nil {
+		return err
+	}
+
+	/* --------------------------- can change layer if needed --------------------------- */
+	staff.CreatedAt = prevStaff.CreatedAt
+	/* -------------------------------------- end --------------------------------------- */
+
+	err = repo.conn.Save(staff).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateValue is a method that updates a certain staff member single column value in the database
+func (repo *StaffRepository) UpdateValue(staff *entity.Staff, columnName string, columnValue interface{}) error {
+
+	prevStaff := new(entity.Staff)
+	err := repo.conn.Model(prevStaff).Where("id = ?", staff.ID).First(prevStaff).Error
+
+	if err != nil {
+		return err
+	}
+
+	err = repo.conn.Model(entity.Staff{}).Where("id = ?", staff.ID).
+		Update(map[string]interface{}{columnName: columnValue}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete is a method that deletes a certain staff member from the database using an identifier.
+// In Delete() id is only used as an key
+func (repo *StaffRepository) Delete(identifier string) (*entity.Staff, error) {
+	staff := new(entity.Staff)
+	err := repo.conn.Model(staff).Where("id = ?", identifier).First(staff).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	repo.conn.Delete(staff)
+	return staff, nil
+}
@@ -0,0 +1,17 @@
+package staff
+
+import "github.com/Benyam-S/asseri/entity"
+
+// IService is an interface that defines all the service methods of a staff struct
+type IService interface {
+	AddStaff(newStaff *entity.Staff, password string) error
+	ValidateStaffProfile(staff *entity.Staff) entity.ErrMap
+	ValidatePassword(password string) error
+	FindStaff(identifier string) (*entity.Staff, error)
+	AllStaffs(role string, pageNum int64) ([]*entity.Staff, int64)
+	TotalStaffs(role string) int64
+	UpdateStaff(staff *entity.Staff) error
+	ChangePassword(staffID, password string) error
+	VerifyPassword(identifier, password string) (*entity.Staff, error)
+	DeleteStaff(staffID string) (*entity.Staff, error)
+}
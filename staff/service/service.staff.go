@@ -0,0 +1,199 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/Benyam-S/asseri/common"
+	"github.com/Benyam-S/asseri/entity"
+	"github.com/Benyam-S/asseri/staff"
+	"github.com/Benyam-S/asseri/tools"
+)
+
+// Service is a type that defines a staff service
+type Service struct {
+	staffRepo    staff.IStaffRepository
+	passwordRepo staff.IPasswordRepository
+	commonRepo   common.ICommonRepository
+}
+
+// NewStaffService is a function that returns a new staff service
+func NewStaffService(staffRepository staff.IStaffRepository, passwordRepository staff.IPasswordRepository,
+	commonRepository common.ICommonRepository) staff.IService {
+	return &Service{staffRepo: staffRepository, passwordRepo: passwordRepository, commonRepo: commonRepository}
+}
+
+// AddStaff is a method that adds a new staff member to the system along with a bcrypt hash of the password
+func (service *Service) AddStaff(newStaff *entity.Staff, password string) error {
+
+	hash, err := tools.HashPassword(password)
+	if err != nil {
+		return errors.New("unable to add new staff member")
+	}
+
+	err = service.staffRepo.Create(newStaff)
+	if err != nil {
+		return errors.New("unable to add new staff member")
+	}
+
+	// The salt is kept in the bcrypt hash so the salt column is left empty
+	newPassword := &entity.Password{ID: newStaff.ID, Password: hash}
+	err = service.passwordRepo.Create(newPassword)
+	if err != nil {
+		// A staff member without a password can't login so it shouldn't be kept
+		service.staffRepo.Delete(newStaff.ID)
+		return errors.New("unable to add new staff member")
+	}
+
+	return nil
+}
+
+// ValidateStaffProfile is a method that validates a staff member profile.
+// It checks if the staff member has a valid entries or not and return map of errors if any.
+// Also it will change a local phone number to an international one: default country code +251
+func (service *Service) ValidateStaffProfile(staff *entity.Staff) entity.ErrMap {
+
+	staff.Email = strings.TrimSpace(staff.Email)
+	staff.PhoneNumber = strings.Join(strings.Fields(staff.PhoneNumber), "")
+
+	errMap := tools.ValidateProfile(staff.Role, staff.FirstName, staff.LastName, staff.PhoneNumber, staff.Email)
+	if errMap == nil {
+		errMap = make(map[string]error)
+	}
+
+	if staff.Role != entity.RoleAdmin && staff.Role != entity.RoleStaff {
+		errMap["role"] = errors.New("invalid role selected")
+	}
+
+	isLocalPhoneNumber, _ := regexp.MatchString(`^0\d{9}$`, staff.PhoneNumber)
+	if isLocalPhoneNumber {
+		staff.PhoneNumber = "+251" + staff.PhoneNumber[1:]
+	}
+
+	var prevProfile *entity.Staff
+	if staff.ID != "" {
+		// Meaning trying to update staff member, checking for err isn't relevant since nil is checked
+		prevProfile, _ = service.staffRepo.Find(staff.ID)
+	}
+
+	if errMap["email"] == nil && (prevProfile == nil || prevProfile.Email != staff.Email) &&
+		!service.commonRepo.IsUnique("email", staff.Email, "staffs") {
+		errMap["email"] = errors.New("email already exists")
+	}
+
+	if errMap["phone_number"] == nil && (prevProfile == nil || prevProfile.PhoneNumber != staff.PhoneNumber) &&
+		!service.commonRepo.IsUnique("phone_number", staff.PhoneNumber, "staffs") {
+		errMap["phone_number"] = errors.New("phone number already exists")
+	}
+
+	if len(errMap) > 0 {
+		return errMap
+	}
+
+	return nil
+}
+
+// ValidatePassword is a method that checks whether a password is strong enough to be used
+func (service *Service) ValidatePassword(password string) error {
+
+	if len(password) < 8 {
+		return errors.New("password should contain at least 8 characters")
+	}
+
+	hasLetter, _ := regexp.MatchString(`[a-zA-Z]`, password)
+	hasDigit, _ := regexp.MatchString(`\d`, password)
+	if !hasLetter || !hasDigit {
+		return errors.New("password should contain both letters and digits")
+	}
+
+	return nil
+}
+
+// FindStaff is a method that find and return a staff member that matchs the identifier value
+func (service *Service) FindStaff(identifier string) (*entity.Staff, error) {
+
+	empty, _ := regexp.MatchString(`^\s*$`, identifier)
+	if empty {
+		return nil, errors.New("no staff member found")
+	}
+
+	staff, err := service.staffRepo.Find(identifier)
+	if err != nil {
+		return nil, errors.New("no staff member found")
+	}
+	return staff, nil
+}
+
+// AllStaffs is a method that returns all the staff members with the given role with pagination
+func (service *Service) AllStaffs(role string, pageNum int64) ([]*entity.Staff, int64) {
+	return service.staffRepo.FindAll(role, pageNum)
+}
+
+// TotalStaffs is a method that returns the total number of staff members with the given role
+func (service *Service) TotalStaffs(role string) int64 {
+	return service.staffRepo.Total(role)
+}
+
+// UpdateStaff is a method that updates a staff member in the system
+func (service *Service) UpdateStaff(staff *entity.Staff) error {
+	err := service.staffRepo.Update(staff)
+	if err != nil {
+		return errors.New("unable to update staff member")
+	}
+
+	return nil
+}
+
+// ChangePassword is a method that replaces the password of a staff member with a new salted hash
+func (service *Service) ChangePassword(staffID, password string) error {
+
+	prevPassword, err := service.passwordRepo.Find(staffID)
+	if err != nil {
+		return errors.New("no staff member found")
+	}
+
+	hash, err := tools.HashPassword(password)
+	if err != nil {
+		return errors.New("unable to change password")
+	}
+
+	prevPassword.Salt = ""
+	prevPassword.Password = hash
+
+	err = service.passwordRepo.Update(prevPassword)
+	if err != nil {
+		return errors.New("unable to change password")
+	}
+
+	return nil
+}
+
+// VerifyPassword is a method that returns the staff member identified by the identifier if the password matches.
+// The same error is returned for an unknown staff member and a wrong password.
+func (service *Service) VerifyPassword(identifier, password string) (*entity.Staff, error) {
+
+	staff, err := service.FindStaff(identifier)
+	if err != nil {
+		return nil, errors.New("invalid identifier or password")
+	}
+
+	storedPassword, err := service.passwordRepo.Find(staff.ID)
+	if err != nil || !tools.ComparePassword(password, storedPassword.Password) {
+		return nil, errors.New("invalid identifier or password")
+	}
+
+	return staff, nil
+}
+
+// DeleteStaff is a method that deletes a staff member and the password from the system
+func (service *Service) DeleteStaff(staffID string) (*entity.Staff, error) {
+
+	staff, err := service.staffRepo.Delete(staffID)
+	if err != nil {
+		return nil, errors.New("unable to delete staff member")
+	}
+
+	service.passwordRepo.Delete(staffID)
+	return staff, nil
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"sync"
+	"time"
+)
+
+// IClock is an interface that defines a source of the current time so time dependent code can be tested
+type IClock interface {
+	Now() time.Time
+}
+
+// SystemClock is a type that returns the current time of the system
+type SystemClock struct{}
+
+// NewSystemClock is a function that returns a new system clock
+func NewSystemClock() IClock {
+	return &SystemClock{}
+}
+
+// Now is a method that returns the current local time
+func (clock *SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a type that returns a time that only changes when it is set or advanced, it is used in tests
+type ManualClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewManualClock is a function that returns a new manual clock that is set to the given time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now is a method that returns the time the clock is set to
+func (clock *ManualClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.now
+}
+
+// Set is a method that sets the clock to the given time
+func (clock *ManualClock) Set(now time.Time) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = now
+}
+
+// Advance is a method that moves the clock forward by the given duration
+func (clock *ManualClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(duration)
+}
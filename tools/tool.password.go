@@ -0,0 +1,19 @@
+package tools
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword is a function that returns a bcrypt hash of the password, the hash holds its own random salt
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// ComparePassword is a function that checks whether the password matches the bcrypt hash
+func ComparePassword(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -27,6 +27,61 @@ func (s *RedisStore) Add(key, value string) {
 	s.store.Set(key, value, time.Hour*24)
 }
 
+// AddWithExpiry is a method that adds new key value pair that is removed once the expiration duration has passed,
+// a zero expiration keeps the pair until it is removed
+func (s *RedisStore) AddWithExpiry(key, value string, expiration time.Duration) {
+	s.store.Set(key, value, expiration)
+}
+
+// AddIfAbsent is a method that adds new key value pair only if the key doesn't exist yet,
+// it returns whether the pair has been added or not
+func (s *RedisStore) AddIfAbsent(key, value string) (bool, error) {
+	return s.store.SetNX(key, value, time.Hour*24).Result()
+}
+
+// Increment is a method that atomically adds one to the number kept under the key and returns the new number,
+// a key without a number starts from zero. The expiration is renewed in the same transaction as the increment.
+func (s *RedisStore) Increment(key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := s.store.TxPipelined(func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(key)
+		if expiration > 0 {
+			pipe.Expire(key, expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Result()
+}
+
+// Take is a method that removes a key value pair and returns its value, it returns empty string if the key doesn't exist.
+// The value is read and removed in a single transaction so it can only be taken once.
+func (s *RedisStore) Take(key string) string {
+	var get *redis.StringCmd
+	s.store.TxPipelined(func(pipe redis.Pipeliner) error {
+		get = pipe.Get(key)
+		pipe.Del(key)
+		return nil
+	})
+
+	value, _ := get.Result()
+	return value
+}
+
+// TTL is a method that returns the duration left before a key value pair expires,
+// zero is returned if the pair doesn't expire or doesn't exist
+func (s *RedisStore) TTL(key string) time.Duration {
+	ttl, err := s.store.PTTL(key).Result()
+	if err != nil || ttl < 0 {
+		return 0
+	}
+
+	return ttl
+}
+
 // Remove is a method that removes a certain key value pair
 func (s *RedisStore) Remove(key string) {
 	s.store.Del(key)
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SessionKey is a constant that holds the store key format of a session payload, identified by the chat and the payload name
+const SessionKey = "session/%s/%s"
+
+// ISessionStore is an interface that defines all the methods required by a per chat session store
+type ISessionStore interface {
+	Load(chatID, name string, payload interface{}) error
+	Save(chatID, name string, payload interface{}) error
+	Clear(chatID, name string)
+}
+
+// SessionStore is a type that keeps json encoded payloads of a chat in a store for a limited duration
+type SessionStore struct {
+	store      IStore
+	expiration time.Duration
+}
+
+// NewSessionStore is a function that returns a new session store whose payloads expire once they
+// haven't been saved for the given duration
+func NewSessionStore(store IStore, expiration time.Duration) ISessionStore {
+	return &SessionStore{store: store, expiration: expiration}
+}
+
+// Load is a method that decodes the payload stored under the given name into the payload value,
+// it returns an error if the payload doesn't exist or has expired
+func (s *SessionStore) Load(chatID, name string, payload interface{}) error {
+
+	value := s.store.Get(fmt.Sprintf(SessionKey, chatID, name))
+	if value == "" {
+		return errors.New("no session found")
+	}
+
+	err := json.Unmarshal([]byte(value), payload)
+	if err != nil {
+		return errors.New("invalid session payload")
+	}
+
+	return nil
+}
+
+// Save is a method that stores the payload under the given name, saving a payload renews its expiration
+func (s *SessionStore) Save(chatID, name string, payload interface{}) error {
+
+	value, err := json.Marshal(payload)
+	if err != nil {
+		return errors.New("unable to encode session payload")
+	}
+
+	s.store.AddWithExpiry(fmt.Sprintf(SessionKey, chatID, name), string(value), s.expiration)
+	return nil
+}
+
+// Clear is a method that removes the payload stored under the given name
+func (s *SessionStore) Clear(chatID, name string) {
+	s.store.Remove(fmt.Sprintf(SessionKey, chatID, name))
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+// testPayload is a type that defines a session payload used by the tests
+type testPayload struct {
+	Step  string
+	Items []string
+}
+
+func TestSessionStore(t *testing.T) {
+	sessions := NewSessionStore(NewMapStore(), time.Hour)
+
+	saved := &testPayload{Step: "title", Items: []string{"Accounting", "Engineering"}}
+	if err := sessions.Save("1001", "draft", saved); err != nil {
+		t.Fatalf("unable to save the payload, %s", err)
+	}
+
+	loaded := new(testPayload)
+	if err := sessions.Load("1001", "draft", loaded); err != nil {
+		t.Fatalf("unable to load the payload, %s", err)
+	}
+
+	if loaded.Step != saved.Step || len(loaded.Items) != 2 || loaded.Items[1] != "Engineering" {
+		t.Errorf("expected %+v to be loaded, got %+v", saved, loaded)
+	}
+
+	// Payloads are kept per chat and per name
+	if err := sessions.Load("1002", "draft", new(testPayload)); err == nil {
+		t.Errorf("expected the payload of another chat not to be loaded")
+	}
+
+	if err := sessions.Load("1001", "profile", new(testPayload)); err == nil {
+		t.Errorf("expected a payload of another name not to be loaded")
+	}
+
+	sessions.Clear("1001", "draft")
+	if err := sessions.Load("1001", "draft", new(testPayload)); err == nil {
+		t.Errorf("expected a cleared payload not to be loaded")
+	}
+
+	if err := sessions.Save("1001", "draft", make(chan int)); err == nil {
+		t.Errorf("expected a payload that can't be encoded to be rejected")
+	}
+}
+
+func TestSessionStoreExpiry(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	store := NewMapStoreWithClock(clock)
+	sessions := NewSessionStore(store, time.Minute*30)
+
+	sessions.Save("1001", "draft", &testPayload{Step: "title"})
+
+	// Saving a payload renews its expiration
+	clock.Advance(time.Minute * 20)
+	sessions.Save("1001", "draft", &testPayload{Step: "description"})
+
+	clock.Advance(time.Minute * 20)
+	loaded := new(testPayload)
+	if err := sessions.Load("1001", "draft", loaded); err != nil || loaded.Step != "description" {
+		t.Fatalf("expected the renewed payload to be loaded, got %+v, %v", loaded, err)
+	}
+
+	clock.Advance(time.Minute * 11)
+	if err := sessions.Load("1001", "draft", new(testPayload)); err == nil {
+		t.Errorf("expected the payload to expire once it hasn't been saved for the expiration duration")
+	}
+
+	// A payload that isn't valid json is reported instead of being loaded
+	store.Add("session/1001/draft", "{")
+	if err := sessions.Load("1001", "draft", new(testPayload)); err == nil {
+		t.Errorf("expected an invalid payload not to be loaded")
+	}
+}
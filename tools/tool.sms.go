@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ISMSSender is an interface that defines a service that sends sms messages
+type ISMSSender interface {
+	Send(to, msg string) error
+}
+
+// TwilioSMSSender is a type that sends sms messages using a twilio api client account
+type TwilioSMSSender struct {
+	account *APIClientSMS
+}
+
+// NewTwilioSMSSender is a function that returns a new twilio sms sender for the given account
+func NewTwilioSMSSender(account *APIClientSMS) ISMSSender {
+	return &TwilioSMSSender{account: account}
+}
+
+// Send is a method that sends the given message to the provided phone number
+func (sender *TwilioSMSSender) Send(to, msg string) error {
+	_, err := sender.account.SendSMS(to, msg)
+	return err
+}
+
+// LocalSMSSender is a type that writes sms messages to a writer instead of sending them,
+// it is used for local development where there isn't any sms account
+type LocalSMSSender struct {
+	out   io.Writer
+	mutex sync.Mutex
+}
+
+// NewLocalSMSSender is a function that returns a new local sms sender that writes to the given writer
+func NewLocalSMSSender(out io.Writer) ISMSSender {
+	return &LocalSMSSender{out: out}
+}
+
+// Send is a method that writes the given message along with its phone number
+func (sender *LocalSMSSender) Send(to, msg string) error {
+	sender.mutex.Lock()
+	defer sender.mutex.Unlock()
+
+	_, err := fmt.Fprintf(sender.out, "SMS to %s: %s\n", to, msg)
+	return err
+}
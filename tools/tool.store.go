@@ -1,33 +1,183 @@
 package tools
 
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
 // IStore is an interface that defines all the methods required by store
 type IStore interface {
 	Get(key string) string
 	Add(key, value string)
+	AddWithExpiry(key, value string, expiration time.Duration)
+	AddIfAbsent(key, value string) (bool, error)
+	Increment(key string, expiration time.Duration) (int64, error)
+	Take(key string) string
+	TTL(key string) time.Duration
 	Remove(key string)
 }
 
+// MapStoreSweepInterval is a constant that holds the least duration between two sweeps of the expired pairs of a map store
+const MapStoreSweepInterval = time.Minute
+
 // MapStore is a type that stores elements as key value pair in map
 type MapStore struct {
-	store map[string]string
+	store    map[string]string
+	expiries map[string]time.Time
+	swept    time.Time
+	clock    IClock
+	mutex    sync.Mutex
 }
 
-// NewMapStore is a function that returns a new redis store
+// NewMapStore is a function that returns a new map store
 func NewMapStore() IStore {
-	return &MapStore{store: make(map[string]string)}
+	return NewMapStoreWithClock(NewSystemClock())
+}
+
+// NewMapStoreWithClock is a function that returns a new map store whose pairs expire according to the given clock
+func NewMapStoreWithClock(clock IClock) IStore {
+	return &MapStore{store: make(map[string]string), expiries: make(map[string]time.Time), swept: clock.Now(),
+		clock: clock}
 }
 
 // Get is a method that gets the value for the given key
 func (s *MapStore) Get(key string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.expire(key)
 	return s.store[key]
 }
 
 // Add is a method that adds new key value pair
 func (s *MapStore) Add(key, value string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep()
+	s.store[key] = value
+	delete(s.expiries, key)
+}
+
+// AddWithExpiry is a method that adds new key value pair that is removed once the expiration duration has passed,
+// like redis a zero expiration keeps the pair until it is removed
+func (s *MapStore) AddWithExpiry(key, value string, expiration time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep()
 	s.store[key] = value
+	delete(s.expiries, key)
+	if expiration > 0 {
+		s.expiries[key] = s.clock.Now().Add(expiration)
+	}
+}
+
+// AddIfAbsent is a method that adds new key value pair only if the key doesn't have a value yet,
+// it returns whether the pair has been added or not. Like redis the pair is removed after a day.
+func (s *MapStore) AddIfAbsent(key, value string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep()
+	s.expire(key)
+	if s.store[key] != "" {
+		return false, nil
+	}
+
+	s.store[key] = value
+	s.expiries[key] = s.clock.Now().Add(time.Hour * 24)
+	return true, nil
+}
+
+// Increment is a method that atomically adds one to the number kept under the key and returns the new number,
+// a key without a number starts from zero. The expiration is renewed on every increment like AddWithExpiry.
+func (s *MapStore) Increment(key string, expiration time.Duration) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep()
+	s.expire(key)
+
+	count := int64(0)
+	if s.store[key] != "" {
+		var err error
+		count, err = strconv.ParseInt(s.store[key], 10, 64)
+		if err != nil {
+			return 0, errors.New("value is not an integer")
+		}
+	}
+
+	count++
+	s.store[key] = strconv.FormatInt(count, 10)
+	delete(s.expiries, key)
+	if expiration > 0 {
+		s.expiries[key] = s.clock.Now().Add(expiration)
+	}
+
+	return count, nil
+}
+
+// Take is a method that removes a key value pair and returns its value, it returns empty string if the key doesn't exist
+func (s *MapStore) Take(key string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.expire(key)
+	value := s.store[key]
+	delete(s.store, key)
+	return value
+}
+
+// TTL is a method that returns the duration left before a key value pair expires,
+// zero is returned if the pair doesn't expire or doesn't exist
+func (s *MapStore) TTL(key string) time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.expire(key)
+	expiry, ok := s.expiries[key]
+	if !ok {
+		return 0
+	}
+
+	return expiry.Sub(s.clock.Now())
 }
 
 // Remove is a method that removes a certain key value pair
 func (s *MapStore) Remove(key string) {
-	s.store[key] = ""
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.store, key)
+	delete(s.expiries, key)
+}
+
+// expire is a method that removes a key value pair whose expiration duration has passed,
+// the caller should hold the lock
+func (s *MapStore) expire(key string) {
+	if expiry, ok := s.expiries[key]; ok && s.clock.Now().After(expiry) {
+		delete(s.store, key)
+		delete(s.expiries, key)
+	}
+}
+
+// sweep is a method that removes every key value pair whose expiration duration has passed, so pairs that are
+// never read again don't stay in the map. It runs at most once in a sweep interval, the caller should hold the lock.
+func (s *MapStore) sweep() {
+
+	now := s.clock.Now()
+	if now.Sub(s.swept) < MapStoreSweepInterval {
+		return
+	}
+
+	s.swept = now
+	for key, expiry := range s.expiries {
+		if now.After(expiry) {
+			delete(s.store, key)
+			delete(s.expiries, key)
+		}
+	}
 }
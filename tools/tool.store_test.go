@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMapStoreExpiry(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	store := NewMapStoreWithClock(clock)
+
+	store.AddWithExpiry("short", "1", time.Second)
+	store.AddWithExpiry("long", "2", time.Hour)
+	store.AddWithExpiry("kept", "3", 0)
+
+	if store.Get("short") != "1" || store.Get("long") != "2" || store.Get("kept") != "3" {
+		t.Fatalf("expected every pair to be found before it expires")
+	}
+
+	clock.Advance(time.Second * 2)
+	if store.Get("short") != "" || store.Get("long") != "2" {
+		t.Errorf("expected only the short lived pair to expire")
+	}
+
+	clock.Advance(time.Hour * 24 * 365)
+	if store.Get("long") != "" || store.Get("kept") != "3" {
+		t.Errorf("expected a pair without an expiration to be kept")
+	}
+
+	// Adding a pair again renews it while a plain add removes its expiration
+	store.AddWithExpiry("renewed", "4", time.Second)
+	store.Add("renewed", "5")
+	clock.Advance(time.Minute)
+	if store.Get("renewed") != "5" {
+		t.Errorf("expected a pair added without an expiration not to expire")
+	}
+
+	if added, _ := store.AddIfAbsent("renewed", "6"); added {
+		t.Errorf("expected an existing pair not to be replaced")
+	}
+
+	store.Remove("renewed")
+	if added, _ := store.AddIfAbsent("renewed", "6"); !added || store.Take("renewed") != "6" {
+		t.Errorf("expected a removed pair to be added again")
+	}
+}
+
+func TestMapStoreTTL(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	store := NewMapStoreWithClock(clock)
+
+	store.AddWithExpiry("token", "UR-1", time.Hour)
+	store.Add("kept", "UR-2")
+
+	clock.Advance(time.Minute * 20)
+	ttl := store.TTL("token")
+	if ttl != time.Minute*40 {
+		t.Errorf("expected 40m to be left, got %s", ttl)
+	}
+
+	if store.TTL("kept") != 0 || store.TTL("missing") != 0 {
+		t.Errorf("expected no time left for a pair without an expiration or a missing pair")
+	}
+
+	// A pair put back with the time it had left expires when it originally would have
+	store.Take("token")
+	store.AddWithExpiry("token", "UR-1", ttl)
+	clock.Advance(time.Minute * 39)
+	if store.Get("token") != "UR-1" {
+		t.Errorf("expected the pair to be kept until its original expiration")
+	}
+
+	clock.Advance(time.Minute * 2)
+	if store.Get("token") != "" || store.TTL("token") != 0 {
+		t.Errorf("expected the pair to expire at its original expiration")
+	}
+}
+
+func TestMapStoreSweep(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	store := NewMapStoreWithClock(clock).(*MapStore)
+
+	for i := 0; i < 100; i++ {
+		store.AddWithExpiry(fmt.Sprintf("session/%d", i), "payload", time.Minute)
+	}
+	store.AddWithExpiry("kept", "payload", time.Hour)
+
+	// Expired pairs that are never read again are removed by the next write after a sweep interval
+	clock.Advance(time.Minute * 2)
+	if len(store.store) != 101 {
+		t.Fatalf("expected the pairs to be kept until a write, got %d", len(store.store))
+	}
+
+	store.Add("new", "payload")
+	if len(store.store) != 2 || len(store.expiries) != 1 {
+		t.Errorf("expected the expired pairs to be swept, got %d pairs and %d expiries",
+			len(store.store), len(store.expiries))
+	}
+
+	if store.Get("kept") != "payload" || store.Get("new") != "payload" {
+		t.Errorf("expected the pairs that haven't expired to be kept")
+	}
+
+	// A sweep runs at most once in a sweep interval
+	store.AddWithExpiry("short", "payload", time.Second)
+	clock.Advance(time.Second * 2)
+	store.Add("another", "payload")
+	if _, ok := store.store["short"]; !ok {
+		t.Errorf("expected no sweep before the sweep interval passes")
+	}
+
+	clock.Advance(MapStoreSweepInterval)
+	store.Add("another", "payload")
+	if _, ok := store.store["short"]; ok {
+		t.Errorf("expected the expired pair to be swept once the sweep interval passes")
+	}
+}
+
+func TestMapStoreIncrement(t *testing.T) {
+	clock := NewManualClock(time.Now())
+	store := NewMapStoreWithClock(clock)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Increment("count", time.Minute)
+		}()
+	}
+	wg.Wait()
+
+	if store.Get("count") != "50" {
+		t.Fatalf("expected every increment to be counted, got %s", store.Get("count"))
+	}
+
+	// Every increment renews the expiration
+	clock.Advance(time.Second * 50)
+	if count, err := store.Increment("count", time.Minute); err != nil || count != 51 {
+		t.Errorf("expected the count to be incremented to 51, got %d, %v", count, err)
+	}
+
+	clock.Advance(time.Second * 50)
+	if store.Get("count") != "51" {
+		t.Errorf("expected the renewed count to be kept, got %q", store.Get("count"))
+	}
+
+	clock.Advance(time.Minute)
+	if count, _ := store.Increment("count", time.Minute); count != 1 {
+		t.Errorf("expected an expired count to start over, got %d", count)
+	}
+
+	store.Add("text", "value")
+	if _, err := store.Increment("text", time.Minute); err == nil {
+		t.Errorf("expected a value that isn't a number not to be incremented")
+	}
+}
@@ -1,7 +1,9 @@
 package tools
 
 import (
+	crand "crypto/rand"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"time"
 )
@@ -30,9 +32,11 @@ func GenerateRandomBytes(n int) ([]byte, error) {
 	return b, nil
 }
 
-// GenerateOTP is a function that generates a random otp value of 4 digits
-func GenerateOTP() string {
-	rand.Seed(time.Now().UnixNano())
-	nBig := rand.Int63n(8999)
-	return fmt.Sprintf("%d", nBig+1000)
+// GenerateOTP is a function that generates a cryptographically secure random otp value of 6 digits
+func GenerateOTP() (string, error) {
+	nBig, err := crand.Int(crand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", nBig.Int64()), nil
 }
@@ -1,6 +1,9 @@
 package tools
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 
 	"github.com/dgrijalva/jwt-go"
@@ -33,3 +36,43 @@ func VerifyToken(signedToken string, signingKey []byte) bool {
 
 	return true
 }
+
+// ParseToken is a function that verifies a signedToken and decodes its claims into the given claims
+func ParseToken(signedToken string, signingKey []byte, claims jwt.Claims) error {
+
+	token, err := jwt.ParseWithClaims(signedToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("error in signing method")
+		}
+		return signingKey, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !token.Valid {
+		return errors.New("invalid token")
+	}
+
+	return nil
+}
+
+// GenerateSignature is a function that generates a hex encoded HMAC-SHA256 signature of the message
+func GenerateSignature(signingKey []byte, message string) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature is a function that checks whether the signature is a valid HMAC-SHA256 signature of the message
+func VerifySignature(signingKey []byte, message, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(message))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
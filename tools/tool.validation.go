@@ -14,7 +14,7 @@ func ValidateProfile(role string, entries ...string) entity.ErrMap {
 	var matchFirstName, matchLastName, matchEmail, matchPhoneNumber bool
 
 	if role == entity.RoleAdmin || role == entity.RoleStaff {
-		matchEmail, _ = regexp.MatchString(`^(([^<>()\[\]\\.,;:\s@"]+(\.[^<>()\[\]\\.,;:\s@"]+)*)|(".+"))@((\[[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\])|(([a-zA-Z\-0-9]+\.)+[a-zA-Z]{2,}))$`, entries[3])
+		matchEmail = IsValidEmail(entries[3])
 	}
 
 	errMap := make(map[string]error)
@@ -43,6 +43,12 @@ func ValidateProfile(role string, entries ...string) entity.ErrMap {
 	return nil
 }
 
+// IsValidEmail is a function that checks whether the given email address is valid or not
+func IsValidEmail(email string) bool {
+	valid, _ := regexp.MatchString(`^(([^<>()\[\]\\.,;:\s@"]+(\.[^<>()\[\]\\.,;:\s@"]+)*)|(".+"))@((\[[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}\])|(([a-zA-Z\-0-9]+\.)+[a-zA-Z]{2,}))$`, email)
+	return valid
+}
+
 // IsValidURL is a function that check whether the given url is valid or not
 func IsValidURL(url string) bool {
 
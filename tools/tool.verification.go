@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/smtp"
 	"net/url"
@@ -28,21 +29,45 @@ type SMTPContainer struct {
 	Extra    string `json:"extra"`
 }
 
-// SendSMS is a function that sends a given message to the provide phone number
-func SendSMS(to, msg string) (string, error) {
+// LoadSMSAccount is a function that reads the sms api client account from account.api.sms.json
+func LoadSMSAccount() (*APIClientSMS, error) {
 
 	dir := filepath.Join(os.Getenv("config_files_dir"), "/accounts/account.api.sms.json")
 	data, err := ioutil.ReadFile(dir)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var clientAccount APIClientSMS
-	err = json.Unmarshal(data, &clientAccount)
+	clientAccount := new(APIClientSMS)
+	err = json.Unmarshal(data, clientAccount)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return clientAccount, nil
+}
+
+// LoadSMTPContainer is a function that reads the smtp entities from account.api.email.json
+func LoadSMTPContainer() (*SMTPContainer, error) {
+
+	dir := filepath.Join(os.Getenv("config_files_dir"), "/accounts/account.api.email.json")
+	data, err := ioutil.ReadFile(dir)
+	if err != nil {
+		return nil, err
 	}
 
+	smtpContainer := new(SMTPContainer)
+	err = json.Unmarshal(data, smtpContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	return smtpContainer, nil
+}
+
+// SendSMS is a method that sends a given message to the provide phone number using the api client account
+func (clientAccount *APIClientSMS) SendSMS(to, msg string) (string, error) {
+
 	urlStr := "https://api.twilio.com/2010-04-01/Accounts/" + clientAccount.AccountID + "/Messages.json"
 
 	msgData := url.Values{}
@@ -61,6 +86,8 @@ func SendSMS(to, msg string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		var data map[string]interface{}
 		decoder := json.NewDecoder(resp.Body)
@@ -79,25 +106,22 @@ func SendSMS(to, msg string) (string, error) {
 	return "", errors.New(resp.Status)
 }
 
-// SendEmail is a function that sends an email to the provided email address.
-func SendEmail(to, subject, msg string) error {
-
-	dir := filepath.Join(os.Getenv("config_files_dir"), "/accounts/account.api.email.json")
-	data, err := ioutil.ReadFile(dir)
-	if err != nil {
-		return err
-	}
-
-	var smtpContainer SMTPContainer
-	err = json.Unmarshal(data, &smtpContainer)
-	if err != nil {
-		return err
-	}
+// SendEmail is a method that sends an email to the provided email address using the smtp entities,
+// the subject is encoded so a user provided subject can't add headers to the email
+func (smtpContainer *SMTPContainer) SendEmail(to, subject, msg string) error {
 
 	auth := smtp.PlainAuth(smtpContainer.Extra, smtpContainer.Email, smtpContainer.Password, smtpContainer.DNS)
 	msgByte := []byte(
-		"To:" + to + "\r\n" + "Subject: " + subject + "\r\n" + "\r\n" + msg)
+		"To:" + to + "\r\n" + "Subject: " + encodeEmailHeader(subject) + "\r\n" +
+			"MIME-Version: 1.0\r\n" + "Content-Type: text/plain; charset=UTF-8\r\n" + "\r\n" + msg)
 	receiver := []string{to}
 
 	return smtp.SendMail(smtpContainer.DNS+":"+smtpContainer.Port, auth, smtpContainer.Email, receiver, msgByte)
 }
+
+// encodeEmailHeader is a function that returns a header value without line breaks, encoded as a utf-8 encoded-word
+// if it has any character other than printable ascii
+func encodeEmailHeader(value string) string {
+	value = strings.Join(strings.FieldsFunc(value, func(r rune) bool { return r == '\r' || r == '\n' }), " ")
+	return mime.QEncoding.Encode("utf-8", value)
+}
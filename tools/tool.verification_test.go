@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"mime"
+	"strings"
+	"testing"
+)
+
+func TestEncodeEmailHeader(t *testing.T) {
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"Junior Accountant", "Junior Accountant"},
+		{"Junior Accountant\r\nBcc: victim@example.com", "Junior Accountant Bcc: victim@example.com"},
+		{"Line\nbreak\r", "Line break"},
+		{"የሂሳብ ሰራተኛ", "የሂሳብ ሰራተኛ"},
+	}
+
+	decoder := new(mime.WordDecoder)
+	for _, test := range tests {
+		encoded := encodeEmailHeader(test.value)
+		if strings.ContainsAny(encoded, "\r\n") {
+			t.Errorf("expected %q to be encoded without line breaks, got %q", test.value, encoded)
+		}
+
+		decoded, err := decoder.DecodeHeader(encoded)
+		if err != nil || decoded != test.expected {
+			t.Errorf("expected %q to be decoded as %q, got %q, %v", test.value, test.expected, decoded, err)
+		}
+	}
+}
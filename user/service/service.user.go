@@ -11,6 +11,7 @@ import (
 	"github.com/Benyam-S/asseri/entity"
 	"github.com/Benyam-S/asseri/job"
 	"github.com/Benyam-S/asseri/jobapplication"
+	"github.com/Benyam-S/asseri/tools"
 	"github.com/Benyam-S/asseri/user"
 	"github.com/nyaruka/phonenumbers"
 )
@@ -96,6 +97,27 @@ func (service *Service) ValidateUserProfile(user *entity.User) entity.ErrMap {
 		errMap["category"] = errors.New("invalid category selected")
 	}
 
+	// Email is optional so only a provided email address is validated
+	user.Email = strings.TrimSpace(user.Email)
+	if user.Email != "" && !tools.IsValidEmail(user.Email) {
+		errMap["email"] = errors.New("invalid email address used")
+	}
+
+	if user.NotificationFallback != "" &&
+		user.NotificationFallback != entity.NotificationFallbackSMS &&
+		user.NotificationFallback != entity.NotificationFallbackEmail &&
+		user.NotificationFallback != entity.NotificationFallbackNone {
+		errMap["notification_fallback"] = errors.New("invalid notification fallback selected")
+	} else if user.NotificationFallback == entity.NotificationFallbackEmail && user.Email == "" {
+		errMap["notification_fallback"] = errors.New("email notifications require an email address")
+	}
+
+	if user.Language != "" &&
+		user.Language != entity.LanguageEnglish &&
+		user.Language != entity.LanguageAmharic {
+		errMap["language"] = errors.New("invalid language selected")
+	}
+
 	// Meaning a new user is being add
 	if user.ID == "" {
 		if validPhoneNumber && !service.commonRepo.IsUnique("phone_number", user.PhoneNumber, "users") {